@@ -2,24 +2,236 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"strings"
 
-	"github.com/sivchari/gonverter/internal/gonverter"
+	"github.com/sivchari/gonverter"
 )
 
+const usage = `Usage:
+  gonverter [flags] <packages>         generate conversion code
+  gonverter [flags] status <packages>  list packages whose generated code is stale or edited
+  gonverter [flags] graph [-format dot|mermaid] <packages>
+                                       print the graph of conversions between types
+
+Flags:
+`
+
+type config struct {
+	format   string
+	cacheDir string
+	tags     []string
+	logger   *slog.Logger
+}
+
 func main() {
+	quiet := flag.Bool("q", false, "suppress progress output")
+	format := flag.String("format", "text", "output format: text or json")
+	cacheDir := flag.String("cache-dir", defaultCacheDir(), "generation cache directory; empty disables caching")
+	tags := flag.String("tags", "", "comma-separated list of extra build tags, combined with gonverter")
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+		flag.PrintDefaults()
+	}
 	flag.Parse()
 
 	args := flag.Args()
 	if len(args) == 0 {
-		fmt.Fprintln(os.Stderr, "Usage: gonverter <package>")
+		flag.Usage()
 		os.Exit(1)
 	}
 
-	if err := gonverter.Run(args[0]); err != nil {
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(os.Stderr, "Error: unknown format %q\n", *format)
+		os.Exit(1)
+	}
+
+	level := slog.LevelInfo
+	if *quiet {
+		level = slog.LevelWarn
+	}
+
+	cfg := &config{
+		format:   *format,
+		cacheDir: *cacheDir,
+		tags:     splitTags(*tags),
+		logger:   slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})),
+	}
+
+	var err error
+
+	switch args[0] {
+	case "status":
+		err = runStatus(cfg, args[1:])
+	case "graph":
+		err = runGraph(cfg, args[1:])
+	default:
+		err = runGenerate(cfg, args)
+	}
+
+	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 }
+
+func runGenerate(cfg *config, patterns []string) error {
+	res, err := gonverter.Generate(context.Background(), gonverter.Options{
+		Patterns: patterns,
+		Logger:   cfg.logger,
+		CacheDir: cfg.cacheDir,
+		Tags:     cfg.tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := res.WriteFiles(); err != nil {
+		return err
+	}
+
+	printDiagnostics(res.Diagnostics, cfg.format)
+
+	if res.HasErrors() {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func runStatus(cfg *config, patterns []string) error {
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	res, err := gonverter.Status(context.Background(), gonverter.Options{
+		Patterns: patterns,
+		Logger:   cfg.logger,
+		CacheDir: cfg.cacheDir,
+		Tags:     cfg.tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	printDiagnostics(res.Diagnostics, "text")
+
+	if cfg.format == "json" {
+		if err := printJSON(res.Packages); err != nil {
+			return err
+		}
+	} else {
+		for _, p := range res.Packages {
+			if p.State == gonverter.StateUpToDate {
+				continue
+			}
+
+			fmt.Printf("%-10s %s: %s\n", p.State, p.Path, p.Reason)
+		}
+	}
+
+	if len(res.Diagnostics) > 0 || !res.UpToDate() {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+func runGraph(cfg *config, args []string) error {
+	fs := flag.NewFlagSet("graph", flag.ExitOnError)
+	format := fs.String("format", string(gonverter.GraphDOT), "graph format: dot or mermaid")
+
+	patterns := parseInterspersed(fs, args)
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	res, err := gonverter.Generate(context.Background(), gonverter.Options{
+		Patterns: patterns,
+		Logger:   cfg.logger,
+		CacheDir: cfg.cacheDir,
+		Tags:     cfg.tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Missing hooks are drawn in the graph, so diagnostics do not fail the command.
+	printDiagnostics(res.Diagnostics, "text")
+
+	return res.WriteGraph(os.Stdout, gonverter.GraphFormat(*format))
+}
+
+// parseInterspersed parses the flags of fs wherever they appear in args and
+// returns the remaining positional arguments.
+func parseInterspersed(fs *flag.FlagSet, args []string) []string {
+	var positional []string
+
+	for {
+		_ = fs.Parse(args) // ExitOnError exits on failure.
+
+		if args = fs.Args(); len(args) == 0 {
+			return positional
+		}
+
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func splitTags(s string) []string {
+	var tags []string
+
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tags = append(tags, t)
+		}
+	}
+
+	return tags
+}
+
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "gonverter")
+}
+
+func printDiagnostics(diags []gonverter.Diagnostic, format string) {
+	if format == "json" {
+		if diags == nil {
+			diags = []gonverter.Diagnostic{}
+		}
+
+		if err := printJSON(diags); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		return
+	}
+
+	for _, d := range diags {
+		fmt.Fprintln(os.Stderr, d.String())
+	}
+}
+
+func printJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode JSON: %w", err)
+	}
+
+	return nil
+}
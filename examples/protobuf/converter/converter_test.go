@@ -0,0 +1,68 @@
+package converter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sivchari/gonverter/examples/protobuf/domain"
+	"github.com/sivchari/gonverter/examples/protobuf/pb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestConvertPbUserToDomainUser(t *testing.T) {
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	src := &pb.User{
+		Name:      "jd",
+		CreatedAt: timestamppb.New(created),
+		Timeout:   durationpb.New(time.Minute),
+		Nickname:  wrapperspb.String("j"),
+		Address:   &pb.Address{City: "Tokyo"},
+		Contact:   &pb.User_Phone{Phone: "555"},
+		Notify:    &pb.User_Webhook{Webhook: &pb.Webhook{Url: "https://example.com"}},
+	}
+
+	var dst domain.User
+
+	ConvertPbUserToDomainUser(src, &dst)
+
+	if dst.Name != "jd" || !dst.CreatedAt.Equal(created) || dst.Timeout != time.Minute || *dst.Nickname != "j" ||
+		dst.Age != nil || dst.Address.City != "Tokyo" || dst.Sms != nil || dst.Webhook.Url != "https://example.com" {
+		t.Fatalf("ConvertPbUserToDomainUser() = %+v", dst)
+	}
+
+	if c, ok := dst.Contact.(*domain.PhoneContact); !ok || c.Phone != "555" {
+		t.Errorf("Contact = %#v, want a *PhoneContact", dst.Contact)
+	}
+
+	// Unset messages become zero values rather than the Unix epoch.
+	ConvertPbUserToDomainUser(&pb.User{}, &dst)
+
+	if !dst.CreatedAt.IsZero() || dst.Timeout != 0 || dst.Nickname != nil || dst.Contact != nil || dst.Webhook != nil {
+		t.Errorf("ConvertPbUserToDomainUser(empty) = %+v", dst)
+	}
+}
+
+func TestConvertDomainUserToPbUser(t *testing.T) {
+	age, sms := int32(30), "555"
+	src := &domain.User{Name: "jd", Age: &age, Contact: domain.EmailContact{Email: "jd@example.com"}, Sms: &sms}
+
+	var dst pb.User
+
+	ConvertDomainUserToPbUser(src, &dst)
+
+	if dst.GetName() != "jd" || dst.GetCreatedAt() != nil || dst.GetAge().GetValue() != 30 || dst.GetNickname() != nil ||
+		dst.GetEmail() != "jd@example.com" || dst.GetSms() != "555" {
+		t.Fatalf("ConvertDomainUserToPbUser() = %+v", &dst)
+	}
+
+	// Pointers to implementations with value receivers are accepted too.
+	src.Contact = &domain.EmailContact{Email: "x@example.com"}
+
+	ConvertDomainUserToPbUser(src, &dst)
+
+	if dst.GetEmail() != "x@example.com" {
+		t.Errorf("GetEmail() = %q", dst.GetEmail())
+	}
+}
@@ -0,0 +1,2 @@
+// Package converter converts between protobuf messages and domain models.
+package converter
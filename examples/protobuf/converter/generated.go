@@ -0,0 +1,224 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:3bffc931427bdad9bcd96dad14da2bd52957654a3348fb821f19ceeb53ddc183
+// Checksum:      sha256:f5d5cf47ba25dac8ac720f7f9b45f97382c9a05fd9d9eb081c19469a06ecc40b
+
+package converter
+
+import (
+	"github.com/sivchari/gonverter/examples/protobuf/domain"
+	"github.com/sivchari/gonverter/examples/protobuf/pb"
+	"github.com/sivchari/gonverter/runtime"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	"time"
+)
+
+// ConvertPbUserToDomainUser converts pb.User to domain.User.
+// If src is nil, dst is left unchanged.
+func ConvertPbUserToDomainUser(src *pb.User, dst *domain.User) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.GetName()
+	dst.Tags = src.GetTags()
+	dst.CreatedAt = time.Time{}
+	if src.GetCreatedAt() != nil {
+		dst.CreatedAt = src.GetCreatedAt().AsTime()
+	}
+	dst.Timeout = src.GetTimeout().AsDuration()
+	dst.Nickname = nil
+	if src.GetNickname() != nil {
+		dst.Nickname = new(string)
+		*dst.Nickname = src.GetNickname().GetValue()
+	}
+	dst.Age = nil
+	if src.GetAge() != nil {
+		dst.Age = new(int32)
+		*dst.Age = src.GetAge().GetValue()
+	}
+	if src.GetAddress() != nil {
+		dst.Address = new(domain.Address)
+		ConvertPbAddressToDomainAddress(src.GetAddress(), dst.Address)
+	}
+	dst.Contact = nil
+	switch c := src.GetContact().(type) {
+	case *pb.User_Email:
+		v := new(domain.EmailContact)
+		ConvertPbUser_EmailToDomainEmailContact(c, v)
+		dst.Contact = *v
+	case *pb.User_Phone:
+		v := new(domain.PhoneContact)
+		ConvertPbUser_PhoneToDomainPhoneContact(c, v)
+		dst.Contact = v
+	}
+	dst.Sms = nil
+	if c, ok := src.GetNotify().(*pb.User_Sms); ok {
+		dst.Sms = new(string)
+		*dst.Sms = c.Sms
+	}
+	dst.Webhook = nil
+	if c, ok := src.GetNotify().(*pb.User_Webhook); ok {
+		dst.Webhook = new(domain.Webhook)
+		ConvertPbWebhookToDomainWebhook(c.Webhook, dst.Webhook)
+	}
+}
+
+// ConvertDomainUserToPbUser converts domain.User to pb.User.
+// If src is nil, dst is left unchanged.
+func ConvertDomainUserToPbUser(src *domain.User, dst *pb.User) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	dst.Tags = src.Tags
+	dst.CreatedAt = nil
+	if !src.CreatedAt.IsZero() {
+		dst.CreatedAt = timestamppb.New(src.CreatedAt)
+	}
+	dst.Timeout = durationpb.New(src.Timeout)
+	dst.Nickname = nil
+	if src.Nickname != nil {
+		dst.Nickname = wrapperspb.String(*src.Nickname)
+	}
+	dst.Age = nil
+	if src.Age != nil {
+		dst.Age = wrapperspb.Int32(*src.Age)
+	}
+	if src.Address != nil {
+		dst.Address = new(pb.Address)
+		ConvertDomainAddressToPbAddress(src.Address, dst.Address)
+	}
+	dst.Contact = nil
+	switch c := src.Contact.(type) {
+	case domain.EmailContact:
+		w := new(pb.User_Email)
+		ConvertDomainEmailContactToPbUser_Email(&c, w)
+		dst.Contact = w
+	case *domain.EmailContact:
+		w := new(pb.User_Email)
+		ConvertDomainEmailContactToPbUser_Email(c, w)
+		dst.Contact = w
+	case *domain.PhoneContact:
+		w := new(pb.User_Phone)
+		ConvertDomainPhoneContactToPbUser_Phone(c, w)
+		dst.Contact = w
+	}
+	dst.Notify = nil
+	if src.Sms != nil {
+		w := new(pb.User_Sms)
+		w.Sms = *src.Sms
+		dst.Notify = w
+	}
+	if src.Webhook != nil {
+		w := new(pb.User_Webhook)
+		w.Webhook = new(pb.Webhook)
+		ConvertDomainWebhookToPbWebhook(src.Webhook, w.Webhook)
+		dst.Notify = w
+	}
+}
+
+// ConvertPbAddressToDomainAddress converts pb.Address to domain.Address.
+// If src is nil, dst is left unchanged.
+func ConvertPbAddressToDomainAddress(src *pb.Address, dst *domain.Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.GetCity()
+	dst.ZipCode = src.GetZipCode()
+}
+
+// ConvertPbUser_EmailToDomainEmailContact converts pb.User_Email to domain.EmailContact.
+// If src is nil, dst is left unchanged.
+func ConvertPbUser_EmailToDomainEmailContact(src *pb.User_Email, dst *domain.EmailContact) {
+	if src == nil {
+		return
+	}
+
+	dst.Email = src.Email
+}
+
+// ConvertPbUser_PhoneToDomainPhoneContact converts pb.User_Phone to domain.PhoneContact.
+// If src is nil, dst is left unchanged.
+func ConvertPbUser_PhoneToDomainPhoneContact(src *pb.User_Phone, dst *domain.PhoneContact) {
+	if src == nil {
+		return
+	}
+
+	dst.Phone = src.Phone
+}
+
+// ConvertPbWebhookToDomainWebhook converts pb.Webhook to domain.Webhook.
+// If src is nil, dst is left unchanged.
+func ConvertPbWebhookToDomainWebhook(src *pb.Webhook, dst *domain.Webhook) {
+	if src == nil {
+		return
+	}
+
+	dst.Url = src.GetUrl()
+}
+
+// ConvertDomainAddressToPbAddress converts domain.Address to pb.Address.
+// If src is nil, dst is left unchanged.
+func ConvertDomainAddressToPbAddress(src *domain.Address, dst *pb.Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+	dst.ZipCode = src.ZipCode
+}
+
+// ConvertDomainEmailContactToPbUser_Email converts domain.EmailContact to pb.User_Email.
+// If src is nil, dst is left unchanged.
+func ConvertDomainEmailContactToPbUser_Email(src *domain.EmailContact, dst *pb.User_Email) {
+	if src == nil {
+		return
+	}
+
+	dst.Email = src.Email
+}
+
+// ConvertDomainPhoneContactToPbUser_Phone converts domain.PhoneContact to pb.User_Phone.
+// If src is nil, dst is left unchanged.
+func ConvertDomainPhoneContactToPbUser_Phone(src *domain.PhoneContact, dst *pb.User_Phone) {
+	if src == nil {
+		return
+	}
+
+	dst.Phone = src.Phone
+}
+
+// ConvertDomainWebhookToPbWebhook converts domain.Webhook to pb.Webhook.
+// If src is nil, dst is left unchanged.
+func ConvertDomainWebhookToPbWebhook(src *domain.Webhook, dst *pb.Webhook) {
+	if src == nil {
+		return
+	}
+
+	dst.Url = src.Url
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertPbUserToDomainUser)
+	runtime.AddConversion(s, ConvertDomainUserToPbUser)
+	runtime.AddConversion(s, ConvertPbAddressToDomainAddress)
+	runtime.AddConversion(s, ConvertPbUser_EmailToDomainEmailContact)
+	runtime.AddConversion(s, ConvertPbUser_PhoneToDomainPhoneContact)
+	runtime.AddConversion(s, ConvertPbWebhookToDomainWebhook)
+	runtime.AddConversion(s, ConvertDomainAddressToPbAddress)
+	runtime.AddConversion(s, ConvertDomainEmailContactToPbUser_Email)
+	runtime.AddConversion(s, ConvertDomainPhoneContactToPbUser_Phone)
+	runtime.AddConversion(s, ConvertDomainWebhookToPbWebhook)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,14 @@
+//go:build gonverter
+
+//go:generate go run ../../../cmd/gonverter .
+
+package converter
+
+import (
+	"github.com/sivchari/gonverter/examples/protobuf/domain"
+	"github.com/sivchari/gonverter/examples/protobuf/pb"
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// Register conversions between the message and the domain model
+var _ = runtime.RegisterBidirectional[*pb.User, *domain.User]()
@@ -0,0 +1,49 @@
+// Package domain provides domain models.
+package domain
+
+import "time"
+
+// User is domain model.
+type User struct {
+	Name      string
+	Tags      []string
+	CreatedAt time.Time
+	Timeout   time.Duration
+	Nickname  *string
+	Age       *int32
+	Address   *Address
+	Contact   Contact
+	// Sms and Webhook are alternatives; at most one is set.
+	Sms     *string
+	Webhook *Webhook
+}
+
+// Address is domain model for address.
+type Address struct {
+	City    string
+	ZipCode string
+}
+
+// Contact is how a user is reached: an EmailContact or a *PhoneContact.
+type Contact interface {
+	isContact()
+}
+
+// EmailContact is a Contact by email.
+type EmailContact struct {
+	Email string
+}
+
+func (EmailContact) isContact() {}
+
+// PhoneContact is a Contact by phone.
+type PhoneContact struct {
+	Phone string
+}
+
+func (*PhoneContact) isContact() {}
+
+// Webhook is domain model for a notification endpoint.
+type Webhook struct {
+	Url string
+}
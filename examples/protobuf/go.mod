@@ -0,0 +1,10 @@
+module github.com/sivchari/gonverter/examples/protobuf
+
+go 1.24.0
+
+require (
+	github.com/sivchari/gonverter v0.0.0
+	google.golang.org/protobuf v1.36.6
+)
+
+replace github.com/sivchari/gonverter => ../..
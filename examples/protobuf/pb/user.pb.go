@@ -0,0 +1,214 @@
+// Package pb holds messages in the shape protoc-gen-go generates them for
+// user.proto, trimmed to the struct fields, getters and oneof wrappers that
+// gonverter reads.
+//
+//	message User {
+//	  string name = 1;
+//	  repeated string tags = 2;
+//	  google.protobuf.Timestamp created_at = 3;
+//	  google.protobuf.Duration timeout = 4;
+//	  google.protobuf.StringValue nickname = 5;
+//	  google.protobuf.Int32Value age = 6;
+//	  Address address = 7;
+//	  oneof contact {
+//	    string email = 8;
+//	    string phone = 9;
+//	  }
+//	  oneof notify {
+//	    string sms = 10;
+//	    Webhook webhook = 11;
+//	  }
+//	}
+package pb
+
+import (
+	"google.golang.org/protobuf/runtime/protoimpl"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type User struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string                  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Tags      []string                `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	CreatedAt *timestamppb.Timestamp  `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Timeout   *durationpb.Duration    `protobuf:"bytes,4,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	Nickname  *wrapperspb.StringValue `protobuf:"bytes,5,opt,name=nickname,proto3" json:"nickname,omitempty"`
+	Age       *wrapperspb.Int32Value  `protobuf:"bytes,6,opt,name=age,proto3" json:"age,omitempty"`
+	Address   *Address                `protobuf:"bytes,7,opt,name=address,proto3" json:"address,omitempty"`
+	// Types that are valid to be assigned to Contact:
+	//
+	//	*User_Email
+	//	*User_Phone
+	Contact isUser_Contact `protobuf_oneof:"contact"`
+	// Types that are valid to be assigned to Notify:
+	//
+	//	*User_Sms
+	//	*User_Webhook
+	Notify isUser_Notify `protobuf_oneof:"notify"`
+}
+
+func (x *User) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *User) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *User) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *User) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
+func (x *User) GetNickname() *wrapperspb.StringValue {
+	if x != nil {
+		return x.Nickname
+	}
+	return nil
+}
+
+func (x *User) GetAge() *wrapperspb.Int32Value {
+	if x != nil {
+		return x.Age
+	}
+	return nil
+}
+
+func (x *User) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *User) GetContact() isUser_Contact {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *User) GetEmail() string {
+	if x, ok := x.GetContact().(*User_Email); ok {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *User) GetPhone() string {
+	if x, ok := x.GetContact().(*User_Phone); ok {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *User) GetNotify() isUser_Notify {
+	if x != nil {
+		return x.Notify
+	}
+	return nil
+}
+
+func (x *User) GetSms() string {
+	if x, ok := x.GetNotify().(*User_Sms); ok {
+		return x.Sms
+	}
+	return ""
+}
+
+func (x *User) GetWebhook() *Webhook {
+	if x, ok := x.GetNotify().(*User_Webhook); ok {
+		return x.Webhook
+	}
+	return nil
+}
+
+type isUser_Contact interface {
+	isUser_Contact()
+}
+
+type User_Email struct {
+	Email string `protobuf:"bytes,8,opt,name=email,proto3,oneof"`
+}
+
+type User_Phone struct {
+	Phone string `protobuf:"bytes,9,opt,name=phone,proto3,oneof"`
+}
+
+func (*User_Email) isUser_Contact() {}
+
+func (*User_Phone) isUser_Contact() {}
+
+type isUser_Notify interface {
+	isUser_Notify()
+}
+
+type User_Sms struct {
+	Sms string `protobuf:"bytes,10,opt,name=sms,proto3,oneof"`
+}
+
+type User_Webhook struct {
+	Webhook *Webhook `protobuf:"bytes,11,opt,name=webhook,proto3,oneof"`
+}
+
+func (*User_Sms) isUser_Notify() {}
+
+func (*User_Webhook) isUser_Notify() {}
+
+type Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	City    string `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	ZipCode string `protobuf:"bytes,2,opt,name=zip_code,json=zipCode,proto3" json:"zip_code,omitempty"`
+}
+
+func (x *Address) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Address) GetZipCode() string {
+	if x != nil {
+		return x.ZipCode
+	}
+	return ""
+}
+
+type Webhook struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+}
+
+func (x *Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
@@ -1,13 +1,20 @@
 // Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:ae9018e415894e29e515e8ab719514b6c11fceca7e14acdbd7b82d119985b751
+// Checksum:      sha256:c21630646653f392462a4791b6ae1cf0572d4c124079de3f6f30e41ab990deb1
 
 package converter
 
 import (
 	"github.com/sivchari/gonverter/examples/simple/domain"
 	"github.com/sivchari/gonverter/examples/simple/handler"
+	"github.com/sivchari/gonverter/runtime"
 )
 
-// ConvertUserRequestToUser converts UserRequest to User
+// ConvertUserRequestToUser converts handler.UserRequest to domain.User.
+// If src is nil, dst is left unchanged.
 func ConvertUserRequestToUser(src *handler.UserRequest, dst *domain.User) {
 	if src == nil {
 		return
@@ -19,7 +26,34 @@ func ConvertUserRequestToUser(src *handler.UserRequest, dst *domain.User) {
 	ConvertAddressRequestToAddress(&src.Address, &dst.Address)
 }
 
-// ConvertAddressRequestToAddress converts AddressRequest to Address
+// ToUser returns src converted to a new domain.User, or nil if src is nil.
+func ToUser(src *handler.UserRequest) *domain.User {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(domain.User)
+	ConvertUserRequestToUser(src, dst)
+
+	return dst
+}
+
+// ToUsers converts every element of src to domain.User. It returns nil if src is nil.
+func ToUsers(src []handler.UserRequest) []domain.User {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]domain.User, len(src))
+	for i := range src {
+		ConvertUserRequestToUser(&src[i], &dst[i])
+	}
+
+	return dst
+}
+
+// ConvertAddressRequestToAddress converts handler.AddressRequest to domain.Address.
+// If src is nil, dst is left unchanged.
 func ConvertAddressRequestToAddress(src *handler.AddressRequest, dst *domain.Address) {
 	if src == nil {
 		return
@@ -28,3 +62,13 @@ func ConvertAddressRequestToAddress(src *handler.AddressRequest, dst *domain.Add
 	ConvertAddressRequestCityToAddressCity(src, dst)
 	dst.ZipCode = src.ZipCode
 }
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertAddressRequestToAddress)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
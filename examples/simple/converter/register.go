@@ -10,5 +10,5 @@ import (
 	"github.com/sivchari/gonverter/runtime"
 )
 
-// Register conversion pair
-var _ = runtime.Register[*handler.UserRequest, *domain.User]()
+// Register conversion pair, with a ToUser constructor
+var _ = runtime.Register[*handler.UserRequest, *domain.User](runtime.WithConstructor())
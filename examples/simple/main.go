@@ -5,7 +5,6 @@ import (
 	"fmt"
 
 	"github.com/sivchari/gonverter/examples/simple/converter"
-	"github.com/sivchari/gonverter/examples/simple/domain"
 	"github.com/sivchari/gonverter/examples/simple/handler"
 )
 
@@ -22,8 +21,7 @@ func main() {
 	}
 
 	// Convert
-	user := &domain.User{}
-	converter.ConvertUserRequestToUser(req, user)
+	user := converter.ToUser(req)
 
 	// Show result
 	fmt.Printf("Name:    %s\n", user.Name)
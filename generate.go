@@ -0,0 +1,99 @@
+package gonverter
+
+import (
+	"context"
+
+	"github.com/sivchari/gonverter/internal/gonverter"
+)
+
+// Options configures a generation run.
+type Options = gonverter.Options
+
+// Result holds everything produced by a generation run.
+type Result = gonverter.Result
+
+// File is a generated Go source file.
+type File = gonverter.File
+
+// Diagnostic is a problem reported during generation.
+type Diagnostic = gonverter.Diagnostic
+
+// Severity classifies a diagnostic.
+type Severity = gonverter.Severity
+
+// Diagnostic severities.
+const (
+	SeverityError   = gonverter.SeverityError
+	SeverityWarning = gonverter.SeverityWarning
+)
+
+// PairPlan describes a generated conversion function.
+type PairPlan = gonverter.PairPlan
+
+// FieldPlan describes how a single destination field is filled.
+type FieldPlan = gonverter.FieldPlan
+
+// MappingKind describes how a destination field is filled.
+type MappingKind = gonverter.MappingKind
+
+// Mapping kinds.
+const (
+	MappingAssign  = gonverter.MappingAssign
+	MappingCustom  = gonverter.MappingCustom
+	MappingNested  = gonverter.MappingNested
+	MappingPointer = gonverter.MappingPointer
+	MappingSlice   = gonverter.MappingSlice
+	MappingMap     = gonverter.MappingMap
+	MappingBuiltin = gonverter.MappingBuiltin
+	MappingOneof   = gonverter.MappingOneof
+	MappingFactory = gonverter.MappingFactory
+)
+
+// Generate runs code generation for the packages matched by opts.Patterns.
+// Generated files are returned in memory; call [Result.WriteFiles] to write them to disk.
+//
+// When opts.CacheDir is set, packages whose sources and dependencies are
+// unchanged since a previous run are served from the cache without being loaded.
+//
+// Problems found in the loaded packages are reported as diagnostics in the result.
+// The returned error is reserved for failures of the generator itself.
+func Generate(ctx context.Context, opts Options) (*Result, error) {
+	//nolint:wrapcheck // The internal package is an implementation detail of this API.
+	return gonverter.Generate(ctx, opts)
+}
+
+// GraphFormat is an output format of [Result.WriteGraph].
+type GraphFormat = gonverter.GraphFormat
+
+// Graph formats.
+const (
+	GraphDOT     = gonverter.GraphDOT
+	GraphMermaid = gonverter.GraphMermaid
+)
+
+// FileState is the state of a generated file relative to its inputs.
+type FileState = gonverter.FileState
+
+// File states.
+const (
+	StateUpToDate = gonverter.StateUpToDate
+	StateStale    = gonverter.StateStale
+	StateModified = gonverter.StateModified
+	StateMissing  = gonverter.StateMissing
+)
+
+// PackageStatus is the state of the generated file of one package.
+type PackageStatus = gonverter.PackageStatus
+
+// StatusResult holds the outcome of a status check.
+type StatusResult = gonverter.StatusResult
+
+// Status reports, for every matched package with registrations, whether its
+// generated file is up to date, stale, hand-edited or missing. It compares the
+// fingerprint header of the generated file with the current inputs without
+// regenerating anything. When opts.CacheDir is set, packages whose sources are
+// unchanged since they were generated are checked without being type-checked.
+func Status(ctx context.Context, opts Options) (*StatusResult, error) {
+	//nolint:wrapcheck // The internal package is an implementation detail of this API.
+	return gonverter.Status(ctx, opts)
+}
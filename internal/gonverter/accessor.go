@@ -0,0 +1,160 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// accessorPatterns checks the accessor name patterns given to
+// runtime.WithAccessorNames, and returns them, or "" if they are invalid.
+func (g *generator) accessorPatterns(expr ast.Expr, patterns string, several bool) string {
+	if patterns == "" {
+		return ""
+	}
+
+	list := strings.Split(patterns, ",")
+	if len(list) > 1 && !several {
+		g.errorf(expr.Pos(), "invalid accessor pattern %q: only getters take several patterns", patterns)
+
+		return ""
+	}
+
+	for _, pattern := range list {
+		name := strings.Replace(pattern, "{Field}", "Name", 1)
+		if strings.Count(pattern, "{Field}") != 1 || !token.IsIdentifier(name) || !token.IsExported(name) {
+			g.errorf(expr.Pos(), "invalid accessor pattern %q: must contain {Field} once and form an exported identifier", pattern)
+
+			return ""
+		}
+	}
+
+	return patterns
+}
+
+// accessor is a method standing for a field a type has no exported field for.
+type accessor struct {
+	field    *types.Var // the field the method stands for
+	method   string
+	fallible bool // the setter returns an error
+}
+
+// getterFor returns the getter of the field name of the source of pair, the
+// first method of its method set named after one of the getter patterns that
+// takes no argument and returns a single value. Otherwise, it returns the
+// first method named like a getter, if any, as misfit.
+func getterFor(pair *conversionPair, name string) (getter accessor, ok bool, misfit *types.Func) {
+	if pair.opts.getters == "" {
+		return accessor{}, false, nil
+	}
+
+	mset := types.NewMethodSet(pointerTo(derefType(pair.from.typ)))
+
+	for _, pattern := range strings.Split(pair.opts.getters, ",") {
+		method := strings.Replace(pattern, "{Field}", name, 1)
+
+		sel := mset.Lookup(nil, method)
+		if sel == nil {
+			continue
+		}
+
+		sig := sel.Type().(*types.Signature)
+		if sig.Params().Len() != 0 || sig.Results().Len() != 1 {
+			if misfit == nil {
+				misfit = sel.Obj().(*types.Func)
+			}
+
+			continue
+		}
+
+		field := types.NewField(sel.Obj().Pos(), sel.Obj().Pkg(), name, sig.Results().At(0).Type(), false)
+
+		return accessor{field: field, method: method}, true, nil
+	}
+
+	return accessor{}, false, misfit
+}
+
+// settersOf returns the setters of the destination of pair for the fields it
+// has no exported field for, in the order of its method set. Setters take a
+// single argument and return nothing or an error. Only the setters of fields
+// the source has, as reported by hasSource, are returned: other methods may
+// be named like setters too.
+func (g *generator) settersOf(pair *conversionPair, dst *types.Struct, hasSource func(name string) bool) []accessor {
+	if pair.opts.setter == "" {
+		return nil
+	}
+
+	prefix, suffix, _ := strings.Cut(pair.opts.setter, "{Field}")
+	mset := types.NewMethodSet(pointerTo(derefType(pair.to.typ)))
+
+	var setters []accessor
+
+	for i := 0; i < mset.Len(); i++ {
+		fn := mset.At(i).Obj()
+
+		method := fn.Name()
+		if len(method) <= len(prefix)+len(suffix) || !strings.HasPrefix(method, prefix) || !strings.HasSuffix(method, suffix) {
+			continue
+		}
+
+		name := method[len(prefix) : len(method)-len(suffix)]
+		if !token.IsExported(name) || findField(dst, name) != nil || !hasSource(name) {
+			continue
+		}
+
+		sig := fn.Type().(*types.Signature)
+		fallible := sig.Results().Len() == 1 && isErrorType(sig.Results().At(0).Type())
+
+		if sig.Params().Len() != 1 || sig.Variadic() || (sig.Results().Len() != 0 && !fallible) {
+			g.warnf(fn.Pos(), "method %s of %s is not a setter: it must take a single argument and return nothing or an error",
+				method, typeString(derefType(pair.to.typ)))
+
+			continue
+		}
+
+		field := types.NewField(fn.Pos(), fn.Pkg(), name, sig.Params().At(0).Type(), false)
+		setters = append(setters, accessor{field: field, method: method, fallible: fallible})
+	}
+
+	return setters
+}
+
+// callSetter makes m, which fills the variable local, set the field of
+// setter to its value. Hooks set the field themselves.
+func (g *generator) callSetter(m fieldMapping, vars mappingVars, local string, setter accessor) fieldMapping {
+	if m.plan.Kind == MappingCustom || m.code == "" {
+		return m
+	}
+
+	name := setter.field.Name()
+	stmts, value := g.localValue(m.code, local, setter.field.Type())
+
+	call := fmt.Sprintf("%s.%s(%s)", vars.dst, setter.method, value)
+	if setter.fallible {
+		call = fmt.Sprintf(`if err := %s; err != nil {
+		return &runtime.FieldError{Path: %q, Err: err}
+	}`, call, name)
+		m.fallible, m.runtime = true, true
+	}
+
+	m.code = call
+	if stmts != "" {
+		m.code = fmt.Sprintf("{\n%s\n%s\n}", stmts, call)
+	}
+
+	return m
+}
+
+// localValue returns the statements declaring the variable local of type t
+// and filling it with code, and the expression of its value. Plain
+// assignments are reduced to the assigned value, without statements.
+func (g *generator) localValue(code, local string, t types.Type) (stmts, value string) {
+	if value, ok := strings.CutPrefix(code, local+" = "); ok && !strings.Contains(value, "\n") && !strings.Contains(value, local) {
+		return "", value
+	}
+
+	return fmt.Sprintf("var %s %s\n%s", local, g.typeExpr(t), code), local
+}
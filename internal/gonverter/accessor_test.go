@@ -0,0 +1,81 @@
+package gonverter
+
+import (
+	"context"
+	"go/ast"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+func TestAccessorPatterns(t *testing.T) {
+	tests := []struct {
+		patterns string
+		several  bool
+		want     string
+	}{
+		{"{Field},Get{Field}", true, "{Field},Get{Field}"},
+		{"Set{Field}", false, "Set{Field}"},
+		{"", false, ""},
+		{"Set{Field},Put{Field}", false, ""},
+		{"Get", true, ""},
+		{"{Field}{Field}", true, ""},
+		{"get{Field}", true, ""},
+	}
+
+	for _, tt := range tests {
+		g := &generator{result: &Result{}}
+		if got := g.accessorPatterns(&ast.BasicLit{}, tt.patterns, tt.several); got != tt.want {
+			t.Errorf("accessorPatterns(%q) = %q, want %q", tt.patterns, got, tt.want)
+		}
+
+		if wantErr := tt.want != tt.patterns; g.result.HasErrors() != wantErr {
+			t.Errorf("accessorPatterns(%q) diagnostics = %v", tt.patterns, g.result.Diagnostics)
+		}
+	}
+}
+
+func TestLocalValue(t *testing.T) {
+	tests := []struct {
+		code, stmts, value string
+	}{
+		{"dstAddress = src.GetAddress()", "", "src.GetAddress()"},
+		{"dstAddress = src.GetAddressLine()", "", "src.GetAddressLine()"},
+		{"dstAddress = append(dstAddress, src.Lines...)", "var dstAddress string\ndstAddress = append(dstAddress, src.Lines...)", "dstAddress"},
+		{"if src.Address != nil {\ndstAddress = *src.Address\n}", "var dstAddress string\nif src.Address != nil {\ndstAddress = *src.Address\n}", "dstAddress"},
+	}
+
+	for _, tt := range tests {
+		g := &generator{}
+		if stmts, value := g.localValue(tt.code, "dstAddress", types.Typ[types.String]); stmts != tt.stmts || value != tt.value {
+			t.Errorf("localValue(%q) = %q, %q, want %q, %q", tt.code, stmts, value, tt.stmts, tt.value)
+		}
+	}
+}
+
+func TestGenerateAccessorPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/accessor"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 1 || !strings.Contains(res.Diagnostics[0].Message, "Nickname of accessor.Customer is not a getter") {
+		t.Errorf("Diagnostics = %v, want a warning for Nickname", res.Diagnostics)
+	}
+
+	for _, p := range res.Plan {
+		switch p.Func {
+		case "ConvertCustomerDTOToCustomer":
+			// Customer has no setter for Nickname, and SetEmail returns an error.
+			if len(p.Fields) != 4 || !p.Fallible {
+				t.Errorf("%s fields = %+v, fallible = %v, want 4 fallible", p.Func, p.Fields, p.Fallible)
+			}
+		case "ConvertCustomerToCustomerDTO":
+			for _, f := range p.Fields {
+				if f.Dst == "Nickname" && f.Kind != MappingCustom {
+					t.Errorf("field Nickname = %+v, want the hook", f)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,390 @@
+package gonverter
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/build"
+	"go/token"
+	"go/types"
+	"log/slog"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const defaultOutputName = "generated.go"
+
+// Options configures a generation run.
+type Options struct {
+	// Patterns are the package patterns to generate code for, as accepted by go list.
+	Patterns []string
+	// Dir is the directory in which packages are resolved. Defaults to the current directory.
+	Dir string
+	// OutputName is the name of the file generated in each package. Defaults to "generated.go".
+	OutputName string
+	// Logger receives progress messages. Defaults to a logger that discards everything.
+	Logger *slog.Logger
+	// Tags are extra build tags, combined with the gonverter tag, used to select
+	// source files and to evaluate the build constraints of registration files.
+	Tags []string
+	// GOOS and GOARCH select the target platform files and constraints are
+	// evaluated for. They default to the environment, as with go build.
+	GOOS, GOARCH string
+	// CacheDir is the directory of the on-disk generation cache. Packages whose
+	// inputs are unchanged since they were cached are not loaded or generated again.
+	// Caching is disabled when empty.
+	CacheDir string
+	// Version is the generator version. It is part of the cache key so that a new
+	// release never reuses output of an older one. Defaults to [Version].
+	Version string
+}
+
+func (o *Options) setDefaults() error {
+	if len(o.Patterns) == 0 {
+		return errors.New("no package patterns given")
+	}
+
+	if o.OutputName == "" {
+		o.OutputName = defaultOutputName
+	}
+
+	if o.Logger == nil {
+		o.Logger = slog.New(slog.DiscardHandler)
+	}
+
+	if o.Version == "" {
+		o.Version = Version
+	}
+
+	if o.GOOS == "" {
+		o.GOOS = build.Default.GOOS
+	}
+
+	if o.GOARCH == "" {
+		o.GOARCH = build.Default.GOARCH
+	}
+
+	return nil
+}
+
+// Result holds everything produced by a generation run.
+type Result struct {
+	// Files are the generated files, one per package containing registrations.
+	Files []File
+	// Diagnostics are the problems found while generating.
+	Diagnostics []Diagnostic
+	// Plan describes every generated conversion function and how its fields are mapped.
+	Plan []PairPlan
+}
+
+// File is a generated Go source file.
+type File struct {
+	// Path is the absolute path the file is meant to be written to.
+	Path string
+	// PkgPath is the import path of the package the file belongs to.
+	PkgPath string
+	// Content is the formatted Go source.
+	Content []byte
+}
+
+// MappingKind describes how a destination field is filled.
+type MappingKind string
+
+// Mapping kinds.
+const (
+	MappingAssign  MappingKind = "assign"
+	MappingCustom  MappingKind = "custom"
+	MappingNested  MappingKind = "nested"
+	MappingPointer MappingKind = "pointer"
+	MappingSlice   MappingKind = "slice"
+	MappingMap     MappingKind = "map"
+	MappingBuiltin MappingKind = "builtin"
+	MappingOneof   MappingKind = "oneof"
+	MappingFactory MappingKind = "factory"
+)
+
+// PairPlan describes a generated conversion function.
+type PairPlan struct {
+	// Func is the name of the generated function.
+	Func string
+	// PkgPath is the import path of the package the function is generated in.
+	PkgPath string
+	// From and To are the qualified source and destination type names. From is
+	// empty for conversions from several Sources, and To for conversions to
+	// several Destinations.
+	From, To string
+	// Sources lists the qualified names of the source types of a many-to-one
+	// conversion, as registered with Register2 or Register3, in parameter order.
+	Sources []string
+	// Conflicts lists the fields of a many-to-one conversion found in more than
+	// one source without a WithPrecedence order saying which one they are taken from.
+	Conflicts []string
+	// Destinations lists the qualified names of the destination types of a split
+	// conversion, as registered with RegisterSplit2 or RegisterSplit3, in parameter order.
+	Destinations []string
+	// Unmapped lists the source fields of a split conversion that none of its
+	// destinations has.
+	Unmapped []string
+	// Fields lists the destination fields in declaration order.
+	Fields []FieldPlan
+	// Via lists the qualified names of the intermediate types a composed
+	// conversion goes through, in order. Such conversions have no Fields.
+	Via []string
+	// Nested reports whether the pair was discovered through a field of another
+	// pair rather than registered.
+	Nested bool
+	// Patch reports whether Func applies the fields set in the source to an
+	// existing destination, as registered with RegisterPatch.
+	Patch bool
+	// Masked reports whether Func converts only the fields selected by a
+	// runtime.FieldMask, as generated with WithFieldMask. Its Fields are the
+	// paths the mask may select at this level.
+	Masked bool
+	// Lossy lists the fields that both ends of a composed conversion have but
+	// an intermediate type drops.
+	Lossy []string
+	// Hooks lists the Before and After hooks Func calls around the field mappings.
+	Hooks []string
+	// Fallible reports whether Func returns an error, because it or a function
+	// it calls calls a hook that returns one.
+	Fallible bool
+	// Validated reports whether Func validates the destination and the values
+	// nested in it, as registered with WithValidation.
+	Validated bool
+	// Enum reports whether Func converts between the constants of two enum
+	// types, as registered with RegisterEnum. Enum conversions have no Fields.
+	Enum bool
+	// Defaulter is the SetDefaults function Func calls on the destination, as
+	// registered with WithDefaults.
+	Defaulter string
+	// Constructors lists the constructor functions generated alongside Func.
+	Constructors []string
+	// Methods lists the methods generated alongside Func, as Type.Method.
+	Methods []string
+	// Factory is the function creating the destination, registered with WithFactory.
+	Factory string
+}
+
+// FieldPlan describes how a single destination field is filled.
+type FieldPlan struct {
+	// Src is the source field name, or empty when the source has no such field.
+	Src string
+	// Source is the qualified name of the source type Src is read from, for
+	// conversions from several sources.
+	Source string
+	// Destination is the qualified name of the destination type Dst belongs to,
+	// for conversions to several destinations.
+	Destination string
+	// Dst is the destination field name.
+	Dst string
+	// Kind is the mapping strategy.
+	Kind MappingKind
+	// Func is the conversion function called for the field, if any.
+	Func string
+	// Missing reports whether Func is a hook that has not been implemented yet.
+	Missing bool
+}
+
+// HasErrors reports whether any diagnostic has error severity.
+func (r *Result) HasErrors() bool {
+	for _, d := range r.Diagnostics {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Err returns the error diagnostics joined into a single error, or nil if there are none.
+func (r *Result) Err() error {
+	var errs []error
+
+	for _, d := range r.Diagnostics {
+		if d.Severity == SeverityError {
+			errs = append(errs, errors.New(d.String()))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// WriteFiles writes all generated files to disk. Files whose content is
+// already up to date are left untouched.
+func (r *Result) WriteFiles() error {
+	for _, f := range r.Files {
+		if cur, err := os.ReadFile(f.Path); err == nil && bytes.Equal(cur, f.Content) {
+			continue
+		}
+
+		if err := os.WriteFile(f.Path, f.Content, 0o600); err != nil {
+			return fmt.Errorf("failed to write file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Generate runs code generation for the packages matched by opts.Patterns and
+// returns the generated files in memory. Nothing is written to disk.
+func Generate(ctx context.Context, opts Options) (*Result, error) {
+	if err := opts.setDefaults(); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		fset:   token.NewFileSet(),
+		logger: opts.Logger,
+	}
+
+	return g.run(ctx, &opts)
+}
+
+// Run generates code for the given package pattern and writes it to disk.
+// Files are written even when diagnostics contain errors; those are returned afterwards.
+func Run(pattern string) error {
+	res, err := Generate(context.Background(), Options{Patterns: []string{pattern}})
+	if err != nil {
+		return err
+	}
+
+	if err := res.WriteFiles(); err != nil {
+		return err
+	}
+
+	return res.Err()
+}
+
+func (g *generator) run(ctx context.Context, opts *Options) (*Result, error) {
+	var (
+		c       *cache
+		keys    []packageKey
+		results = make(map[string]*Result)
+	)
+
+	patterns := opts.Patterns
+
+	if opts.CacheDir != "" {
+		c = &cache{dir: opts.CacheDir}
+
+		var err error
+		if keys, err = packageKeys(ctx, opts); err != nil {
+			return nil, err
+		}
+
+		patterns = nil
+
+		for _, k := range keys {
+			if k.key != "" {
+				if res, ok := c.get(k.key); ok {
+					g.logger.Info("unchanged, using cache", "package", k.pkgPath)
+					results[k.pkgPath] = res
+
+					continue
+				}
+			}
+
+			patterns = append(patterns, k.pkgPath)
+		}
+	}
+
+	var order []string
+
+	if len(patterns) > 0 {
+		pkgs, err := g.load(ctx, opts, patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range pkgs {
+			res, err := g.generatePackage(pkg, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			results[pkg.PkgPath] = res
+			order = append(order, pkg.PkgPath)
+		}
+	}
+
+	if c != nil {
+		order = order[:0]
+
+		for _, k := range keys {
+			order = append(order, k.pkgPath)
+
+			if res := results[k.pkgPath]; res != nil && k.key != "" && !res.HasErrors() {
+				if err := c.put(k.key, res); err != nil {
+					g.logger.Warn("failed to update cache", "package", k.pkgPath, "error", err)
+				}
+			}
+		}
+	}
+
+	merged := &Result{}
+
+	for _, pkgPath := range order {
+		if res := results[pkgPath]; res != nil {
+			merged.Files = append(merged.Files, res.Files...)
+			merged.Diagnostics = append(merged.Diagnostics, res.Diagnostics...)
+			merged.Plan = append(merged.Plan, res.Plan...)
+		}
+	}
+
+	if len(merged.Files) == 0 && !merged.HasErrors() {
+		g.logger.Info("no conversion pairs found")
+	}
+
+	return merged, nil
+}
+
+// generatePackage generates the output file of a single package.
+func (g *generator) generatePackage(pkg *packages.Package, opts *Options) (*Result, error) {
+	g.result = &Result{}
+	g.pkgPath = pkg.PkgPath
+
+	in, ok := g.parse(pkg, opts)
+	if !ok || len(in.pairs) == 0 && len(in.defaults) == 0 {
+		return g.result, nil
+	}
+
+	g.logger.Info("found conversion pairs", "package", in.pkgPath, "count", len(in.pairs))
+
+	// Hooks and nested pairs are scoped to the package being generated.
+	g.customFuncs = in.customFuncs
+	g.objectHooks = in.objectHooks
+	g.hookFuncs = in.hookFuncs
+	g.generatedPairs = make(map[string]bool)
+	g.graph = g.newConversionGraph(&in)
+	g.constructors = make(map[string]string)
+	g.validators = make(map[string]bool)
+	g.validatorFuncs = nil
+	g.validates = make(map[*types.Named]bool)
+	g.defaultHooks = in.defaultHooks
+	g.defaulters = make(map[string]*types.Named)
+	g.defaulterFuncs = nil
+	g.defaults = make(map[*types.Named]bool)
+	g.enums = make(map[string]*conversionPair)
+
+	for i := range in.pairs {
+		if pair := &in.pairs[i]; pair.enum {
+			g.enums[g.pairKey(pair)] = pair
+		}
+	}
+
+	code, err := g.generate(&in)
+	if err != nil {
+		return nil, err
+	}
+
+	code = stampHeader(code, g.fingerprint(pkg, &in, opts))
+
+	path := filepath.Join(in.dir, opts.OutputName)
+	g.result.Files = append(g.result.Files, File{Path: path, PkgPath: in.pkgPath, Content: code})
+	g.logger.Info("generated", "path", path)
+
+	return g.result, nil
+}
@@ -0,0 +1,140 @@
+package gonverter
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerateReturnsFilesInMemory(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/nested"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Files) != 1 {
+		t.Fatalf("len(Files) = %d, want 1", len(res.Files))
+	}
+
+	f := res.Files[0]
+	if filepath.Base(f.Path) != "generated.go" {
+		t.Errorf("Path = %q, want generated.go", f.Path)
+	}
+
+	want, err := os.ReadFile(f.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(f.Content, want) {
+		t.Errorf("Content differs from %s", f.Path)
+	}
+}
+
+func TestGeneratePlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/pointer"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Plan) != 2 {
+		t.Fatalf("len(Plan) = %d, want 2", len(res.Plan))
+	}
+
+	p := res.Plan[0]
+	if p.Func != "ConvertUserRequestToUser" {
+		t.Errorf("Func = %q, want %q", p.Func, "ConvertUserRequestToUser")
+	}
+
+	if p.From != "github.com/sivchari/gonverter/testdata/pointer.UserRequest" {
+		t.Errorf("From = %q", p.From)
+	}
+
+	want := []FieldPlan{
+		{Src: "Name", Dst: "Name", Kind: MappingAssign},
+		{Src: "Profile", Dst: "Profile", Kind: MappingPointer, Func: "ConvertProfileRequestToProfile"},
+	}
+
+	if len(p.Fields) != len(want) {
+		t.Fatalf("len(Fields) = %d, want %d", len(p.Fields), len(want))
+	}
+
+	for i := range want {
+		if p.Fields[i] != want[i] {
+			t.Errorf("Fields[%d] = %+v, want %+v", i, p.Fields[i], want[i])
+		}
+	}
+}
+
+func TestGenerateCustomFieldPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../examples/simple/converter"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got := res.Plan[0].Fields[0]
+	want := FieldPlan{Dst: "Name", Kind: MappingCustom, Func: "ConvertUserRequestNameToUserName"}
+
+	if got != want {
+		t.Errorf("Fields[0] = %+v, want %+v", got, want)
+	}
+}
+
+func TestGenerateHubPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/hub"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	plans := make(map[string]PairPlan)
+	for _, p := range res.Plan {
+		plans[p.Func] = p
+	}
+
+	const pkg = "github.com/sivchari/gonverter/testdata/hub"
+
+	spoke, ok := plans["ConvertV1UserToUser"]
+	if !ok {
+		t.Fatal("ConvertV1UserToUser not planned")
+	}
+
+	if spoke.From != pkg+"/v1.User" || spoke.To != pkg+".User" || len(spoke.Via) != 0 {
+		t.Errorf("ConvertV1UserToUser = %+v", spoke)
+	}
+
+	composed, ok := plans["ConvertV1UserToV3User"]
+	if !ok {
+		t.Fatal("ConvertV1UserToV3User not planned")
+	}
+
+	if len(composed.Via) != 1 || composed.Via[0] != pkg+".User" || len(composed.Fields) != 0 {
+		t.Errorf("ConvertV1UserToV3User = %+v, want Via [%s.User] and no fields", composed, pkg)
+	}
+
+	if _, ok := plans["ConvertV2AddressToAddress"]; !ok {
+		t.Error("nested pairs of spokes should use package-qualified names")
+	}
+}
+
+func TestGenerateReportsPackageErrors(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"invalid/nonexistent/path"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !res.HasErrors() {
+		t.Error("expected error diagnostics for invalid pattern")
+	}
+
+	if len(res.Files) != 0 {
+		t.Errorf("len(Files) = %d, want 0", len(res.Files))
+	}
+}
+
+func TestGenerateWithoutPatterns(t *testing.T) {
+	if _, err := Generate(context.Background(), Options{}); err == nil {
+		t.Error("expected error without patterns")
+	}
+}
@@ -0,0 +1,155 @@
+package gonverter
+
+import (
+	"go/ast"
+	"go/build"
+	"go/build/constraint"
+	"os"
+	"runtime"
+	"strings"
+)
+
+// unixOS lists the GOOS values that satisfy the "unix" build tag.
+var unixOS = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+	"illumos": true, "ios": true, "linux": true, "netbsd": true, "openbsd": true, "solaris": true,
+}
+
+// tagSet is the set of build tags registration files are evaluated against.
+type tagSet struct {
+	goos, goarch string
+	tags         map[string]bool
+}
+
+func newTagSet(opts *Options) tagSet {
+	ts := tagSet{goos: opts.GOOS, goarch: opts.GOARCH, tags: make(map[string]bool)}
+
+	for _, t := range append([]string{buildTag}, opts.Tags...) {
+		ts.tags[t] = true
+	}
+
+	for _, t := range build.Default.ReleaseTags {
+		ts.tags[t] = true
+	}
+
+	if cgoEnabled(opts.GOOS, opts.GOARCH, os.Getenv("CGO_ENABLED")) {
+		ts.tags["cgo"] = true
+	}
+
+	return ts
+}
+
+// cgoEnabled reports whether cgo is enabled when building for goos and goarch,
+// as in the go command: env, the value of CGO_ENABLED, decides if it is set,
+// otherwise cgo is enabled by default on the host and disabled when
+// cross-compiling.
+func cgoEnabled(goos, goarch, env string) bool {
+	switch env {
+	case "1":
+		return true
+	case "0":
+		return false
+	}
+
+	if goos != runtime.GOOS || goarch != runtime.GOARCH {
+		return false
+	}
+
+	return build.Default.CgoEnabled
+}
+
+// match reports whether tag is satisfied, following the rules of go/build:
+// GOOS and GOARCH are implicit tags, android implies linux, ios implies darwin,
+// illumos implies solaris, and "unix" matches every Unix-like GOOS.
+func (ts tagSet) match(tag string) bool {
+	switch {
+	case ts.tags[tag], tag == ts.goos, tag == ts.goarch:
+		return true
+	case tag == "unix":
+		return unixOS[ts.goos]
+	case tag == "linux":
+		return ts.goos == "android"
+	case tag == "darwin":
+		return ts.goos == "ios"
+	case tag == "solaris":
+		return ts.goos == "illumos"
+	}
+
+	return false
+}
+
+// without returns a copy of ts that does not contain tag.
+func (ts tagSet) without(tag string) tagSet {
+	tags := make(map[string]bool, len(ts.tags))
+	for t := range ts.tags {
+		if t != tag {
+			tags[t] = true
+		}
+	}
+
+	return tagSet{goos: ts.goos, goarch: ts.goarch, tags: tags}
+}
+
+// buildFlags returns the flags passed to the build system when loading packages.
+func buildFlags(opts *Options) []string {
+	return []string{"-tags=" + strings.Join(append([]string{buildTag}, opts.Tags...), ",")}
+}
+
+// buildEnv returns the environment used when loading packages, so that go list
+// selects files for the configured GOOS and GOARCH.
+func buildEnv(opts *Options) []string {
+	return append(os.Environ(), "GOOS="+opts.GOOS, "GOARCH="+opts.GOARCH)
+}
+
+// isRegistrationFile reports whether file is a registration file: its header
+// build constraint must be satisfied by ts, and must not be satisfied once the
+// gonverter tag is removed. Files that would be built anyway, such as those
+// constrained only by GOOS or by "gonverter || linux", are ordinary sources.
+func isRegistrationFile(file *ast.File, ts tagSet) bool {
+	expr := headerConstraint(file)
+	if expr == nil {
+		return false
+	}
+
+	without := ts.without(buildTag)
+
+	return expr.Eval(ts.match) && !expr.Eval(without.match)
+}
+
+// headerConstraint parses the build constraint in the file header, i.e. the
+// comments before the package clause. A //go:build line takes precedence over
+// legacy // +build lines, as in the go command.
+func headerConstraint(file *ast.File) constraint.Expr {
+	var plusBuild []constraint.Expr
+
+	for _, cg := range file.Comments {
+		if cg.Pos() >= file.Package {
+			break
+		}
+
+		for _, c := range cg.List {
+			switch {
+			case constraint.IsGoBuild(c.Text):
+				if expr, err := constraint.Parse(c.Text); err == nil {
+					return expr
+				}
+			case constraint.IsPlusBuild(c.Text):
+				if expr, err := constraint.Parse(c.Text); err == nil {
+					plusBuild = append(plusBuild, expr)
+				}
+			}
+		}
+	}
+
+	if len(plusBuild) == 0 {
+		return nil
+	}
+
+	// Multiple +build lines are ANDed together.
+	expr := plusBuild[0]
+	for _, e := range plusBuild[1:] {
+		expr = &constraint.AndExpr{X: expr, Y: e}
+	}
+
+	return expr
+}
@@ -0,0 +1,95 @@
+package gonverter
+
+import (
+	"go/build"
+	"go/parser"
+	"go/token"
+	"runtime"
+	"testing"
+)
+
+func TestIsRegistrationFile(t *testing.T) {
+	tests := []struct {
+		name  string
+		src   string
+		goos  string
+		extra []string
+		want  bool
+	}{
+		{name: "plain tag", src: "//go:build gonverter\n\npackage p\n", want: true},
+		{name: "negated tag", src: "//go:build !gonverter\n\npackage p\n", want: false},
+		{name: "longer tag name", src: "//go:build gonverterx\n\npackage p\n", want: false},
+		{name: "no constraint", src: "package p\n", want: false},
+		{name: "unrelated constraint", src: "//go:build linux\n\npackage p\n", goos: "linux", want: false},
+		{name: "built anyway", src: "//go:build gonverter || linux\n\npackage p\n", goos: "linux", want: false},
+		{name: "matching GOOS", src: "//go:build gonverter && linux\n\npackage p\n", goos: "linux", want: true},
+		{name: "other GOOS", src: "//go:build gonverter && linux\n\npackage p\n", goos: "darwin", want: false},
+		{name: "unix", src: "//go:build gonverter && unix\n\npackage p\n", goos: "darwin", want: true},
+		{name: "extra tag set", src: "//go:build gonverter && integration\n\npackage p\n", extra: []string{"integration"}, want: true},
+		{name: "extra tag unset", src: "//go:build gonverter && integration\n\npackage p\n", want: false},
+		{name: "legacy plus build", src: "// +build gonverter\n\npackage p\n", want: true},
+		{
+			name: "constraint after package clause",
+			src:  "package p\n\n//go:build gonverter\nvar x int\n",
+			want: false,
+		},
+		{
+			name: "go:build wins over plus build",
+			src:  "//go:build !gonverter\n// +build gonverter\n\npackage p\n",
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parser.ParseFile(token.NewFileSet(), "f.go", tt.src, parser.ParseComments)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			goos := tt.goos
+			if goos == "" {
+				goos = "linux"
+			}
+
+			ts := newTagSet(&Options{GOOS: goos, GOARCH: "amd64", Tags: tt.extra})
+			if got := isRegistrationFile(file, ts); got != tt.want {
+				t.Errorf("isRegistrationFile() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCgoEnabled(t *testing.T) {
+	other := "windows"
+	if runtime.GOOS == other {
+		other = "linux"
+	}
+
+	tests := []struct {
+		name         string
+		goos, goarch string
+		env          string
+		want         bool
+	}{
+		{name: "host", goos: runtime.GOOS, goarch: runtime.GOARCH, want: build.Default.CgoEnabled},
+		{name: "cross-compiling", goos: other, goarch: runtime.GOARCH, want: false},
+		{name: "enabled when cross-compiling", goos: other, goarch: runtime.GOARCH, env: "1", want: true},
+		{name: "disabled on the host", goos: runtime.GOOS, goarch: runtime.GOARCH, env: "0", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cgoEnabled(tt.goos, tt.goarch, tt.env); got != tt.want {
+				t.Errorf("cgoEnabled(%s, %s, %q) = %v, want %v", tt.goos, tt.goarch, tt.env, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildFlags(t *testing.T) {
+	got := buildFlags(&Options{Tags: []string{"a", "b"}})
+	if len(got) != 1 || got[0] != "-tags=gonverter,a,b" {
+		t.Errorf("buildFlags() = %v", got)
+	}
+}
@@ -0,0 +1,130 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// builtinSet is a set of categories of built-in converters, enabled with
+// runtime.WithBuiltins.
+type builtinSet uint8
+
+// Categories of built-in converters, named after the runtime.Builtin constants.
+const (
+	builtinTime builtinSet = 1 << iota
+	builtinPointers
+	builtinSQL
+	builtinBytes
+	builtinNet
+
+	allBuiltins = builtinTime | builtinPointers | builtinSQL | builtinBytes | builtinNet
+)
+
+var builtinCategories = map[string]builtinSet{
+	"BuiltinTime":     builtinTime,
+	"BuiltinPointers": builtinPointers,
+	"BuiltinSQL":      builtinSQL,
+	"BuiltinBytes":    builtinBytes,
+	"BuiltinNet":      builtinNet,
+}
+
+// builtinConverter is a runtime function converting a field to a field of
+// another type, called as fn(src.X, &dst.Y).
+type builtinConverter struct {
+	category builtinSet
+	fn       string
+	fallible bool // fn returns an error
+}
+
+// builtinConverters maps pairs of types, formatted by builtinTypeKey, to the
+// runtime function converting between them. Pointers are handled by
+// createValueMapping.
+var builtinConverters = map[[2]string]builtinConverter{
+	{"time.Time", "string"}:                  {builtinTime, "TimeToRFC3339", false},
+	{"string", "time.Time"}:                  {builtinTime, "RFC3339ToTime", true},
+	{"time.Time", "int64"}:                   {builtinTime, "TimeToUnix", false},
+	{"int64", "time.Time"}:                   {builtinTime, "UnixToTime", false},
+	{"time.Duration", "int64"}:               {builtinTime, "DurationToInt64", false},
+	{"int64", "time.Duration"}:               {builtinTime, "Int64ToDuration", false},
+	{"time.Duration", "string"}:              {builtinTime, "DurationToString", false},
+	{"string", "time.Duration"}:              {builtinTime, "StringToDuration", true},
+	{"database/sql.NullString", "*string"}:   {builtinSQL, "NullStringToPointer", false},
+	{"*string", "database/sql.NullString"}:   {builtinSQL, "PointerToNullString", false},
+	{"database/sql.NullInt64", "*int64"}:     {builtinSQL, "NullInt64ToPointer", false},
+	{"*int64", "database/sql.NullInt64"}:     {builtinSQL, "PointerToNullInt64", false},
+	{"database/sql.NullInt32", "*int32"}:     {builtinSQL, "NullInt32ToPointer", false},
+	{"*int32", "database/sql.NullInt32"}:     {builtinSQL, "PointerToNullInt32", false},
+	{"database/sql.NullFloat64", "*float64"}: {builtinSQL, "NullFloat64ToPointer", false},
+	{"*float64", "database/sql.NullFloat64"}: {builtinSQL, "PointerToNullFloat64", false},
+	{"database/sql.NullBool", "*bool"}:       {builtinSQL, "NullBoolToPointer", false},
+	{"*bool", "database/sql.NullBool"}:       {builtinSQL, "PointerToNullBool", false},
+	{"database/sql.NullTime", "*time.Time"}:  {builtinSQL, "NullTimeToPointer", false},
+	{"*time.Time", "database/sql.NullTime"}:  {builtinSQL, "PointerToNullTime", false},
+	{"[]byte", "string"}:                     {builtinBytes, "BytesToString", false},
+	{"string", "[]byte"}:                     {builtinBytes, "StringToBytes", false},
+	{"net/url.URL", "string"}:                {builtinNet, "URLToString", false},
+	{"string", "net/url.URL"}:                {builtinNet, "StringToURL", true},
+	{"*net/url.URL", "string"}:               {builtinNet, "URLPointerToString", false},
+	{"string", "*net/url.URL"}:               {builtinNet, "StringToURLPointer", true},
+	{"net/netip.Addr", "string"}:             {builtinNet, "AddrToString", false},
+	{"string", "net/netip.Addr"}:             {builtinNet, "StringToAddr", true},
+}
+
+// parseBuiltins reads the categories given to runtime.WithBuiltins. Without
+// any, all categories are enabled.
+func (g *generator) parseBuiltins(pkg *packages.Package, args []ast.Expr) builtinSet {
+	if len(args) == 0 {
+		return allBuiltins
+	}
+
+	var set builtinSet
+
+	for _, arg := range args {
+		category, ok := builtinCategories[runtimeFuncName(pkg, arg)]
+		if !ok {
+			g.errorf(arg.Pos(), "WithBuiltins arguments must be runtime.Builtin constants")
+
+			continue
+		}
+
+		set |= category
+	}
+
+	return set
+}
+
+// builtinFor returns the built-in converter enabled in set that converts src
+// to dst, if any.
+func builtinFor(set builtinSet, src, dst types.Type) (builtinConverter, bool) {
+	c, ok := builtinConverters[[2]string{builtinTypeKey(src), builtinTypeKey(dst)}]
+	if !ok || set&c.category == 0 {
+		return builtinConverter{}, false
+	}
+
+	return c, true
+}
+
+// builtinTypeKey formats t with package paths, spelling uint8 slices []byte.
+func builtinTypeKey(t types.Type) string {
+	if s, ok := t.(*types.Slice); ok && types.Identical(s.Elem(), types.Typ[types.Byte]) {
+		return "[]byte"
+	}
+
+	return types.TypeString(t, nil)
+}
+
+// createBuiltinMapping creates the code converting the field of refs with the
+// runtime function of c. Errors are annotated with the field path.
+func createBuiltinMapping(c builtinConverter, refs fieldRefs, path string) string {
+	call := fmt.Sprintf("runtime.%s(%s, &%s)", c.fn, refs.src, refs.dst)
+	if !c.fallible {
+		return call
+	}
+
+	return fmt.Sprintf(`if err := %s; err != nil {
+		return &runtime.FieldError{Path: %q, Err: err}
+	}`, call, path)
+}
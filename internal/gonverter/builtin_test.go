@@ -0,0 +1,76 @@
+package gonverter
+
+import (
+	"context"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestBuiltinFor(t *testing.T) {
+	timePkg := types.NewPackage("time", "time")
+	timeType := types.NewNamed(types.NewTypeName(token.NoPos, timePkg, "Time", nil), types.NewStruct(nil, nil), nil)
+	userID := types.NewNamed(types.NewTypeName(token.NoPos, types.NewPackage("example.com/domain", "domain"), "UserID", nil), types.Typ[types.String], nil)
+	str := types.Typ[types.String]
+
+	tests := []struct {
+		set      builtinSet
+		src, dst types.Type
+		want     string
+	}{
+		{allBuiltins, timeType, str, "TimeToRFC3339"},
+		{builtinTime, str, timeType, "RFC3339ToTime"},
+		{builtinSQL, timeType, str, ""},
+		{builtinBytes, types.NewSlice(types.Typ[types.Uint8]), str, "BytesToString"},
+		{allBuiltins, userID, str, ""},
+		{0, timeType, str, ""},
+	}
+
+	for _, tt := range tests {
+		got := ""
+		if c, ok := builtinFor(tt.set, tt.src, tt.dst); ok {
+			got = c.fn
+		}
+
+		if got != tt.want {
+			t.Errorf("builtinFor(%b, %s, %s) = %q, want %q", tt.set, tt.src, tt.dst, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateBuiltinPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/builtin"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	for _, p := range res.Plan {
+		switch p.Func {
+		case "ConvertEventRowToEvent":
+			if !p.Fallible {
+				t.Errorf("%s is not fallible, want it to return parse errors", p.Func)
+			}
+
+			for _, f := range p.Fields {
+				if f.Dst == "StartsAt" && (f.Kind != MappingBuiltin || f.Func != "runtime.RFC3339ToTime") {
+					t.Errorf("field StartsAt = %+v, want runtime.RFC3339ToTime", f)
+				}
+			}
+		case "ConvertEventToEventRow", "ConvertVenueRowToVenue":
+			if p.Fallible {
+				t.Errorf("%s is fallible, want no error", p.Func)
+			}
+		case "ConvertSummaryToSummaryDTO":
+			// The hook takes precedence, and BuiltinPointers is not enabled.
+			for _, f := range p.Fields {
+				if f.Dst == "Title" && f.Kind != MappingCustom {
+					t.Errorf("field Title = %+v, want the hook", f)
+				}
+			}
+		}
+	}
+}
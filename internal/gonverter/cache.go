@@ -0,0 +1,180 @@
+package gonverter
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// metadataMode lists packages and their files without type-checking anything.
+const metadataMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedDeps | packages.NeedModule
+
+// cache stores per-package generation results on disk. Entries are keyed by a
+// hash of the generator version and of every non-standard-library source file
+// the package depends on, so an unchanged package can be skipped without
+// type-checking it.
+type cache struct {
+	dir string
+}
+
+// packageKey is the cache key of a matched package.
+type packageKey struct {
+	pkgPath string
+	key     string // empty when the package cannot be cached, e.g. because it has errors
+}
+
+func (c *cache) path(key string) string {
+	return filepath.Join(c.dir, key[:2], key)
+}
+
+// get returns the cached result for key, if any. Unreadable entries are treated as misses.
+func (c *cache) get(key string) (*Result, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var res Result
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&res); err != nil {
+		return nil, false
+	}
+
+	return &res, true
+}
+
+// put stores res under key. The entry is written to a temporary file first so
+// that concurrent go:generate invocations never observe a partial entry.
+func (c *cache) put(key string, res *Result) error {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(res); err != nil {
+		return fmt.Errorf("failed to encode cache entry: %w", err)
+	}
+
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	return nil
+}
+
+// packageKeys lists the packages matched by opts.Patterns and computes their
+// cache keys. This only runs go list; nothing is parsed or type-checked.
+func packageKeys(ctx context.Context, opts *Options) ([]packageKey, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:       metadataMode,
+		Context:    ctx,
+		Dir:        opts.Dir,
+		Env:        buildEnv(opts),
+		BuildFlags: buildFlags(opts),
+	}, opts.Patterns...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load packages: %w", err)
+	}
+
+	keys := make([]packageKey, 0, len(pkgs))
+
+	for _, pkg := range pkgs {
+		pk := packageKey{pkgPath: pkg.PkgPath}
+
+		if len(pkg.Errors) == 0 {
+			key, err := hashPackage(pkg, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			pk.key = key
+		}
+
+		keys = append(keys, pk)
+	}
+
+	return keys, nil
+}
+
+// hashPackage hashes the generator inputs of pkg: the generator version and
+// template, the options that affect output, and the contents of the package's
+// files and of all its non-standard-library dependencies.
+func hashPackage(root *packages.Package, opts *Options) (string, error) {
+	h := sha256.New()
+
+	fmt.Fprintf(h, "version %s\ngo %s\noutput %s\npackage %s\n", opts.Version, runtime.Version(), opts.OutputName, root.PkgPath)
+	fmt.Fprintf(h, "target %s/%s tags %s\n", opts.GOOS, opts.GOARCH, strings.Join(opts.Tags, ","))
+	fmt.Fprintf(h, "template %x\n", sha256.Sum256([]byte(converterTemplate)))
+
+	var files []string
+
+	packages.Visit([]*packages.Package{root}, nil, func(pkg *packages.Package) {
+		if pkg.Module == nil && pkg != root {
+			return // standard library, covered by the Go version
+		}
+
+		for _, f := range pkg.GoFiles {
+			if pkg == root && filepath.Base(f) == opts.OutputName {
+				continue
+			}
+
+			files = append(files, f)
+		}
+	})
+
+	sort.Strings(files)
+
+	for _, f := range files {
+		if err := hashFile(h, f); err != nil {
+			return "", err
+		}
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFile(w io.Writer, path string) error {
+	f, err := os.Open(path) //nolint:gosec // Paths come from go list.
+	if err != nil {
+		return fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return fmt.Errorf("failed to hash %s: %w", path, err)
+	}
+
+	fmt.Fprintf(w, "file %s %x\n", path, h.Sum(nil))
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package gonverter
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestGenerateUsesCache(t *testing.T) {
+	cacheDir := t.TempDir()
+	opts := Options{Patterns: []string{"../../testdata/slice"}, CacheDir: cacheDir, Version: "test"}
+
+	first, err := Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var logs bytes.Buffer
+
+	opts.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+
+	second, err := Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if !strings.Contains(logs.String(), "using cache") {
+		t.Errorf("expected cache hit, logs:\n%s", logs.String())
+	}
+
+	if len(second.Files) != 1 || !bytes.Equal(first.Files[0].Content, second.Files[0].Content) {
+		t.Error("cached result differs from generated result")
+	}
+
+	if len(second.Plan) != len(first.Plan) {
+		t.Errorf("len(Plan) = %d, want %d", len(second.Plan), len(first.Plan))
+	}
+}
+
+func TestPackageKeysDependOnVersion(t *testing.T) {
+	opts := Options{Patterns: []string{"../../testdata/slice"}, Version: "v1"}
+	if err := opts.setDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	k1, err := packageKeys(context.Background(), &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts.Version = "v2"
+
+	k2, err := packageKeys(context.Background(), &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(k1) != 1 || len(k2) != 1 {
+		t.Fatalf("got %d and %d keys, want 1", len(k1), len(k2))
+	}
+
+	if k1[0].key == "" || k1[0].key == k2[0].key {
+		t.Errorf("keys should differ between versions: %q, %q", k1[0].key, k2[0].key)
+	}
+}
+
+func TestPackageKeysSkipBrokenPackages(t *testing.T) {
+	opts := Options{Patterns: []string{"invalid/nonexistent/path"}}
+	if err := opts.setDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := packageKeys(context.Background(), &opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, k := range keys {
+		if k.key != "" {
+			t.Errorf("package %s with errors got cache key %q", k.pkgPath, k.key)
+		}
+	}
+}
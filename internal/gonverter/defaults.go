@@ -0,0 +1,306 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Prefixes of the generated defaulters, e.g. SetDefaultsConfig, and of the
+// hand-written hooks they call, e.g. SetDefaults_Config.
+const (
+	defaultsPrefix     = "SetDefaults"
+	defaultsHookPrefix = "SetDefaults_"
+	defaultTag         = "default"
+)
+
+// defaultSizes are the sizes default tag values must fit in.
+var defaultSizes = types.SizesFor("gc", "amd64")
+
+// defaultsTarget is a type registered with RegisterDefaults.
+type defaultsTarget struct {
+	typ types.Type
+	pos token.Pos
+}
+
+// defaulterData describes a generated SetDefaults function.
+type defaulterData struct {
+	Name  string
+	Type  string
+	Stmts []string // default tags first, then the hook, then the nested values
+}
+
+// detectDefaultHooks returns the SetDefaults_ hooks declared in the package,
+// mapped to the type they set the defaults of.
+func (g *generator) detectDefaultHooks(pkg *packages.Package, customFuncs map[string]bool) map[string]*types.Named {
+	hooks := make(map[string]*types.Named)
+
+	for _, name := range sortedKeys(customFuncs) {
+		if !strings.HasPrefix(name, defaultsHookPrefix) {
+			continue
+		}
+
+		fn, ok := pkg.Types.Scope().Lookup(name).(*types.Func)
+		if !ok {
+			continue
+		}
+
+		typeName := strings.TrimPrefix(name, defaultsHookPrefix)
+
+		var named *types.Named
+		if sig, ok := fn.Type().(*types.Signature); ok && sig.Params().Len() == 1 && sig.Results().Len() == 0 {
+			if ptr, ok := sig.Params().At(0).Type().(*types.Pointer); ok {
+				named, _ = ptr.Elem().(*types.Named)
+			}
+		}
+
+		if named == nil || named.Obj().Name() != typeName {
+			g.errorf(fn.Pos(), "invalid hook %s: must be func(obj *%s)", name, typeName)
+
+			continue
+		}
+
+		hooks[name] = named
+	}
+
+	return hooks
+}
+
+// registerDefaults generates the defaulter of a type registered with
+// RegisterDefaults, even if it has no defaults yet.
+func (g *generator) registerDefaults(target defaultsTarget) {
+	named, ok := derefType(target.typ).(*types.Named)
+	if !ok {
+		g.errorf(target.pos, "cannot set defaults of %s: not a struct type", typeString(target.typ))
+
+		return
+	}
+
+	if g.defaulterFor(named, target.pos, true) != "" && !g.needsDefaults(named) {
+		g.warnf(target.pos, "%s has no default tags or %s hook, and neither have the values nested in it", typeString(named), defaultsHookPrefix+named.Obj().Name())
+	}
+}
+
+// newDefaults returns the defaulter a conversion registered with
+// runtime.WithDefaults calls on its destination, or an empty string if there
+// is nothing to default.
+func (g *generator) newDefaults(pair *conversionPair) string {
+	named, ok := derefType(pair.to.typ).(*types.Named)
+	if !pair.to.isPointer || !ok {
+		g.errorf(pair.pos, "defaults require a pointer destination")
+
+		return ""
+	}
+
+	fn := g.defaulterFor(named, pair.pos, false)
+	if fn == "" {
+		g.warnf(pair.pos, "WithDefaults has no effect: %s has no default tags or %s hook, and neither have the values nested in it", typeString(named), defaultsHookPrefix+named.Obj().Name())
+	}
+
+	return fn
+}
+
+// defaulterFor returns the SetDefaults function of t, generating it and the
+// functions of the types nested in t on first use. Unless force is set, it
+// returns an empty string if neither t nor a value nested in it has defaults.
+func (g *generator) defaulterFor(t *types.Named, pos token.Pos, force bool) string {
+	name := defaultsPrefix + t.Obj().Name()
+	if other, ok := g.defaulters[name]; ok {
+		if other != t {
+			g.errorf(pos, "defaulter %s is already generated for %s", name, typeString(other))
+
+			return ""
+		}
+
+		return name
+	}
+
+	if !force && !g.needsDefaults(t) {
+		return ""
+	}
+
+	st, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		g.errorf(pos, "cannot set defaults of %s: not a struct type", typeString(t))
+
+		return ""
+	}
+
+	g.defaulters[name] = t
+	d := defaulterData{Name: name, Type: g.typeExpr(t)}
+
+	// Reserve the position of d before generating the functions it calls.
+	idx := len(g.defaulterFuncs)
+	g.defaulterFuncs = append(g.defaulterFuncs, d)
+
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		if value, ok := reflect.StructTag(st.Tag(i)).Lookup(defaultTag); ok && f.Exported() {
+			if stmt := g.defaultTagStmt(f, value); stmt != "" {
+				d.Stmts = append(d.Stmts, stmt)
+			}
+		}
+	}
+
+	if hook := defaultsHookPrefix + t.Obj().Name(); g.defaultHooks[hook] == t {
+		d.Stmts = append(d.Stmts, hook+"(obj)")
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+
+		elem := nestedNamed(f.Type())
+		if !f.Exported() || elem == nil || !g.needsDefaults(elem) {
+			continue
+		}
+
+		if fn := g.defaulterFor(elem, f.Pos(), false); fn != "" {
+			d.Stmts = append(d.Stmts, nestedValuesStmt("obj", f, true, func(ptr, _ string) string {
+				return fmt.Sprintf("%s(%s)", fn, ptr)
+			}))
+		}
+	}
+
+	g.defaulterFuncs[idx] = d
+
+	return name
+}
+
+// needsDefaults reports whether values of t, or values nested in them, have
+// default tags or a SetDefaults_ hook.
+func (g *generator) needsDefaults(t *types.Named) bool {
+	return reachesAny(t, g.defaults, func(n *types.Named) bool {
+		if g.defaultHooks[defaultsHookPrefix+n.Obj().Name()] == n {
+			return true
+		}
+
+		st, ok := n.Underlying().(*types.Struct)
+		if !ok {
+			return false
+		}
+
+		for i := 0; i < st.NumFields(); i++ {
+			if _, ok := reflect.StructTag(st.Tag(i)).Lookup(defaultTag); ok && st.Field(i).Exported() {
+				return true
+			}
+		}
+
+		return false
+	})
+}
+
+// hasDefaultTag reports whether the field name of the struct info has a default tag.
+func hasDefaultTag(info typeInfo, name string) bool {
+	st, ok := derefType(info.typ).Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() == name {
+			_, ok := reflect.StructTag(st.Tag(i)).Lookup(defaultTag)
+
+			return ok
+		}
+	}
+
+	return false
+}
+
+// defaultTagStmt returns the statement setting field f of obj to the value of
+// its default tag if the field is zero, or nil if it is a pointer.
+func (g *generator) defaultTagStmt(f *types.Var, value string) string {
+	name := f.Name()
+
+	if ptr, ok := f.Type().(*types.Pointer); ok {
+		lit, ok := defaultLiteral(ptr.Elem(), value)
+		if !ok {
+			g.errorf(f.Pos(), "invalid default %q for field %s of type %s", value, name, typeString(f.Type()))
+
+			return ""
+		}
+
+		return fmt.Sprintf(`if obj.%s == nil {
+		obj.%s = new(%s)
+		*obj.%s = %s
+	}`, name, name, g.typeExpr(ptr.Elem()), name, lit)
+	}
+
+	basic, ok := f.Type().Underlying().(*types.Basic)
+	if ok && basic.Info()&types.IsBoolean != 0 {
+		g.errorf(f.Pos(), "default for field %s has no effect: false cannot be told from unset; use a *bool field", name)
+
+		return ""
+	}
+
+	lit, ok := defaultLiteral(f.Type(), value)
+	if !ok {
+		g.errorf(f.Pos(), "invalid default %q for field %s of type %s", value, name, typeString(f.Type()))
+
+		return ""
+	}
+
+	zero := "0"
+	if basic.Info()&types.IsString != 0 {
+		zero = `""`
+	}
+
+	return fmt.Sprintf(`if obj.%s == %s {
+		obj.%s = %s
+	}`, name, zero, name, lit)
+}
+
+// defaultLiteral returns the Go literal of a default tag value for a field of
+// type t, which must be a string, boolean or number. Integers are decimal, and
+// values of time.Duration are written like "30s" and generated as nanoseconds.
+// It reports false if the value is not valid for t.
+func defaultLiteral(t types.Type, value string) (string, bool) {
+	if named, ok := t.(*types.Named); ok && named.Obj().Pkg() != nil &&
+		named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Duration" {
+		d, err := time.ParseDuration(value)
+
+		return strconv.FormatInt(int64(d), 10), err == nil
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return "", false
+	}
+
+	var (
+		lit  string
+		err  error
+		bits = int(defaultSizes.Sizeof(basic)) * 8
+	)
+
+	switch info := basic.Info(); {
+	case info&types.IsString != 0:
+		lit = strconv.Quote(value)
+	case info&types.IsBoolean != 0:
+		var b bool
+		b, err = strconv.ParseBool(value)
+		lit = strconv.FormatBool(b)
+	case info&types.IsUnsigned != 0:
+		var n uint64
+		n, err = strconv.ParseUint(value, 10, bits)
+		lit = strconv.FormatUint(n, 10)
+	case info&types.IsInteger != 0:
+		var n int64
+		n, err = strconv.ParseInt(value, 10, bits)
+		lit = strconv.FormatInt(n, 10)
+	case info&types.IsFloat != 0:
+		var f float64
+		f, err = strconv.ParseFloat(value, bits)
+		lit = strconv.FormatFloat(f, 'g', -1, bits)
+	default:
+		return "", false
+	}
+
+	return lit, err == nil
+}
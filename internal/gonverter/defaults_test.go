@@ -0,0 +1,62 @@
+package gonverter
+
+import (
+	"context"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestDefaultLiteral(t *testing.T) {
+	timePkg := types.NewPackage("time", "time")
+	duration := types.NewNamed(types.NewTypeName(token.NoPos, timePkg, "Duration", nil), types.Typ[types.Int64], nil)
+
+	tests := []struct {
+		typ   types.Type
+		value string
+		want  string
+		ok    bool
+	}{
+		{types.Typ[types.String], `say "hi"`, `"say \"hi\""`, true},
+		{types.Typ[types.Int], "010", "10", true},
+		{types.Typ[types.Int], "0x10", "", false},
+		{types.Typ[types.Uint], "0b1", "", false},
+		{types.Typ[types.Int8], "128", "", false},
+		{types.Typ[types.Uint16], "-1", "", false},
+		{types.Typ[types.Float32], "1.5", "1.5", true},
+		{types.Typ[types.Bool], "yes", "", false},
+		{types.Typ[types.Bool], "true", "true", true},
+		{duration, "1m30s", "90000000000", true},
+		{duration, "90", "", false},
+		{types.NewSlice(types.Typ[types.String]), "a", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := defaultLiteral(tt.typ, tt.value)
+		if ok != tt.ok || ok && got != tt.want {
+			t.Errorf("defaultLiteral(%s, %q) = %s, %v, want %s, %v", tt.typ, tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestGenerateDefaultsPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/defaults"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	if len(res.Plan) != 1 || res.Plan[0].Defaulter != "SetDefaultsServer" {
+		t.Fatalf("Plan = %+v, want ConvertServerRequestToServer calling SetDefaultsServer", res.Plan)
+	}
+
+	// Scheme has no source field and is left to the defaulter.
+	for _, f := range res.Plan[0].Fields {
+		if f.Dst == "Scheme" && (f.Func != "SetDefaultsServer" || f.Missing) {
+			t.Errorf("field Scheme = %+v, want filled by the defaulter", f)
+		}
+	}
+}
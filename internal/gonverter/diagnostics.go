@@ -0,0 +1,110 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/token"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Severity classifies a diagnostic.
+type Severity string
+
+// Diagnostic severities.
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem reported during generation.
+type Diagnostic struct {
+	File     string   `json:"file,omitempty"`
+	Line     int      `json:"line,omitempty"`
+	Column   int      `json:"column,omitempty"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// String formats the diagnostic as "file:line:col: message".
+// Warnings are marked as such; the position is omitted when unknown.
+func (d Diagnostic) String() string {
+	msg := d.Message
+	if d.Severity == SeverityWarning {
+		msg = "warning: " + msg
+	}
+
+	if d.File == "" {
+		return msg
+	}
+
+	pos := d.File
+	if d.Line > 0 {
+		pos += ":" + strconv.Itoa(d.Line)
+
+		if d.Column > 0 {
+			pos += ":" + strconv.Itoa(d.Column)
+		}
+	}
+
+	return pos + ": " + msg
+}
+
+// errorf records an error diagnostic at pos.
+func (g *generator) errorf(pos token.Pos, format string, args ...any) {
+	g.report(pos, SeverityError, fmt.Sprintf(format, args...))
+}
+
+// warnf records a warning diagnostic at pos.
+func (g *generator) warnf(pos token.Pos, format string, args ...any) {
+	g.report(pos, SeverityWarning, fmt.Sprintf(format, args...))
+}
+
+func (g *generator) report(pos token.Pos, severity Severity, msg string) {
+	d := Diagnostic{Severity: severity, Message: msg}
+
+	if pos.IsValid() {
+		p := g.fset.Position(pos)
+		d.File, d.Line, d.Column = p.Filename, p.Line, p.Column
+	}
+
+	g.result.Diagnostics = append(g.result.Diagnostics, d)
+}
+
+// packageErrorDiagnostic converts a go/packages error, whose position is a
+// "file:line:col" string, into a diagnostic.
+func packageErrorDiagnostic(e packages.Error) Diagnostic {
+	d := Diagnostic{Severity: SeverityError, Message: e.Msg}
+
+	if e.Pos == "" || e.Pos == "-" {
+		return d
+	}
+
+	parts := strings.Split(e.Pos, ":")
+
+	// Peel numeric line and column off the end; file names may contain colons.
+	var nums []int
+
+	for len(parts) > 1 && len(nums) < 2 {
+		n, err := strconv.Atoi(parts[len(parts)-1])
+		if err != nil {
+			break
+		}
+
+		nums = append([]int{n}, nums...)
+		parts = parts[:len(parts)-1]
+	}
+
+	d.File = strings.Join(parts, ":")
+
+	if len(nums) > 0 {
+		d.Line = nums[0]
+	}
+
+	if len(nums) > 1 {
+		d.Column = nums[1]
+	}
+
+	return d
+}
@@ -0,0 +1,118 @@
+package gonverter
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestDiagnosticString(t *testing.T) {
+	tests := []struct {
+		name string
+		diag Diagnostic
+		want string
+	}{
+		{
+			name: "full position",
+			diag: Diagnostic{File: "a.go", Line: 3, Column: 7, Severity: SeverityError, Message: "boom"},
+			want: "a.go:3:7: boom",
+		},
+		{
+			name: "line only",
+			diag: Diagnostic{File: "a.go", Line: 3, Severity: SeverityError, Message: "boom"},
+			want: "a.go:3: boom",
+		},
+		{
+			name: "no position",
+			diag: Diagnostic{Severity: SeverityError, Message: "boom"},
+			want: "boom",
+		},
+		{
+			name: "warning",
+			diag: Diagnostic{File: "a.go", Line: 1, Column: 1, Severity: SeverityWarning, Message: "careful"},
+			want: "a.go:1:1: warning: careful",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.diag.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackageErrorDiagnostic(t *testing.T) {
+	tests := []struct {
+		name     string
+		pos      string
+		wantFile string
+		wantLine int
+		wantCol  int
+	}{
+		{name: "empty", pos: ""},
+		{name: "dash", pos: "-"},
+		{name: "file line col", pos: "/src/a.go:10:4", wantFile: "/src/a.go", wantLine: 10, wantCol: 4},
+		{name: "file line", pos: "/src/a.go:10", wantFile: "/src/a.go", wantLine: 10},
+		{name: "colon in file", pos: `C:\src\a.go:2:1`, wantFile: `C:\src\a.go`, wantLine: 2, wantCol: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := packageErrorDiagnostic(packages.Error{Pos: tt.pos, Msg: "msg"})
+			if d.File != tt.wantFile || d.Line != tt.wantLine || d.Column != tt.wantCol {
+				t.Errorf("got %s:%d:%d, want %s:%d:%d", d.File, d.Line, d.Column, tt.wantFile, tt.wantLine, tt.wantCol)
+			}
+
+			if d.Severity != SeverityError || d.Message != "msg" {
+				t.Errorf("got severity %q message %q", d.Severity, d.Message)
+			}
+		})
+	}
+}
+
+func TestGenerateAggregatesDiagnostics(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/invalid"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := []struct {
+		file    string
+		line    int
+		message string
+	}{
+		{file: "register.go", line: 9, message: "invalid spoke"},
+		{file: "register.go", line: 11, message: "unsupported registration option"},
+		{file: "register.go", line: 12, message: "RegisterPatch only supports the WithZeroAsUnset option"},
+		{file: "hooks.go", line: 9, message: "invalid hook BeforeConvertSourceToTarget: must be func(*invalid.Source, *invalid.Target)"},
+		{file: "hooks.go", line: 5, message: "invalid hook AfterConvertSourceToTarget"},
+		{file: "types.go", line: 14, message: "unsupported field kind"},
+		{file: "types.go", line: 15, message: "missing hook"},
+		{file: "register.go", line: 8, message: "not a struct type"},
+		{file: "register.go", line: 12, message: "not a struct type"},
+		{file: "types.go", line: 33, message: "WithFieldMask cannot select the fields of Profile in part"},
+		{file: "types.go", line: 50, message: "unsupported field kind: cannot convert Window.Opens (time.Time) to WindowDTO.Opens (*time.Time)"},
+		{file: "register.go", line: 10, message: "no conversion path"},
+	}
+
+	if len(res.Diagnostics) != len(want) {
+		t.Fatalf("len(Diagnostics) = %d, want %d: %v", len(res.Diagnostics), len(want), res.Diagnostics)
+	}
+
+	for i, w := range want {
+		d := res.Diagnostics[i]
+		if filepath.Base(d.File) != w.file || d.Line != w.line || !strings.Contains(d.Message, w.message) {
+			t.Errorf("Diagnostics[%d] = %s, want %s:%d containing %q", i, d, w.file, w.line, w.message)
+		}
+	}
+
+	// The valid pair is still generated alongside the errors.
+	if len(res.Files) != 1 {
+		t.Errorf("len(Files) = %d, want 1", len(res.Files))
+	}
+}
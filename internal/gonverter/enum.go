@@ -0,0 +1,186 @@
+package gonverter
+
+import (
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// enumData describes the switch of a conversion registered with RegisterEnum.
+type enumData struct {
+	Cases    []enumCase
+	Fallback string // the constant values without an equivalent become, if any
+}
+
+// enumCase converts the constants in From, a comma-separated list, to To.
+type enumCase struct {
+	From string
+	To   string
+}
+
+// enumFor returns the registered enum conversion between the types of two
+// fields, or nil if there is none.
+func (g *generator) enumFor(src, dst types.Type) *conversionPair {
+	return g.enums[g.pairKey(&conversionPair{from: extractTypeInfo(derefType(src)), to: extractTypeInfo(derefType(dst))})]
+}
+
+// buildEnumFuncData builds a conversion registered with RegisterEnum. The
+// constants of both types are paired by their enumKey, and constants of the
+// source type with the same value share a case.
+func (g *generator) buildEnumFuncData(pair *conversionPair, pkgName, pkgPath string, imports map[string]bool) (funcData, bool) {
+	fd := g.newFuncData(pair, pkgName, imports)
+
+	from, _ := derefType(pair.from.typ).(*types.Named)
+	to, _ := derefType(pair.to.typ).(*types.Named)
+
+	fromConsts, toConsts := enumConstants(from, g.pkgPath), enumConstants(to, g.pkgPath)
+	if len(fromConsts) == 0 || len(toConsts) == 0 {
+		g.errorf(pair.pos, "cannot convert %s to %s: enums must be named string or integer types with constants",
+			typeString(derefType(pair.from.typ)), typeString(derefType(pair.to.typ)))
+
+		return fd, false
+	}
+
+	targets := make(map[string]*types.Const, len(toConsts))
+	for _, c := range toConsts {
+		if key := enumKey(c.Name(), to.Obj().Name(), pair.opts.enumPrefixTo); targets[key] == nil {
+			targets[key] = c
+		}
+	}
+
+	fd.Enum = &enumData{}
+	fd.runtime = pair.opts.enumFallback == nil // for runtime.UnknownEnumValue
+
+	var (
+		unmatched []string
+		values    = make(map[string]bool)
+		caseIndex = make(map[*types.Const]int)
+	)
+
+	for _, c := range fromConsts {
+		if values[c.Val().ExactString()] {
+			continue
+		}
+
+		values[c.Val().ExactString()] = true
+
+		target := targets[enumKey(c.Name(), from.Obj().Name(), pair.opts.enumPrefixFrom)]
+		if target == nil {
+			unmatched = append(unmatched, c.Name())
+
+			continue
+		}
+
+		if i, ok := caseIndex[target]; ok {
+			fd.Enum.Cases[i].From += ", " + g.objectExpr(c)
+
+			continue
+		}
+
+		caseIndex[target] = len(fd.Enum.Cases)
+		fd.Enum.Cases = append(fd.Enum.Cases, enumCase{From: g.objectExpr(c), To: g.objectExpr(target)})
+	}
+
+	if len(fd.Enum.Cases) == 0 {
+		g.errorf(pair.pos, "no constants of %s match those of %s", typeString(from), typeString(to))
+
+		return fd, false
+	}
+
+	outcome := "converting them returns an error"
+
+	if fallback := pair.opts.enumFallback; fallback != nil {
+		if !types.Identical(fallback.Type(), to) {
+			g.errorf(pair.pos, "WithEnumFallback value %s is not a constant of %s", fallback.Name(), typeString(to))
+
+			return fd, false
+		}
+
+		fd.Enum.Fallback = g.objectExpr(fallback)
+		outcome = "they are converted to " + fd.Enum.Fallback
+	}
+
+	if len(unmatched) > 0 {
+		g.warnf(pair.pos, "constants of %s without an equivalent in %s: %s; %s",
+			typeString(from), typeString(to), strings.Join(unmatched, ", "), outcome)
+	}
+
+	g.result.Plan = append(g.result.Plan, PairPlan{
+		Func:     fd.Name,
+		PkgPath:  pkgPath,
+		From:     qualifiedTypeName(pair.from),
+		To:       qualifiedTypeName(pair.to),
+		Enum:     true,
+		Fallible: fd.Fallible,
+	})
+
+	return fd, true
+}
+
+// enumConstants returns the constants of t declared in its package that code
+// in the package pkgPath can refer to, in order of declaration, or nil if t is
+// not a named string or integer type. An empty pkgPath keeps unexported constants.
+func enumConstants(t *types.Named, pkgPath string) []*types.Const {
+	if t == nil || t.Obj().Pkg() == nil {
+		return nil
+	}
+
+	basic, ok := t.Underlying().(*types.Basic)
+	if !ok || basic.Info()&(types.IsString|types.IsInteger) == 0 {
+		return nil
+	}
+
+	var consts []*types.Const
+
+	scope := t.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), t) {
+			continue
+		}
+
+		if pkgPath == "" || c.Exported() || c.Pkg().Path() == pkgPath {
+			consts = append(consts, c)
+		}
+	}
+
+	sort.Slice(consts, func(i, j int) bool { return consts[i].Pos() < consts[j].Pos() })
+
+	return consts
+}
+
+// enumKey returns the key the constant name of the enum type typeName is paired
+// by: the name ignoring case and underscores, without prefix or, if prefix is
+// empty, without leading repetitions of the type name. For example, both
+// Status_STATUS_ACTIVE and StatusActive become "active".
+func enumKey(name, typeName, prefix string) string {
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, "_", ""))
+	}
+
+	key := normalize(name)
+	if prefix != "" {
+		return strings.TrimPrefix(key, normalize(prefix))
+	}
+
+	typeKey := normalize(typeName)
+	for len(key) > len(typeKey) && strings.HasPrefix(key, typeKey) {
+		key = key[len(typeKey):]
+	}
+
+	return key
+}
+
+// objectExpr returns the expression referring to obj, a package-level
+// constant or function, in generated code.
+func (g *generator) objectExpr(obj types.Object) string {
+	if obj.Pkg() == nil || obj.Pkg().Path() == g.pkgPath {
+		return obj.Name()
+	}
+
+	if g.imports != nil {
+		g.imports[obj.Pkg().Path()] = true
+	}
+
+	return obj.Pkg().Name() + "." + obj.Name()
+}
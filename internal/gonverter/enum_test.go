@@ -0,0 +1,81 @@
+package gonverter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestEnumKey(t *testing.T) {
+	tests := []struct {
+		name, typeName, prefix string
+		want                   string
+	}{
+		{"Status_STATUS_ACTIVE", "Status", "", "active"},
+		{"StatusActive", "Status", "", "active"},
+		{"STATUS_ACTIVE", "Status", "", "active"},
+		{"Active", "Status", "", "active"},
+		{"Status", "Status", "", "status"},
+		{"Role_ROLE_ADMIN", "Role", "Role_ROLE_", "admin"},
+		{"PermAdmin", "Role", "Perm", "admin"},
+		{"RoleAdmin", "Role", "Perm", "roleadmin"},
+	}
+
+	for _, tt := range tests {
+		if got := enumKey(tt.name, tt.typeName, tt.prefix); got != tt.want {
+			t.Errorf("enumKey(%q, %q, %q) = %q, want %q", tt.name, tt.typeName, tt.prefix, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateEnumPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/enum"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	want := map[string]bool{
+		"ConvertPbStatusToStatus": false,
+		"ConvertStatusToPbStatus": false,
+		"ConvertPbRoleToRole":     true,
+	}
+
+	for _, p := range res.Plan {
+		fallible, ok := want[p.Func]
+		if !ok {
+			continue
+		}
+
+		if !p.Enum || p.Fallible != fallible || len(p.Fields) != 0 {
+			t.Errorf("plan of %s = %+v, want an enum conversion with Fallible %v", p.Func, p, fallible)
+		}
+
+		delete(want, p.Func)
+	}
+
+	if len(want) != 0 {
+		t.Errorf("Plan = %+v, missing %v", res.Plan, want)
+	}
+
+	// Constants without an equivalent are reported, aliases are not.
+	var unmatched []string
+
+	for _, d := range res.Diagnostics {
+		if d.Severity != SeverityWarning {
+			t.Errorf("unexpected diagnostic %v", d)
+		}
+
+		unmatched = append(unmatched, d.Message)
+	}
+
+	got := strings.Join(unmatched, "\n")
+	for _, name := range []string{"Status_STATUS_DELETED", "StatusUnknown", "Role_ROLE_UNSPECIFIED"} {
+		if !strings.Contains(got, name) {
+			t.Errorf("Diagnostics = %v, want %s reported", res.Diagnostics, name)
+		}
+	}
+
+	if strings.Contains(got, "Role_ROLE_OWNER") {
+		t.Errorf("Diagnostics = %v, want alias Role_ROLE_OWNER not reported", res.Diagnostics)
+	}
+}
@@ -0,0 +1,293 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// factorySpec is the function given to runtime.WithFactory creating the
+// destination, and the functions creating the values of its parameters.
+type factorySpec struct {
+	fn     *types.Func
+	params []*types.Func
+}
+
+// reservedLocals are the names of the variables of generated code, which the
+// variables of factory parameters avoid.
+var reservedLocals = map[string]bool{
+	"src": true, "dst": true, "err": true, "ok": true, "fields": true, "c": true, "i": true, "k": true, "v": true, "w": true,
+}
+
+// parseFactory reads the functions given to runtime.WithFactory.
+func (g *generator) parseFactory(pkg *packages.Package, args []ast.Expr) *factorySpec {
+	spec := &factorySpec{}
+
+	for i, arg := range args {
+		fn := g.funcObject(pkg, arg)
+		if fn == nil {
+			return nil
+		}
+
+		if i == 0 {
+			spec.fn = fn
+		} else {
+			spec.params = append(spec.params, fn)
+		}
+	}
+
+	return spec
+}
+
+// funcObject returns the package-level function an option argument names,
+// reporting an error for anything else.
+func (g *generator) funcObject(pkg *packages.Package, expr ast.Expr) *types.Func {
+	var ident *ast.Ident
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	}
+
+	fn, ok := pkg.TypesInfo.Uses[ident].(*types.Func)
+	if ident == nil || !ok || fn.Type().(*types.Signature).Recv() != nil {
+		g.errorf(expr.Pos(), "option argument must name a function")
+
+		return nil
+	}
+
+	return fn
+}
+
+// creates reports whether fn returns a value of type t, or a pointer to one
+// if ptr is true, optionally followed by an error, and whether it returns a
+// pointer and an error.
+func creates(fn *types.Func, t types.Type, ptr bool) (isPtr, fallible, ok bool) {
+	results := fn.Type().(*types.Signature).Results()
+	if results.Len() == 0 || results.Len() > 2 || (results.Len() == 2 && !isErrorType(results.At(1).Type())) {
+		return false, false, false
+	}
+
+	res := results.At(0).Type()
+	if p, isPointer := res.(*types.Pointer); isPointer && ptr && types.Identical(p.Elem(), t) {
+		return true, results.Len() == 2, true
+	}
+
+	return false, results.Len() == 2, types.Identical(res, t)
+}
+
+// buildFactoryMappings maps the source fields of pair to the parameters of
+// its factory, and calls the factory with them to create dst.
+func (g *generator) buildFactoryMappings(pair *conversionPair, fromStruct *types.Struct) ([]fieldMapping, bool) {
+	spec := pair.opts.factory
+	dstType := derefType(pair.to.typ)
+	factory := g.objectExpr(spec.fn)
+
+	if !pair.to.isPointer {
+		g.errorf(pair.pos, "WithFactory requires a pointer destination type")
+
+		return nil, false
+	}
+
+	isPtr, fallible, ok := creates(spec.fn, dstType, true)
+	if !ok {
+		g.errorf(pair.pos, "factory %s must return %s or a pointer to it, optionally followed by an error", factory, typeString(dstType))
+
+		return nil, false
+	}
+
+	// Variables must not shadow the packages of the generated code either.
+	sig := spec.fn.Type().(*types.Signature)
+	taken := make(map[string]bool)
+
+	for path := range g.imports {
+		taken[path[strings.LastIndex(path, "/")+1:]] = true
+	}
+
+	var (
+		mappings []fieldMapping
+		args     []string
+	)
+
+	for i := 0; i < sig.Params().Len(); i++ {
+		m, arg, ok := g.createParamMapping(pair, fromStruct, sig.Params().At(i), taken)
+		if !ok {
+			return nil, false
+		}
+
+		mappings = append(mappings, m)
+		args = append(args, arg)
+	}
+
+	if sig.Variadic() {
+		args[len(args)-1] += "..."
+	}
+
+	call := fmt.Sprintf("%s(%s)", factory, strings.Join(args, ", "))
+	if !isPtr && !fallible {
+		return append(mappings, fieldMapping{code: "*dst = " + call}), true
+	}
+
+	local := freeLocal(lowerFirst(pair.to.typeName), taken)
+
+	var code string
+	if fallible {
+		code = fmt.Sprintf(`%s, err := %s
+	if err != nil {
+		return err
+	}`, local, call)
+	} else {
+		code = fmt.Sprintf("%s := %s", local, call)
+	}
+
+	if !isPtr {
+		return append(mappings, fieldMapping{code: fmt.Sprintf("%s\n\n*dst = %s", code, local), fallible: true}), true
+	}
+
+	// Factories returning a pointer may return nil, which is not dereferenced.
+	code = fmt.Sprintf(`%s
+	if %s == nil {
+		return runtime.ErrNilFactoryResult
+	}
+
+	*dst = *%s`, code, local, local)
+
+	return append(mappings, fieldMapping{code: code, fallible: true, runtime: true}), true
+}
+
+// createParamMapping maps the source field named like the factory parameter
+// param, ignoring case, to it. It returns the mapping declaring what the
+// parameter needs and the expression of the argument. Parameters of the types
+// value factories create are filled with them.
+func (g *generator) createParamMapping(pair *conversionPair, fromStruct *types.Struct, param *types.Var, taken map[string]bool) (fieldMapping, string, bool) {
+	var (
+		valueFactory  *types.Func
+		valueFallible bool
+		valueType     = param.Type()
+	)
+
+	for _, fn := range pair.opts.factory.params {
+		if _, fallible, ok := creates(fn, param.Type(), false); ok && fn.Type().(*types.Signature).Params().Len() == 1 {
+			valueFactory, valueFallible = fn, fallible
+			valueType = fn.Type().(*types.Signature).Params().At(0).Type()
+
+			break
+		}
+	}
+
+	name := sourceFieldName(pair, fromStruct, param.Name())
+	if name == "" {
+		g.errorf(param.Pos(), "factory parameter %s has no source field: %s has no field %s",
+			param.Name(), typeString(derefType(pair.from.typ)), capitalize(param.Name()))
+
+		return fieldMapping{}, "", false
+	}
+
+	local := freeLocal(param.Name(), taken)
+
+	target := local
+	if valueFactory != nil {
+		target = freeLocal(local+"Value", taken)
+	}
+
+	m := g.createFieldMappingTo(pair, pairVars, fromStruct, types.NewField(param.Pos(), param.Pkg(), name, valueType, false), target)
+	m.plan.Dst = param.Name()
+
+	if m.plan.Kind == MappingCustom {
+		if !m.plan.Missing {
+			g.errorf(param.Pos(), "factory parameter %s cannot be filled by the field hook %s", param.Name(), m.plan.Func)
+		}
+
+		return m, "", false
+	}
+
+	stmts, value := g.localValue(m.code, target, valueType)
+	if valueFactory == nil {
+		m.code = stmts
+
+		return m, value, true
+	}
+
+	call := fmt.Sprintf("%s(%s)", g.objectExpr(valueFactory), value)
+	m.plan.Kind, m.plan.Func = MappingFactory, g.objectExpr(valueFactory)
+
+	if !valueFallible {
+		m.code = stmts
+
+		return m, call, true
+	}
+
+	create := fmt.Sprintf(`%s, err := %s
+	if err != nil {
+		return &runtime.FieldError{Path: %q, Err: err}
+	}`, local, call, name)
+
+	m.code = create
+	if stmts != "" {
+		m.code = stmts + "\n" + create
+	}
+
+	m.fallible, m.runtime = true, true
+
+	return m, local, true
+}
+
+// sourceFieldName returns the name of the exported field of fromStruct
+// named param ignoring case, or of the getter of such a field, or "".
+func sourceFieldName(pair *conversionPair, fromStruct *types.Struct, param string) string {
+	for i := 0; i < fromStruct.NumFields(); i++ {
+		if f := fromStruct.Field(i); f.Exported() && strings.EqualFold(f.Name(), param) {
+			return f.Name()
+		}
+	}
+
+	if _, ok, _ := getterFor(pair, capitalize(param)); ok {
+		return capitalize(param)
+	}
+
+	return ""
+}
+
+// freeLocal returns name, or name followed by Value if it is reserved or a
+// keyword, as the name of a variable, and marks it taken.
+func freeLocal(name string, taken map[string]bool) string {
+	for token.IsKeyword(name) || reservedLocals[name] || taken[name] {
+		name += "Value"
+	}
+
+	if taken != nil {
+		taken[name] = true
+	}
+
+	return name
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// lowerFirst lowers the first letter of s, or the initialism it starts with,
+// so that ID becomes id and URLPath urlPath.
+func lowerFirst(s string) string {
+	n := 0
+	for n < len(s) && unicode.IsUpper(rune(s[n])) {
+		n++
+	}
+
+	if n > 1 && n < len(s) && unicode.IsLower(rune(s[n])) {
+		n--
+	}
+
+	return strings.ToLower(s[:n]) + s[n:]
+}
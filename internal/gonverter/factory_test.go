@@ -0,0 +1,75 @@
+package gonverter
+
+import (
+	"context"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestCreates(t *testing.T) {
+	pkg := types.NewPackage("example.com/domain", "domain")
+	user := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "User", nil), types.NewStruct(nil, nil), nil)
+	errType := types.Universe.Lookup("error").Type()
+
+	newFunc := func(results ...types.Type) *types.Func {
+		vars := make([]*types.Var, len(results))
+		for i, r := range results {
+			vars[i] = types.NewParam(token.NoPos, pkg, "", r)
+		}
+
+		sig := types.NewSignatureType(nil, nil, nil, nil, types.NewTuple(vars...), false)
+
+		return types.NewFunc(token.NoPos, pkg, "NewUser", sig)
+	}
+
+	tests := []struct {
+		name                   string
+		fn                     *types.Func
+		ptr                    bool
+		wantPtr, wantErr, want bool
+	}{
+		{"value", newFunc(user), true, false, false, true},
+		{"pointer", newFunc(types.NewPointer(user)), true, true, false, true},
+		{"pointer and error", newFunc(types.NewPointer(user), errType), true, true, true, true},
+		{"value and error", newFunc(user, errType), false, false, true, true},
+		{"pointer not allowed", newFunc(types.NewPointer(user)), false, false, false, false},
+		{"other type", newFunc(types.Typ[types.String]), true, false, false, false},
+		{"two values", newFunc(user, user), true, false, false, false},
+		{"nothing", newFunc(), true, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isPtr, fallible, ok := creates(tt.fn, user, tt.ptr)
+			if ok != tt.want || (ok && (isPtr != tt.wantPtr || fallible != tt.wantErr)) {
+				t.Errorf("creates() = %v, %v, %v, want %v, %v, %v", isPtr, fallible, ok, tt.wantPtr, tt.wantErr, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateFactoryPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/factory"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	for _, p := range res.Plan {
+		if p.Func != "ConvertSignupRequestToUser" {
+			continue
+		}
+
+		if p.Factory != "domain.NewUser" || !p.Fallible || len(p.Fields) != 4 {
+			t.Fatalf("plan = %+v, want the fallible factory domain.NewUser with 4 parameters", p)
+		}
+
+		if f := p.Fields[2]; f.Src != "Email" || f.Dst != "email" || f.Kind != MappingFactory || f.Func != "domain.NewEmail" {
+			t.Errorf("Fields[2] = %+v, want email from domain.NewEmail", f)
+		}
+	}
+}
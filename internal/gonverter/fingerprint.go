@@ -0,0 +1,256 @@
+package gonverter
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Header field prefixes written below the "DO NOT EDIT" banner of generated files.
+const (
+	headerVersion       = "// Version:       "
+	headerRegistrations = "// Registrations: "
+	headerInputs        = "// Inputs:        "
+	headerChecksum      = "// Checksum:      "
+)
+
+// fingerprint identifies the inputs a generated file was produced from.
+type fingerprint struct {
+	version       string
+	registrations string // comma-separated base names of the registration files
+	inputs        string // hash of registrations, reachable type definitions and package functions
+}
+
+// fingerprint computes the fingerprint of a parsed package. It hashes the
+// registration files, the definitions of every named type reachable from the
+// registered pairs and the types their routes pass through (including method
+// sets), the constants of registered enum types, the signatures of the
+// factories of WithFactory and of the package-level functions that may act as
+// hooks.
+func (g *generator) fingerprint(pkg *packages.Package, in *packageInput, opts *Options) fingerprint {
+	h := sha256.New()
+
+	names := make([]string, 0, len(in.registrationFiles))
+	for _, f := range in.registrationFiles {
+		names = append(names, filepath.Base(f.path))
+		fmt.Fprintf(h, "registration %s %x\n", filepath.Base(f.path), sha256.Sum256(f.src))
+	}
+
+	named := make(map[string]*types.Named)
+	for _, pair := range in.pairs {
+		collectNamedTypes(pair.from.typ, named)
+		collectNamedTypes(pair.to.typ, named)
+
+		// Routes of RegisterVia and RegisterPath convert through these too.
+		for _, via := range pair.via {
+			collectNamedTypes(via.typ, named)
+		}
+	}
+
+	for _, target := range in.defaults {
+		collectNamedTypes(target.typ, named)
+	}
+
+	factories := factoriesOf(in.pairs)
+	for _, fn := range factories {
+		sig := fn.Type().(*types.Signature)
+		collectNamedTypes(sig.Params(), named)
+		collectNamedTypes(sig.Results(), named)
+	}
+
+	// Methods generated on local types are output, not input.
+	generated := func(fn *types.Func) bool {
+		return filepath.Base(g.fset.Position(fn.Pos()).Filename) == opts.OutputName
+	}
+
+	for _, key := range sortedKeys(named) {
+		fmt.Fprintf(h, "type %s %s\n", key, typeDefinition(named[key], generated))
+	}
+
+	// Enum conversions switch over the constants of both types, which the type
+	// definitions above don't cover.
+	for _, pair := range in.pairs {
+		if !pair.enum {
+			continue
+		}
+
+		for _, t := range []types.Type{pair.from.typ, pair.to.typ} {
+			named, _ := derefType(t).(*types.Named)
+			for _, c := range enumConstants(named, pkg.PkgPath) {
+				fmt.Fprintf(h, "const %s.%s %s\n", c.Pkg().Path(), c.Name(), c.Val().ExactString())
+			}
+		}
+	}
+
+	// Factories of other packages are not among the package functions below.
+	for _, fn := range factories {
+		if fn.Pkg() != pkg.Types {
+			fmt.Fprintf(h, "factory %s %s\n", fn.FullName(), typeString(fn.Type()))
+		}
+	}
+
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		fn, ok := scope.Lookup(name).(*types.Func)
+		if !ok || filepath.Base(g.fset.Position(fn.Pos()).Filename) == opts.OutputName {
+			continue
+		}
+
+		fmt.Fprintf(h, "func %s %s\n", name, typeString(fn.Type()))
+	}
+
+	return fingerprint{
+		version:       opts.Version,
+		registrations: strings.Join(names, ", "),
+		inputs:        fmt.Sprintf("sha256:%x", h.Sum(nil)),
+	}
+}
+
+// factoriesOf returns the functions given to WithFactory by pairs.
+func factoriesOf(pairs []conversionPair) []*types.Func {
+	var fns []*types.Func
+
+	for _, pair := range pairs {
+		if pair.opts.factory != nil {
+			fns = append(fns, pair.opts.factory.fn)
+			fns = append(fns, pair.opts.factory.params...)
+		}
+	}
+
+	return fns
+}
+
+// collectNamedTypes adds every named type reachable from t to seen.
+func collectNamedTypes(t types.Type, seen map[string]*types.Named) {
+	switch t := t.(type) {
+	case *types.Named:
+		key := t.String()
+		if _, ok := seen[key]; ok {
+			return
+		}
+
+		seen[key] = t
+		collectNamedTypes(t.Underlying(), seen)
+	case *types.Pointer:
+		collectNamedTypes(t.Elem(), seen)
+	case *types.Slice:
+		collectNamedTypes(t.Elem(), seen)
+	case *types.Array:
+		collectNamedTypes(t.Elem(), seen)
+	case *types.Map:
+		collectNamedTypes(t.Key(), seen)
+		collectNamedTypes(t.Elem(), seen)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			collectNamedTypes(t.Field(i).Type(), seen)
+		}
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			collectNamedTypes(t.At(i).Type(), seen)
+		}
+	}
+}
+
+// typeDefinition renders the underlying type of named, including struct tags, and
+// its method set without the methods skip reports true for.
+func typeDefinition(named *types.Named, skip func(*types.Func) bool) string {
+	var b strings.Builder
+
+	b.WriteString(types.TypeString(named.Underlying(), nil))
+
+	mset := types.NewMethodSet(types.NewPointer(named))
+	for i := 0; i < mset.Len(); i++ {
+		fn, ok := mset.At(i).Obj().(*types.Func)
+		if !ok || skip != nil && skip(fn) {
+			continue
+		}
+
+		fmt.Fprintf(&b, "; %s%s", fn.Name(), strings.TrimPrefix(types.TypeString(fn.Type(), nil), "func"))
+	}
+
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// stampHeader inserts the fingerprint below the first line of the generated
+// source and appends a checksum of the resulting file so that hand edits can be detected.
+func stampHeader(src []byte, fp fingerprint) []byte {
+	banner, rest, _ := bytes.Cut(src, []byte("\n"))
+
+	var b bytes.Buffer
+
+	b.Write(banner)
+	b.WriteString("\n//\n")
+	b.WriteString(headerVersion + fp.version + "\n")
+	b.WriteString(headerRegistrations + fp.registrations + "\n")
+	b.WriteString(headerInputs + fp.inputs + "\n")
+	b.Write(rest)
+
+	stamped := b.Bytes()
+	idx := bytes.Index(stamped, []byte(headerInputs))
+	eol := idx + bytes.IndexByte(stamped[idx:], '\n') + 1
+
+	out := make([]byte, 0, len(stamped)+len(headerChecksum)+80)
+	out = append(out, stamped[:eol]...)
+	out = append(out, headerChecksum+checksum(stamped)+"\n"...)
+	out = append(out, stamped[eol:]...)
+
+	return out
+}
+
+// readHeader parses the fingerprint and checksum of a generated file. It
+// reports false if the file carries no gonverter header.
+func readHeader(src []byte) (fp fingerprint, sum string, ok bool) {
+	sc := bufio.NewScanner(bytes.NewReader(src))
+
+	for sc.Scan() {
+		line := sc.Text()
+		if !strings.HasPrefix(line, "//") {
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(line, headerVersion):
+			fp.version = strings.TrimPrefix(line, headerVersion)
+		case strings.HasPrefix(line, headerRegistrations):
+			fp.registrations = strings.TrimPrefix(line, headerRegistrations)
+		case strings.HasPrefix(line, headerInputs):
+			fp.inputs = strings.TrimPrefix(line, headerInputs)
+		case strings.HasPrefix(line, headerChecksum):
+			sum = strings.TrimPrefix(line, headerChecksum)
+			ok = true
+		}
+	}
+
+	return fp, sum, ok
+}
+
+// checksum hashes a generated file without its checksum line.
+func checksum(src []byte) string {
+	var b bytes.Buffer
+
+	for line := range bytes.Lines(src) {
+		if !bytes.HasPrefix(line, []byte(headerChecksum)) {
+			b.Write(line)
+		}
+	}
+
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(b.Bytes()))
+}
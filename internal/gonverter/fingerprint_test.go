@@ -0,0 +1,198 @@
+package gonverter
+
+import (
+	"bytes"
+	"context"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+const unstampedSource = `// Code generated by gonverter. DO NOT EDIT.
+
+package sample
+
+func ConvertAToB(src *A, dst *B) {}
+`
+
+func TestStampHeaderRoundTrip(t *testing.T) {
+	fp := fingerprint{version: "1.2.3", registrations: "register.go", inputs: "sha256:abc"}
+	stamped := stampHeader([]byte(unstampedSource), fp)
+
+	if !bytes.HasPrefix(stamped, []byte("// Code generated by gonverter. DO NOT EDIT.\n")) {
+		t.Errorf("banner must stay on the first line:\n%s", stamped)
+	}
+
+	got, sum, ok := readHeader(stamped)
+	if !ok {
+		t.Fatalf("readHeader() found no header in:\n%s", stamped)
+	}
+
+	if got != fp {
+		t.Errorf("readHeader() = %+v, want %+v", got, fp)
+	}
+
+	if sum != checksum(stamped) {
+		t.Errorf("checksum = %q, want %q", sum, checksum(stamped))
+	}
+}
+
+func TestChecksumDetectsEdits(t *testing.T) {
+	stamped := stampHeader([]byte(unstampedSource), fingerprint{version: "1.2.3"})
+	_, sum, _ := readHeader(stamped)
+
+	edited := bytes.Replace(stamped, []byte("{}"), []byte("{ _ = src }"), 1)
+	if sum == checksum(edited) {
+		t.Error("checksum should change when the body is edited")
+	}
+}
+
+func TestReadHeaderWithoutFingerprint(t *testing.T) {
+	if _, _, ok := readHeader([]byte(unstampedSource)); ok {
+		t.Error("expected no header")
+	}
+}
+
+func TestTypeDefinitionIncludesTagsAndMethods(t *testing.T) {
+	named := mustLoadNamed(t, "../../testdata/nested", "User")
+
+	def := typeDefinition(named, nil)
+	if !strings.Contains(def, "Address") {
+		t.Errorf("typeDefinition() = %q, want it to contain field Address", def)
+	}
+}
+
+func mustLoadNamed(t *testing.T, dir, name string) *types.Named {
+	t.Helper()
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: loadMode, Dir: abs}, ".")
+	if err != nil || len(pkgs) != 1 || pkgs[0].Types == nil {
+		t.Fatalf("failed to load %s: %v", dir, err)
+	}
+
+	named, ok := pkgs[0].Types.Scope().Lookup(name).Type().(*types.Named)
+	if !ok {
+		t.Fatalf("%s is not a named type", name)
+	}
+
+	return named
+}
+
+func TestFingerprintIncludesEnumConstants(t *testing.T) {
+	opts := Options{Patterns: []string{"../../testdata/enum"}}
+	if err := opts.setDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	g := &generator{fset: token.NewFileSet(), logger: opts.Logger, result: &Result{}}
+
+	pkgs, err := g.load(context.Background(), &opts, opts.Patterns)
+	if err != nil || len(pkgs) != 1 {
+		t.Fatalf("load() = %d packages, %v", len(pkgs), err)
+	}
+
+	in, ok := g.parse(pkgs[0], &opts)
+	if !ok {
+		t.Fatalf("parse() failed: %v", g.result.Diagnostics)
+	}
+
+	withEnums := g.fingerprint(pkgs[0], &in, &opts)
+
+	for i := range in.pairs {
+		in.pairs[i].enum = false
+	}
+
+	if g.fingerprint(pkgs[0], &in, &opts).inputs == withEnums.inputs {
+		t.Error("fingerprint() does not depend on the constants of enum types")
+	}
+}
+
+func TestFingerprintIncludesFactories(t *testing.T) {
+	opts := Options{Patterns: []string{"../../testdata/factory"}}
+	if err := opts.setDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	g := &generator{fset: token.NewFileSet(), logger: opts.Logger, result: &Result{}}
+
+	pkgs, err := g.load(context.Background(), &opts, opts.Patterns)
+	if err != nil || len(pkgs) != 1 {
+		t.Fatalf("load() = %d packages, %v", len(pkgs), err)
+	}
+
+	in, ok := g.parse(pkgs[0], &opts)
+	if !ok {
+		t.Fatalf("parse() failed: %v", g.result.Diagnostics)
+	}
+
+	withFactories := g.fingerprint(pkgs[0], &in, &opts)
+
+	for i := range in.pairs {
+		if f := in.pairs[i].opts.factory; f != nil {
+			in.pairs[i].opts.factory = &factorySpec{fn: f.fn}
+		}
+	}
+
+	if g.fingerprint(pkgs[0], &in, &opts).inputs == withFactories.inputs {
+		t.Error("fingerprint() does not depend on the value factories of WithFactory")
+	}
+}
+
+func TestFingerprintIncludesRouteTypes(t *testing.T) {
+	opts := Options{Patterns: []string{"../../testdata/multihop"}}
+	if err := opts.setDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	g := &generator{fset: token.NewFileSet(), logger: opts.Logger, result: &Result{}}
+
+	pkgs, err := g.load(context.Background(), &opts, opts.Patterns)
+	if err != nil || len(pkgs) != 1 {
+		t.Fatalf("load() = %d packages, %v", len(pkgs), err)
+	}
+
+	in, ok := g.parse(pkgs[0], &opts)
+	if !ok {
+		t.Fatalf("parse() failed: %v", g.result.Diagnostics)
+	}
+
+	// The registered types of multihop are all reached through pairs, so the
+	// route passes through a type that is not.
+	pkg := types.NewPackage("example.com/hop", "hop")
+	hop := types.NewNamed(types.NewTypeName(token.NoPos, pkg, "Hop", nil),
+		types.NewStruct([]*types.Var{types.NewField(token.NoPos, pkg, "ID", types.Typ[types.String], false)}, nil), nil)
+
+	for i := range in.pairs {
+		in.pairs[i].via = nil
+	}
+
+	withoutVia := g.fingerprint(pkgs[0], &in, &opts)
+	in.pairs[0].via = []typeInfo{extractTypeInfo(types.NewPointer(hop))}
+
+	if g.fingerprint(pkgs[0], &in, &opts).inputs == withoutVia.inputs {
+		t.Error("fingerprint() does not depend on the types routes pass through")
+	}
+}
+
+func TestEnumConstantsSkipsUnexportedOfOtherPackages(t *testing.T) {
+	named := mustLoadNamed(t, "../../testdata/enum/pb", "Status")
+
+	for _, c := range enumConstants(named, "github.com/sivchari/gonverter/testdata/enum") {
+		if !c.Exported() {
+			t.Errorf("enumConstants() includes unexported %s", c.Name())
+		}
+	}
+
+	if got := len(enumConstants(named, named.Obj().Pkg().Path())); got != 5 {
+		t.Errorf("len(enumConstants()) in its own package = %d, want 5", got)
+	}
+}
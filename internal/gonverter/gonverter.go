@@ -2,11 +2,13 @@ package gonverter
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"go/ast"
 	"go/format"
 	"go/token"
 	"go/types"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"sort"
@@ -19,63 +21,48 @@ import (
 const (
 	runtimePkgSuffix = "gonverter/runtime"
 	convertPrefix    = "Convert"
+	applyPrefix      = "Apply"
 	buildTag         = "gonverter"
 )
 
-// Run executes the code generation for the given package pattern.
-func Run(pattern string) error {
-	g := &generator{
-		fset:           token.NewFileSet(),
-		customFuncs:    make(map[string]bool),
-		generatedPairs: make(map[string]bool),
-	}
-
-	return g.run(pattern)
-}
-
 type generator struct {
 	fset           *token.FileSet
+	logger         *slog.Logger
 	customFuncs    map[string]bool
-	generatedPairs map[string]bool // tracks already generated conversion pairs
-}
-
-func (g *generator) run(pattern string) error {
-	pairs, pkgDir, err := g.parse(pattern)
-	if err != nil {
-		return err
-	}
-
-	if len(pairs) == 0 {
-		fmt.Println("No conversion pairs found")
-
-		return nil
-	}
-
-	fmt.Printf("Found %d conversion pair(s)\n", len(pairs))
-
-	if err := g.detectCustomFuncs(pattern); err != nil {
-		return err
-	}
-
-	code, err := g.generate(pairs, filepath.Base(pkgDir))
-	if err != nil {
-		return err
-	}
-
-	outputPath := filepath.Join(pkgDir, "generated.go")
-	if err := os.WriteFile(outputPath, code, 0o600); err != nil {
-		return fmt.Errorf("failed to write file: %w", err)
-	}
-
-	fmt.Printf("Generated: %s\n", outputPath)
-
-	return nil
+	generatedPairs map[string]bool        // tracks already generated conversion pairs
+	pkgPath        string                 // package the code is generated in
+	graph          conversionGraph        // conversions routes are composed from
+	constructors   map[string]string      // constructor names to the function they wrap
+	imports        map[string]bool        // packages referred to by type expressions in the output
+	objectHooks    map[string]bool        // Before and After hooks, to whether they return an error
+	hookFuncs      map[string]*types.Func // functions named like Before and After hooks, whether they fit or not
+	fallible       map[string]bool        // generated functions that return an error
+	validators     map[string]bool        // names of the generated validation functions
+	validatorFuncs []validatorData        // generated validation functions, in order
+	validates      map[*types.Named]bool
+	defaultHooks   map[string]*types.Named // SetDefaults_ hooks, to the type they default
+	defaulters     map[string]*types.Named // names of the generated defaulters, to their type
+	defaulterFuncs []defaulterData         // generated defaulters, in order
+	defaults       map[*types.Named]bool
+	enums          map[string]*conversionPair // registered enum conversions by pair key
+	result         *Result
 }
 
 // --- Parsing ---
 
 type conversionPair struct {
-	from, to typeInfo
+	from, to     typeInfo
+	via          []typeInfo  // types a route must pass through, in order
+	pos          token.Pos   // position of the registration or of the field that required the pair
+	route        bool        // composed from other conversions found in the conversion graph
+	patch        bool        // applies the set fields of the source to an existing destination
+	masked       bool        // the WithMask variant, converting the fields selected by a runtime.FieldMask
+	enum         bool        // converts between the constants of two enum types
+	nested       bool        // discovered through a field of another pair
+	qualified    bool        // prefix non-local type names with their package name in function names
+	sources      []typeParam // sources of a many-to-one conversion, whose from is their tuple
+	destinations []typeParam // destinations of a split conversion, whose to is their tuple
+	opts         registrationOptions
 }
 
 type typeInfo struct {
@@ -86,46 +73,130 @@ type typeInfo struct {
 	isPointer bool
 }
 
-func (g *generator) parse(pattern string) ([]conversionPair, string, error) {
+// packageInput holds the registrations found in a single package.
+type packageInput struct {
+	pkgPath           string
+	pkgName           string
+	dir               string
+	pairs             []conversionPair
+	customFuncs       map[string]bool
+	registrationFiles []registrationFile
+	runtimePath       string           // import path of the gonverter runtime package
+	hooks             []conversionEdge // hand-written whole-type conversion functions
+	objectHooks       map[string]bool  // Before and After hooks, to whether they return an error
+	hookFuncs         map[string]*types.Func
+	defaults          []defaultsTarget // types registered with RegisterDefaults
+	defaultHooks      map[string]*types.Named
+}
+
+// registrationFile is a source file selected by the gonverter build tag.
+type registrationFile struct {
+	path string
+	src  []byte
+}
+
+// loadMode is used for the one package load of a run. Syntax is only kept for
+// the matched packages; hooks are detected from the same syntax trees.
+// NeedDeps type-checks dependencies from source instead of reading export data,
+// which older x/tools releases cannot decode when built by a newer toolchain.
+const loadMode = packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
+	packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo
+
+// load type-checks all packages matching patterns in a single go/packages call,
+// so that dependencies shared between packages are only processed once.
+func (g *generator) load(ctx context.Context, opts *Options, patterns []string) ([]*packages.Package, error) {
 	cfg := &packages.Config{
-		Mode: packages.NeedName | packages.NeedFiles | packages.NeedCompiledGoFiles |
-			packages.NeedImports | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Mode:       loadMode,
+		Context:    ctx,
+		Dir:        opts.Dir,
 		Fset:       g.fset,
-		BuildFlags: []string{"-tags=gonverter"},
+		Env:        buildEnv(opts),
+		BuildFlags: buildFlags(opts),
 	}
 
-	pkgs, err := packages.Load(cfg, pattern)
+	pkgs, err := packages.Load(cfg, patterns...)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to load packages: %w", err)
+		return nil, fmt.Errorf("failed to load packages: %w", err)
 	}
 
-	if packages.PrintErrors(pkgs) > 0 {
-		return nil, "", fmt.Errorf("packages contain errors")
+	return pkgs, nil
+}
+
+// parse extracts registrations and hooks from a loaded package. It reports
+// false when the package has errors that prevent generation.
+func (g *generator) parse(pkg *packages.Package, opts *Options) (packageInput, bool) {
+	in := packageInput{pkgPath: pkg.PkgPath, pkgName: pkg.Name}
+
+	if errs := outputIndependentErrors(pkg, opts.OutputName); len(errs) > 0 {
+		for _, e := range errs {
+			g.result.Diagnostics = append(g.result.Diagnostics, packageErrorDiagnostic(e))
+		}
+
+		return in, false
 	}
 
-	var pairs []conversionPair
+	if len(pkg.GoFiles) > 0 {
+		in.dir = filepath.Dir(pkg.GoFiles[0])
+	}
 
-	var pkgDir string
+	tags := newTagSet(opts)
 
-	for _, pkg := range pkgs {
-		if len(pkg.GoFiles) > 0 && pkgDir == "" {
-			pkgDir = filepath.Dir(pkg.GoFiles[0])
+	for _, file := range pkg.Syntax {
+		if !isRegistrationFile(file, tags) {
+			continue
 		}
 
-		for _, file := range pkg.Syntax {
-			if !hasBuildTag(file, buildTag) {
-				continue
-			}
+		path := g.fset.Position(file.Pos()).Filename
+
+		src, err := os.ReadFile(path) //nolint:gosec // Paths come from go list.
+		if err != nil {
+			g.errorf(file.Pos(), "failed to read registration file: %v", err)
 
-			pairs = append(pairs, g.extractPairs(pkg, file)...)
+			return in, false
 		}
+
+		in.registrationFiles = append(in.registrationFiles, registrationFile{path: path, src: src})
+		pairs, defaults := g.extractPairs(pkg, file)
+		in.pairs = append(in.pairs, pairs...)
+		in.defaults = append(in.defaults, defaults...)
 	}
 
-	return pairs, pkgDir, nil
+	in.customFuncs = g.detectCustomFuncs(pkg, opts.OutputName)
+	in.hooks = g.detectConversionHooks(pkg, in.customFuncs)
+	in.objectHooks, in.hookFuncs = g.detectObjectHooks(pkg, in.customFuncs)
+	in.defaultHooks = g.detectDefaultHooks(pkg, in.customFuncs)
+
+	for path := range pkg.Imports {
+		if strings.HasSuffix(path, runtimePkgSuffix) {
+			in.runtimePath = path
+		}
+	}
+
+	return in, true
 }
 
-func (g *generator) extractPairs(pkg *packages.Package, file *ast.File) []conversionPair {
-	var pairs []conversionPair
+// outputIndependentErrors returns the package errors that are not located in a
+// previously generated output file. That file is about to be replaced, so errors
+// in it (e.g. calls to hooks that do not exist yet) must not block regeneration.
+func outputIndependentErrors(pkg *packages.Package, outputName string) []packages.Error {
+	var errs []packages.Error
+
+	for _, e := range pkg.Errors {
+		if d := packageErrorDiagnostic(e); filepath.Base(d.File) == outputName {
+			continue
+		}
+
+		errs = append(errs, e)
+	}
+
+	return errs
+}
+
+func (g *generator) extractPairs(pkg *packages.Package, file *ast.File) ([]conversionPair, []defaultsTarget) {
+	var (
+		pairs    []conversionPair
+		defaults []defaultsTarget
+	)
 
 	ast.Inspect(file, func(n ast.Node) bool {
 		call, ok := n.(*ast.CallExpr)
@@ -133,82 +204,222 @@ func (g *generator) extractPairs(pkg *packages.Package, file *ast.File) []conver
 			return true
 		}
 
-		indexExpr, ok := call.Fun.(*ast.IndexListExpr)
-		if !ok || len(indexExpr.Indices) != 2 {
+		var (
+			fun      ast.Expr
+			typeArgs []ast.Expr
+		)
+
+		switch f := call.Fun.(type) {
+		case *ast.IndexExpr:
+			fun, typeArgs = f.X, []ast.Expr{f.Index}
+		case *ast.IndexListExpr:
+			fun, typeArgs = f.X, f.Indices
+		default:
 			return true
 		}
 
-		callType := g.getRegisterCallType(pkg, indexExpr)
-		if callType == registerCallNone {
+		name := runtimeFuncName(pkg, fun)
+		if name == "" {
 			return true
 		}
 
-		fromType := pkg.TypesInfo.TypeOf(indexExpr.Indices[0])
-		toType := pkg.TypesInfo.TypeOf(indexExpr.Indices[1])
-
-		if fromType == nil || toType == nil {
-			return true
+		typeList := make([]types.Type, len(typeArgs))
+		for i, arg := range typeArgs {
+			if typeList[i] = pkg.TypesInfo.TypeOf(arg); typeList[i] == nil {
+				return true
+			}
 		}
 
-		// Add forward conversion (From → To)
-		pairs = append(pairs, conversionPair{
-			from: extractTypeInfo(fromType),
-			to:   extractTypeInfo(toType),
-		})
+		switch {
+		case name == "RegisterHub" && len(typeList) == 1:
+			pairs = append(pairs, g.extractHubPairs(pkg, call, typeList[0])...)
+		case (name == "Register" || name == "RegisterBidirectional") && len(typeList) == 2:
+			start := len(pairs)
+
+			opts := g.parseOptions(pkg, call.Args)
+			if opts.zeroAsUnset {
+				g.errorf(call.Pos(), "WithZeroAsUnset requires RegisterPatch")
+			}
 
-		// Add reverse conversion (To → From) for bidirectional registration
-		if callType == registerCallBidirectional {
+			if opts.hasEnumOptions() {
+				g.errorf(call.Pos(), "WithEnumPrefix and WithEnumFallback require RegisterEnum")
+			}
+
+			if opts.precedence != nil {
+				g.errorf(call.Pos(), "WithPrecedence requires Register2 or Register3")
+
+				opts.precedence = nil
+			}
+
+			// Messages and domain types often share their name.
+			qualified := isProtoMessage(typeList[0]) || isProtoMessage(typeList[1])
+
+			// Add forward conversion (From → To)
 			pairs = append(pairs, conversionPair{
-				from: extractTypeInfo(toType),
-				to:   extractTypeInfo(fromType),
+				from:      extractTypeInfo(typeList[0]),
+				to:        extractTypeInfo(typeList[1]),
+				pos:       call.Pos(),
+				opts:      opts,
+				qualified: qualified,
+			})
+
+			// Add reverse conversion (To → From) for bidirectional registration,
+			// or when a From<Pkg> method needs it
+			if name == "RegisterBidirectional" || opts.fromMethod != "" {
+				var reverseOpts registrationOptions
+				if name == "RegisterBidirectional" {
+					reverseOpts = opts.withoutMethods()
+				}
+
+				pairs = append(pairs, conversionPair{
+					from:      extractTypeInfo(typeList[1]),
+					to:        extractTypeInfo(typeList[0]),
+					pos:       call.Pos(),
+					opts:      reverseOpts,
+					qualified: qualified,
+				})
+			}
+
+			for _, pair := range pairs[start:] {
+				if pair.opts.fieldMask {
+					pairs = append(pairs, maskedPair(pair))
+				}
+			}
+		case name == "Register2" && len(typeList) == 3, name == "Register3" && len(typeList) == 4:
+			if pair, ok := g.extractMultiPair(pkg, call, typeList); ok {
+				pairs = append(pairs, pair)
+			}
+		case name == "RegisterSplit2" && len(typeList) == 3, name == "RegisterSplit3" && len(typeList) == 4:
+			if pair, ok := g.extractSplitPair(pkg, call, typeList); ok {
+				pairs = append(pairs, pair)
+			}
+		case name == "RegisterPatch" && len(typeList) == 2:
+			pairs = append(pairs, conversionPair{
+				from:  extractTypeInfo(pointerTo(typeList[0])),
+				to:    extractTypeInfo(pointerTo(typeList[1])),
+				pos:   call.Pos(),
+				patch: true,
+				opts:  g.parsePatchOptions(pkg, call),
+			})
+		case name == "RegisterPath" && len(typeList) == 2:
+			pairs = append(pairs, conversionPair{
+				from:  extractTypeInfo(pointerTo(typeList[0])),
+				to:    extractTypeInfo(pointerTo(typeList[1])),
+				pos:   call.Pos(),
+				route: true,
+				opts:  g.parseRouteOptions(pkg, call),
+			})
+		case name == "RegisterEnum" && len(typeList) == 2:
+			pairs = append(pairs, conversionPair{
+				from: extractTypeInfo(pointerTo(typeList[0])),
+				to:   extractTypeInfo(pointerTo(typeList[1])),
+				pos:  call.Pos(),
+				enum: true,
+				opts: g.parseEnumOptions(pkg, call),
+				// Enums of different layers often share their name.
+				qualified: true,
+			})
+		case name == "RegisterDefaults" && len(typeList) == 1:
+			defaults = append(defaults, defaultsTarget{typ: typeList[0], pos: call.Pos()})
+		case name == "RegisterVia" && len(typeList) == 3:
+			pairs = append(pairs, conversionPair{
+				from:  extractTypeInfo(pointerTo(typeList[0])),
+				to:    extractTypeInfo(pointerTo(typeList[1])),
+				via:   []typeInfo{extractTypeInfo(pointerTo(typeList[2]))},
+				pos:   call.Pos(),
+				route: true,
+				opts:  g.parseRouteOptions(pkg, call),
 			})
 		}
 
 		return true
 	})
 
-	return pairs
+	return pairs, defaults
 }
 
-type registerCallType int
+// extractHubPairs expands a RegisterHub call into conversions between each spoke
+// and the hub in both directions, followed by spoke-to-spoke conversions composed
+// through the hub. All of them convert between pointers.
+func (g *generator) extractHubPairs(pkg *packages.Package, call *ast.CallExpr, hubType types.Type) []conversionPair {
+	hub := extractTypeInfo(pointerTo(hubType))
 
-const (
-	registerCallNone registerCallType = iota
-	registerCallUnidirectional
-	registerCallBidirectional
-)
+	if len(call.Args) == 0 {
+		g.errorf(call.Pos(), "RegisterHub needs at least one spoke type")
 
-func (g *generator) getRegisterCallType(pkg *packages.Package, indexExpr *ast.IndexListExpr) registerCallType {
-	sel, ok := indexExpr.X.(*ast.SelectorExpr)
-	if !ok {
-		return registerCallNone
+		return nil
 	}
 
-	// Check for Register or RegisterBidirectional
-	if sel.Sel.Name != "Register" && sel.Sel.Name != "RegisterBidirectional" {
-		return registerCallNone
+	var (
+		pairs  []conversionPair
+		spokes []typeInfo
+		seen   = make(map[string]bool)
+	)
+
+	for _, arg := range call.Args {
+		t := pkg.TypesInfo.TypeOf(arg)
+		if t == nil {
+			continue
+		}
+
+		spoke := extractTypeInfo(pointerTo(t))
+
+		name := qualifiedTypeName(spoke)
+		if name == qualifiedTypeName(hub) || seen[name] {
+			g.errorf(arg.Pos(), "invalid spoke %s: registered twice or equal to the hub", typeString(spoke.typ))
+
+			continue
+		}
+
+		seen[name] = true
+		spokes = append(spokes, spoke)
+		pairs = append(pairs,
+			conversionPair{from: spoke, to: hub, pos: arg.Pos(), qualified: true},
+			conversionPair{from: hub, to: spoke, pos: arg.Pos(), qualified: true},
+		)
 	}
 
-	ident, ok := sel.X.(*ast.Ident)
-	if !ok {
-		return registerCallNone
+	for i, from := range spokes {
+		for j, to := range spokes {
+			if i == j {
+				continue
+			}
+
+			pairs = append(pairs, conversionPair{from: from, to: to, via: []typeInfo{hub}, pos: call.Pos(), route: true, qualified: true})
+		}
 	}
 
-	obj := pkg.TypesInfo.ObjectOf(ident)
-	if obj == nil {
-		return registerCallNone
+	return pairs
+}
+
+// pointerTo returns t as a pointer type, leaving pointer types untouched.
+func pointerTo(t types.Type) types.Type {
+	if _, ok := t.(*types.Pointer); ok {
+		return t
 	}
 
-	pkgName, ok := obj.(*types.PkgName)
-	if !ok || !strings.HasSuffix(pkgName.Imported().Path(), runtimePkgSuffix) {
-		return registerCallNone
+	return types.NewPointer(t)
+}
+
+// runtimeFuncName returns the name of the function expr selects from the
+// gonverter runtime package, or an empty string if it selects something else.
+func runtimeFuncName(pkg *packages.Package, expr ast.Expr) string {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
 	}
 
-	if sel.Sel.Name == "RegisterBidirectional" {
-		return registerCallBidirectional
+	ident, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
 	}
 
-	return registerCallUnidirectional
+	pkgName, ok := pkg.TypesInfo.ObjectOf(ident).(*types.PkgName)
+	if !ok || !strings.HasSuffix(pkgName.Imported().Path(), runtimePkgSuffix) {
+		return ""
+	}
+
+	return sel.Sel.Name
 }
 
 func extractTypeInfo(t types.Type) typeInfo {
@@ -232,43 +443,32 @@ func extractTypeInfo(t types.Type) typeInfo {
 	return info
 }
 
-func hasBuildTag(file *ast.File, tag string) bool {
-	for _, cg := range file.Comments {
-		for _, c := range cg.List {
-			text := strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
-			if (strings.HasPrefix(text, "go:build") || strings.HasPrefix(text, "+build")) &&
-				strings.Contains(text, tag) {
-				return true
-			}
-		}
-	}
-
-	return false
-}
-
 // --- Custom function detection ---
 
-func (g *generator) detectCustomFuncs(pattern string) error {
-	pkgs, err := packages.Load(&packages.Config{
-		Mode: packages.NeedName | packages.NeedSyntax | packages.NeedFiles,
-	}, pattern)
-	if err != nil {
-		return fmt.Errorf("failed to load packages: %w", err)
-	}
+// detectCustomFuncs collects the Convert*, Before* and After* functions declared
+// in the package, ignoring the previously generated output file.
+func (g *generator) detectCustomFuncs(pkg *packages.Package, outputName string) map[string]bool {
+	funcs := make(map[string]bool)
 
-	for _, pkg := range pkgs {
-		for _, file := range pkg.Syntax {
-			ast.Inspect(file, func(n ast.Node) bool {
-				if fn, ok := n.(*ast.FuncDecl); ok && strings.HasPrefix(fn.Name.Name, convertPrefix) {
-					g.customFuncs[fn.Name.Name] = true
-				}
+	for _, file := range pkg.Syntax {
+		if filepath.Base(g.fset.Position(file.Pos()).Filename) == outputName {
+			continue
+		}
 
-				return true
-			})
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+
+			if name := fn.Name.Name; strings.HasPrefix(name, convertPrefix) || strings.HasPrefix(name, beforePrefix) || strings.HasPrefix(name, afterPrefix) ||
+				strings.HasPrefix(name, defaultsHookPrefix) {
+				funcs[name] = true
+			}
 		}
 	}
 
-	return nil
+	return funcs
 }
 
 // --- Code generation ---
@@ -277,6 +477,15 @@ type templateData struct {
 	PackageName string
 	Imports     []string
 	Funcs       []funcData
+	SchemeFuncs []schemeFunc // functions registered in the runtime scheme
+	Validators  []validatorData
+	Defaulters  []defaulterData
+}
+
+// schemeFunc is a generated function registered in the runtime scheme.
+type schemeFunc struct {
+	Name     string
+	Fallible bool
 }
 
 type funcData struct {
@@ -286,15 +495,44 @@ type funcData struct {
 	SrcTypeDecl  string
 	DstTypeDecl  string
 	SrcIsPointer bool
+	Sources      string // parameters of the sources of a many-to-one conversion, replacing src
+	Destinations string // parameters of the destinations of a split conversion, replacing dst
 	Mappings     []string
+	Constructor  *constructorData
+	Methods      *methodData
+	Semantics    []string // doc comment lines on nil and zero values
+	DstZero      string   // zero value of the destination, if dst is reset
+	ResetDst     bool
+	ResetOnNil   bool
+	Patch        bool
+	Mask         *maskData
+	Fallible     bool   // returns an error
+	Before       string // statement calling the Before hook, if any
+	After        string // statement calling the After hook, if any
+	Defaults     string // defaulter called on dst after the mappings, if any
+	Enum         *enumData
+	Validate     string // function validating dst after the mappings, if any
+
+	hooks   []string // names of the Before and After hooks called
+	runtime bool     // calls functions of the runtime package
 }
 
-func (g *generator) generate(pairs []conversionPair, pkgName string) ([]byte, error) {
+func (g *generator) generate(in *packageInput) ([]byte, error) {
+	pkgName, pkgPath := in.pkgName, in.pkgPath
 	data := templateData{PackageName: pkgName}
 	imports := make(map[string]bool)
+	g.imports = imports
+
+	// Process pairs including nested structs (use queue to handle discovered nested pairs).
+	// Routes go last so that a pair registered directly always takes precedence.
+	queue := append([]conversionPair{}, in.pairs...)
+	sort.SliceStable(queue, func(i, j int) bool { return !queue[i].route && queue[j].route })
 
-	// Process pairs including nested structs (use queue to handle discovered nested pairs)
-	queue := append([]conversionPair{}, pairs...)
+	g.fallible = g.findFallible(queue)
+
+	for _, target := range in.defaults {
+		g.registerDefaults(target)
+	}
 
 	for len(queue) > 0 {
 		pair := queue[0]
@@ -308,17 +546,34 @@ func (g *generator) generate(pairs []conversionPair, pkgName string) ([]byte, er
 
 		g.generatedPairs[pairKey] = true
 
-		fd, nestedPairs, err := g.buildFuncDataWithNested(&pair, pkgName, imports)
-		if err != nil {
-			return nil, err
+		fd, nestedPairs, ok := g.buildFuncDataWithNested(&pair, pkgName, pkgPath, imports)
+		if !ok {
+			continue
 		}
 
 		data.Funcs = append(data.Funcs, fd)
 
+		// Only pointer-to-pointer functions fit the scheme's func(*From, *To) shape.
+		// Patches are not conversions, so they stay out of it.
+		if pair.from.isPointer && pair.to.isPointer && !pair.patch && !pair.masked {
+			data.SchemeFuncs = append(data.SchemeFuncs, schemeFunc{Name: fd.Name, Fallible: fd.Fallible})
+		}
+
+		if pair.masked || fd.runtime {
+			imports[in.runtimePath] = true
+		}
+
 		// Add discovered nested pairs to queue
 		queue = append(queue, nestedPairs...)
 	}
 
+	data.Validators = g.validatorFuncs
+	data.Defaulters = g.defaulterFuncs
+
+	if len(data.SchemeFuncs) > 0 || len(data.Validators) > 0 {
+		imports[in.runtimePath] = true
+	}
+
 	for imp := range imports {
 		data.Imports = append(data.Imports, imp)
 	}
@@ -344,17 +599,73 @@ func (g *generator) generate(pairs []conversionPair, pkgName string) ([]byte, er
 }
 
 func (g *generator) pairKey(pair *conversionPair) string {
-	return fmt.Sprintf("%s/%s->%s/%s", pair.from.pkgPath, pair.from.typeName, pair.to.pkgPath, pair.to.typeName)
+	key := fmt.Sprintf("%s/%s->%s/%s", pair.from.pkgPath, pair.from.typeName, pair.to.pkgPath, pair.to.typeName)
+	switch {
+	case pair.patch:
+		key = "patch " + key
+	case pair.masked:
+		key = "mask " + key
+	}
+
+	return key
 }
 
-func (g *generator) buildFuncDataWithNested(pair *conversionPair, pkgName string, imports map[string]bool) (funcData, []conversionPair, error) {
+func (g *generator) newFuncData(pair *conversionPair, pkgName string, imports map[string]bool) funcData {
 	fd := funcData{
-		Name:         fmt.Sprintf("Convert%sTo%s", pair.from.typeName, pair.to.typeName),
-		SrcTypeName:  pair.from.typeName,
-		DstTypeName:  pair.to.typeName,
+		Name:         g.convertFuncName(pair),
+		SrcTypeName:  g.typeExpr(derefType(pair.from.typ)),
+		DstTypeName:  g.typeExpr(derefType(pair.to.typ)),
 		SrcTypeDecl:  formatTypeDecl(pair.from, pkgName),
 		DstTypeDecl:  formatTypeDecl(pair.to, pkgName),
 		SrcIsPointer: pair.from.isPointer,
+		Patch:        pair.patch,
+		Fallible:     g.fallible[g.convertFuncName(pair)] && !pair.masked,
+	}
+
+	if len(pair.sources) > 0 {
+		fd.SrcTypeName, fd.Sources = g.paramsDoc(pair.sources), g.paramsDecl(pair.sources)
+	}
+
+	if len(pair.destinations) > 0 {
+		fd.DstTypeName, fd.Destinations = g.paramsDoc(pair.destinations), g.paramsDecl(pair.destinations)
+	}
+
+	// WithMask variants fill a part of dst only, so whole-object hooks are left
+	// to the conversion itself.
+	if before, after := g.objectHookNames(pair); !pair.masked {
+		g.checkObjectHooks(pair)
+
+		if before != "" {
+			fd.Before = g.callStmt(before, hookArgs(pair)...)
+			fd.hooks = append(fd.hooks, before)
+		}
+
+		if after != "" {
+			fd.After = g.callStmt(after, hookArgs(pair)...)
+			fd.hooks = append(fd.hooks, after)
+		}
+	}
+
+	if pair.opts.defaults && !pair.masked {
+		fd.Defaults = g.newDefaults(pair)
+	}
+
+	if pair.opts.validate && !pair.masked {
+		fd.Validate = g.newValidation(pair)
+	}
+
+	fd.Constructor = g.newConstructorData(pair, fd.Name)
+	fd.Methods = g.newMethodData(pair, fd.Name)
+	fd.Semantics = semanticsDoc(pair)
+
+	if pair.opts.resetDst {
+		if pair.to.isPointer {
+			fd.DstZero = g.typeExpr(derefType(pair.to.typ)) + "{}"
+			fd.ResetDst = true
+			fd.ResetOnNil = resetOnNil(pair)
+		} else {
+			g.errorf(pair.pos, "WithResetDst requires a pointer destination type")
+		}
 	}
 
 	// Collect imports
@@ -366,18 +677,112 @@ func (g *generator) buildFuncDataWithNested(pair *conversionPair, pkgName string
 		imports[pair.to.pkgPath] = true
 	}
 
+	return fd
+}
+
+func (g *generator) buildFuncDataWithNested(pair *conversionPair, pkgName, pkgPath string, imports map[string]bool) (funcData, []conversionPair, bool) {
+	if pair.route {
+		fd, ok := g.buildRouteFuncData(pair, pkgName, pkgPath, imports)
+
+		return fd, nil, ok
+	}
+
+	if pair.masked {
+		return g.buildMaskedFuncData(pair, pkgName, pkgPath, imports)
+	}
+
+	if pair.enum {
+		fd, ok := g.buildEnumFuncData(pair, pkgName, pkgPath, imports)
+
+		return fd, nil, ok
+	}
+
+	fd := g.newFuncData(pair, pkgName, imports)
+
 	// Build mappings and collect nested pairs
-	mappings, nestedPairs, err := g.buildMappingsWithNested(pair)
-	if err != nil {
-		return fd, nil, err
+	mappings, ok := g.buildMappingsWithNested(pair)
+	if !ok {
+		return fd, nil, false
 	}
 
-	fd.Mappings = mappings
+	plan := PairPlan{
+		Func:         fd.Name,
+		PkgPath:      pkgPath,
+		From:         qualifiedTypeName(pair.from),
+		To:           qualifiedTypeName(pair.to),
+		Nested:       pair.nested,
+		Patch:        pair.patch,
+		Hooks:        fd.hooks,
+		Fallible:     fd.Fallible,
+		Validated:    fd.Validate != "",
+		Defaulter:    fd.Defaults,
+		Constructors: fd.Constructor.funcNames(),
+		Methods:      fd.Methods.funcNames(),
+	}
 
-	return fd, nestedPairs, nil
+	if pair.opts.factory != nil {
+		plan.Factory = g.objectExpr(pair.opts.factory.fn)
+	}
+
+	if len(pair.sources) > 0 {
+		plan.From = ""
+		plan.Conflicts = g.checkConflicts(pair)
+
+		for _, s := range pair.sources {
+			plan.Sources = append(plan.Sources, qualifiedTypeName(s.info))
+		}
+	}
+
+	if len(pair.destinations) > 0 {
+		plan.To = ""
+
+		for _, d := range pair.destinations {
+			plan.Destinations = append(plan.Destinations, qualifiedTypeName(d.info))
+		}
+	}
+
+	var nestedPairs []conversionPair
+
+	for _, m := range mappings {
+		if m.code != "" {
+			fd.Mappings = append(fd.Mappings, m.code)
+		}
+
+		// The call of a factory, the blocks of the sources of a many-to-one
+		// conversion and the reads of a split conversion fill no field of their own.
+		if m.plan != (FieldPlan{}) {
+			plan.Fields = append(plan.Fields, m.plan)
+		}
+		fd.runtime = fd.runtime || m.runtime
+
+		if m.nested != nil {
+			nestedPairs = append(nestedPairs, *m.nested)
+		}
+
+		nestedPairs = append(nestedPairs, m.calls...)
+	}
+
+	if len(pair.destinations) > 0 {
+		plan.Unmapped = g.checkUnmapped(pair, plan.Fields)
+	}
+
+	g.result.Plan = append(g.result.Plan, plan)
+
+	return fd, nestedPairs, true
 }
 
-func (g *generator) buildMappingsWithNested(pair *conversionPair) ([]string, []conversionPair, error) {
+// buildMappingsWithNested maps every exported destination field. It reports
+// false when the pair cannot be generated at all; field-level problems are
+// recorded as diagnostics without aborting the pair.
+func (g *generator) buildMappingsWithNested(pair *conversionPair) ([]fieldMapping, bool) {
+	if len(pair.sources) > 0 {
+		return g.buildMultiMappings(pair)
+	}
+
+	if len(pair.destinations) > 0 {
+		return g.buildSplitMappings(pair)
+	}
+
 	fromType := pair.from.typ
 	if ptr, ok := fromType.(*types.Pointer); ok {
 		fromType = ptr.Elem()
@@ -385,7 +790,13 @@ func (g *generator) buildMappingsWithNested(pair *conversionPair) ([]string, []c
 
 	fromStruct, ok := fromType.Underlying().(*types.Struct)
 	if !ok {
-		return nil, nil, fmt.Errorf("from type is not a struct: %v", pair.from.typ)
+		g.errorf(pair.pos, "cannot convert from %s: not a struct type", typeString(pair.from.typ))
+
+		return nil, false
+	}
+
+	if pair.opts.factory != nil {
+		return g.buildFactoryMappings(pair, fromStruct)
 	}
 
 	toType := pair.to.typ
@@ -395,12 +806,18 @@ func (g *generator) buildMappingsWithNested(pair *conversionPair) ([]string, []c
 
 	toStruct, ok := toType.Underlying().(*types.Struct)
 	if !ok {
-		return nil, nil, fmt.Errorf("to type is not a struct: %v", pair.to.typ)
+		g.errorf(pair.pos, "cannot convert to %s: not a struct type", typeString(pair.to.typ))
+
+		return nil, false
 	}
 
-	var mappings []string
+	return g.buildFieldMappings(pair, pairVars, fromStruct, toStruct), true
+}
 
-	var nestedPairs []conversionPair
+// buildFieldMappings maps every exported field of toStruct, and the fields set
+// through setters, from fromStruct.
+func (g *generator) buildFieldMappings(pair *conversionPair, vars mappingVars, fromStruct, toStruct *types.Struct) []fieldMapping {
+	var mappings []fieldMapping
 
 	for i := 0; i < toStruct.NumFields(); i++ {
 		dstField := toStruct.Field(i)
@@ -408,79 +825,351 @@ func (g *generator) buildMappingsWithNested(pair *conversionPair) ([]string, []c
 			continue
 		}
 
-		srcField := findField(fromStruct, dstField.Name())
-		mapping, nested := g.createMappingWithNested(pair, srcField, dstField)
-		mappings = append(mappings, mapping)
+		mappings = append(mappings, g.createFieldMapping(pair, vars, fromStruct, dstField, nil))
+	}
+
+	hasSource := func(name string) bool {
+		_, ok, _ := getterFor(pair, name)
+
+		return findField(fromStruct, name) != nil || ok
+	}
+
+	for _, setter := range g.settersOf(pair, toStruct, hasSource) {
+		mappings = append(mappings, g.createFieldMapping(pair, vars, fromStruct, setter.field, &setter))
+	}
+
+	return mappings
+}
+
+// createFieldMapping maps dstField of the destination of pair, set by setter
+// unless it is nil, from the field of the same name of fromStruct or its getter.
+func (g *generator) createFieldMapping(pair *conversionPair, vars mappingVars, fromStruct *types.Struct, dstField *types.Var, setter *accessor) fieldMapping {
+	if setter == nil {
+		return g.createFieldMappingTo(pair, vars, fromStruct, dstField, vars.dst+"."+dstField.Name())
+	}
+
+	// The value is filled in a variable and set by the setter.
+	local := vars.dst + dstField.Name()
+
+	m := g.callSetter(g.createFieldMappingTo(pair, vars, fromStruct, dstField, local), vars, local, *setter)
+	m.setter = setter
 
-		if nested != nil {
-			nestedPairs = append(nestedPairs, *nested)
+	return m
+}
+
+// createFieldMappingTo maps dstField of the destination of pair to the
+// expression dst from the field of the same name of fromStruct or its getter.
+func (g *generator) createFieldMappingTo(pair *conversionPair, vars mappingVars, fromStruct *types.Struct, dstField *types.Var, dst string) fieldMapping {
+	srcField := findField(fromStruct, dstField.Name())
+
+	getter, ok := accessor{}, false
+	if srcField == nil {
+		var misfit *types.Func
+
+		switch getter, ok, misfit = getterFor(pair, dstField.Name()); {
+		case ok:
+			srcField = getter.field
+		case misfit != nil:
+			g.warnf(misfit.Pos(), "method %s of %s is not a getter: it must take no argument and return a single value",
+				misfit.Name(), typeString(derefType(pair.from.typ)))
 		}
 	}
 
-	return mappings, nestedPairs, nil
+	refs := fieldRefs{vars: vars, dst: dst}
+
+	switch local, read := vars.reads[dstField.Name()]; {
+	case srcField != nil && read:
+		refs.src, refs.addr = local, "&"+local
+	case ok:
+		// Getters return copies, which are read into a variable when their
+		// address is taken.
+		refs.src, refs.addr = vars.src+"."+getter.method+"()", "&"+vars.src+getter.field.Name()
+		refs.load = fmt.Sprintf("%s%s := %s", vars.src, getter.field.Name(), refs.src)
+	case srcField != nil:
+		refs.src, refs.addr = g.sourceRead(pair, vars, srcField), "&"+vars.src+"."+srcField.Name()
+	}
+
+	m := g.createMappingWithNested(pair, refs, srcField, dstField)
+	m.src, m.dst, m.refs = srcField, dstField, refs
+
+	return m
+}
+
+// fieldMapping is the generated code for a single destination field.
+type fieldMapping struct {
+	code     string
+	nested   *conversionPair
+	calls    []conversionPair // pairs converting the cases of a oneof
+	fallible bool             // code returns an error
+	runtime  bool             // code calls the runtime package
+	plan     FieldPlan
+	src, dst *types.Var // src is nil if the source has no such field
+	refs     fieldRefs  // the expressions the code reads and writes the fields with
+	setter   *accessor  // sets the destination field, if it has no exported field
 }
 
-func (g *generator) createMappingWithNested(pair *conversionPair, srcField, dstField *types.Var) (string, *conversionPair) {
+// mappingVars are the variables the mappings of the fields of a pair refer to.
+type mappingVars struct {
+	src, dst string            // the source and the destination
+	hookArgs string            // the arguments of field hooks
+	reads    map[string]string // source fields read ahead into variables, to the variables
+}
+
+// pairVars are the variables of a conversion from src to dst.
+var pairVars = mappingVars{src: "src", dst: "dst", hookArgs: "src, dst"}
+
+// fieldRefs are the expressions the mapping of a field reads the source
+// field and writes the destination field with.
+type fieldRefs struct {
+	vars mappingVars
+	src  string // the value of the source field, e.g. src.Name, or src.GetName() on messages
+	addr string // the address of the source field
+	load string // the statement reading a getter into the variable addr points to, if any
+	dst  string // the destination field
+}
+
+// withLoad returns code, which takes the address of the source field,
+// preceded by the statement loading it, if any.
+func (r fieldRefs) withLoad(code string) string {
+	if r.load == "" {
+		return code
+	}
+
+	return fmt.Sprintf("{\n%s\n%s\n}", r.load, code)
+}
+
+// hookCall returns the call of the field hook fn.
+func (r fieldRefs) hookCall(fn string) string {
+	return fmt.Sprintf("%s(%s)", fn, r.vars.hookArgs)
+}
+
+// sourceRead returns the expression reading the field f of the source of
+// pair. Fields of messages are read through their getters, e.g.
+// src.GetName(), which are nil-safe. Only getters returning the type of
+// the field are used, so optional scalar fields, whose getters dereference
+// them, are read directly. Fields read ahead are read from their variables.
+func (g *generator) sourceRead(pair *conversionPair, vars mappingVars, f *types.Var) string {
+	if local, ok := vars.reads[f.Name()]; ok {
+		return local
+	}
+
+	if isProtoMessage(pair.from.typ) && hasGetter(pair.from.typ, f) {
+		return vars.src + ".Get" + f.Name() + "()"
+	}
+
+	return vars.src + "." + f.Name()
+}
+
+func (g *generator) createMappingWithNested(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) fieldMapping {
 	dstName := dstField.Name()
 
 	// No matching source field -> custom function
 	if srcField == nil {
-		funcName := g.fieldFuncName(pair.from.typeName, pair.to.typeName, dstName, dstName)
+		funcName := g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), dstName, dstName)
+		// Cases of a oneof -> the field of the case, or the case of the set field
+		if !g.customFuncs[funcName] {
+			if m, ok := g.createOneofCaseMapping(pair, refs, dstField); ok {
+				return m
+			}
+
+			if m, ok := g.createOneofFieldMapping(pair, refs, dstField); ok {
+				return m
+			}
+		}
+
+		// Fields without a hook are left to the After hook, if there is one.
+		if _, after := g.objectHookNames(pair); after != "" && !g.customFuncs[funcName] {
+			return fieldMapping{plan: FieldPlan{Dst: dstName, Kind: MappingCustom, Func: after}}
+		}
+
+		// Fields with a default tag are left to the defaulter of WithDefaults.
+		if pair.opts.defaults && !g.customFuncs[funcName] && hasDefaultTag(pair.to, dstName) {
+			return fieldMapping{plan: FieldPlan{Dst: dstName, Kind: MappingCustom, Func: defaultsPrefix + pair.to.typeName}}
+		}
+
+		if !g.customFuncs[funcName] {
+			g.errorf(dstField.Pos(), "missing hook: %s has no field %s; implement %s", pair.from.typeName, dstName, funcName)
+		}
 
-		return fmt.Sprintf("%s(src, dst)", funcName), nil
+		return fieldMapping{
+			code: refs.hookCall(funcName),
+			plan: FieldPlan{Dst: dstName, Kind: MappingCustom, Func: funcName, Missing: !g.customFuncs[funcName]},
+		}
 	}
 
 	srcName := srcField.Name()
 
+	if pair.patch {
+		if m, ok := g.createPatchMapping(pair, refs, srcField, dstField); ok {
+			return m
+		}
+	}
+
 	// Same type -> direct assignment or custom if exists
 	if types.Identical(srcField.Type(), dstField.Type()) {
-		return g.handleIdenticalTypes(pair, srcName, dstName)
+		mapping, nested := g.handleIdenticalTypes(pair, refs, srcName, dstName, dstField.Type())
+
+		return g.newFieldMapping(pair, srcName, dstName, MappingAssign, mapping, nested)
+	}
+
+	funcName := g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), srcName, dstName)
+
+	// Well-known protobuf types and oneofs -> their Go counterparts, unless
+	// there is a custom function
+	if !g.customFuncs[funcName] {
+		if m, ok := g.createWellKnownMapping(pair, refs, srcField, dstField); ok {
+			return m
+		}
+
+		if m, ok := g.createSealedMapping(pair, refs, srcField, dstField); ok {
+			return m
+		}
+	}
+
+	// Standard types such as time.Time and sql.NullString -> built-in converter
+	// of WithBuiltins, unless there is a custom function
+	if c, ok := builtinFor(pair.opts.builtins, srcField.Type(), dstField.Type()); ok && !g.customFuncs[funcName] {
+		return fieldMapping{
+			code:     createBuiltinMapping(c, refs, dstName),
+			fallible: c.fallible,
+			runtime:  true,
+			plan:     FieldPlan{Src: srcName, Dst: dstName, Kind: MappingBuiltin, Func: "runtime." + c.fn},
+		}
 	}
 
 	// Check if both fields are slices of structs
-	if mapping, nested := g.handleSliceField(pair, srcField, dstField, srcName, dstName); mapping != "" {
-		return mapping, nested
+	if mapping, nested := g.handleSliceField(pair, refs, srcField, dstField); mapping != "" {
+		return g.newFieldMapping(pair, srcName, dstName, MappingSlice, mapping, nested)
 	}
 
 	// Check if both fields are maps with struct values
-	if mapping, nested := g.handleMapField(pair, srcField, dstField, srcName, dstName); mapping != "" {
-		return mapping, nested
+	if mapping, nested := g.handleMapField(pair, refs, srcField, dstField); mapping != "" {
+		return g.newFieldMapping(pair, srcName, dstName, MappingMap, mapping, nested)
 	}
 
 	// Check if both fields are structs (nested struct case)
-	if mapping, nested := g.handleStructField(pair, srcField, dstField, srcName, dstName); mapping != "" {
-		return mapping, nested
+	if mapping, nested := g.handleStructField(pair, refs, srcField, dstField); mapping != "" {
+		return g.newFieldMapping(pair, srcName, dstName, structMappingKind(srcField, dstField), mapping, nested)
+	}
+
+	// Values behind pointers or of convertible types -> dereference, allocate or convert
+	if !g.customFuncs[funcName] {
+		if m, ok := g.createValueMapping(pair, refs, srcField, dstField); ok {
+			return m
+		}
 	}
 
 	// Different type (non-struct) -> custom function
-	funcName := g.fieldFuncName(pair.from.typeName, pair.to.typeName, srcName, dstName)
+	if !g.customFuncs[funcName] {
+		g.errorf(dstField.Pos(), "unsupported field kind: cannot convert %s.%s (%s) to %s.%s (%s); implement %s",
+			pair.from.typeName, srcName, typeString(srcField.Type()), pair.to.typeName, dstName, typeString(dstField.Type()), funcName)
+	}
+
+	m := g.newFieldMapping(pair, srcName, dstName, MappingCustom, refs.hookCall(funcName), nil)
+	m.plan.Missing = !g.customFuncs[funcName]
+
+	return m
+}
+
+// newFieldMapping builds a fieldMapping and its plan entry. A mapping that
+// ends up calling the field-level custom function is planned as MappingCustom.
+func (g *generator) newFieldMapping(pair *conversionPair, srcName, dstName string, kind MappingKind, code string, nested *conversionPair) fieldMapping {
+	m := fieldMapping{
+		code:   code,
+		nested: nested,
+		plan:   FieldPlan{Src: srcName, Dst: dstName, Kind: kind},
+	}
+
+	if nested != nil {
+		m.plan.Func = g.convertFuncName(nested)
+
+		return m
+	}
+
+	if funcName := g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), srcName, dstName); kind == MappingCustom || g.customFuncs[funcName] {
+		m.plan.Kind = MappingCustom
+		m.plan.Func = funcName
+	}
+
+	return m
+}
+
+func structMappingKind(srcField, dstField *types.Var) MappingKind {
+	_, srcPtr := srcField.Type().(*types.Pointer)
+	_, dstPtr := dstField.Type().(*types.Pointer)
+
+	if srcPtr || dstPtr {
+		return MappingPointer
+	}
 
-	return fmt.Sprintf("%s(src, dst)", funcName), nil
+	return MappingNested
 }
 
-func (g *generator) handleIdenticalTypes(pair *conversionPair, srcName, dstName string) (string, *conversionPair) {
-	funcName := g.fieldFuncName(pair.from.typeName, pair.to.typeName, srcName, dstName)
+// convertFuncName returns the name of the generated function converting pair,
+// or applying it for patches.
+func (g *generator) convertFuncName(pair *conversionPair) string {
+	prefix, suffix := convertPrefix, ""
+
+	switch {
+	case pair.patch:
+		prefix = applyPrefix
+	case pair.masked:
+		suffix = maskSuffix
+	}
+
+	return fmt.Sprintf("%s%sTo%s%s", prefix, g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), suffix)
+}
+
+// funcTypeName returns the name info has in the function names of pair. Types of
+// qualified pairs declared outside the generated package are prefixed with their
+// package name, so that v1.User becomes V1User.
+func (g *generator) funcTypeName(pair *conversionPair, info typeInfo) string {
+	if !pair.qualified || info.pkgPath == g.pkgPath || info.pkgName == "" {
+		return info.typeName
+	}
+
+	return strings.ToUpper(info.pkgName[:1]) + info.pkgName[1:] + info.typeName
+}
+
+func (g *generator) handleIdenticalTypes(pair *conversionPair, refs fieldRefs, srcName, dstName string, dstType types.Type) (string, *conversionPair) {
+	funcName := g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), srcName, dstName)
 	if g.customFuncs[funcName] {
-		return fmt.Sprintf("%s(src, dst)", funcName), nil
+		return refs.hookCall(funcName), nil
+	}
+
+	mapping := fmt.Sprintf("%s = %s", refs.dst, refs.src)
+
+	switch dstType.Underlying().(type) {
+	case *types.Slice, *types.Map:
+		if pair.opts.emptyCollections {
+			mapping += fmt.Sprintf(`
+	if %s == nil {
+		%s = %s{}
+	}`, refs.dst, refs.dst, g.typeExpr(dstType))
+		}
 	}
 
-	return fmt.Sprintf("dst.%s = src.%s", dstName, srcName), nil
+	return mapping, nil
 }
 
-func (g *generator) handleSliceField(pair *conversionPair, srcField, dstField *types.Var, srcName, dstName string) (string, *conversionPair) {
+func (g *generator) handleSliceField(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) (string, *conversionPair) {
 	srcSlice, dstSlice := getSliceElemType(srcField.Type()), getSliceElemType(dstField.Type())
-	if srcSlice == nil || dstSlice == nil || !isStructType(srcSlice) || !isStructType(dstSlice) {
+	if srcSlice == nil || dstSlice == nil {
+		return "", nil
+	}
+
+	enum := g.enumFor(srcSlice, dstSlice)
+	if enum == nil && (!isStructType(srcSlice) || !isStructType(dstSlice)) {
 		return "", nil
 	}
 
 	srcElemInfo := extractTypeInfo(srcSlice)
 	dstElemInfo := extractTypeInfo(dstSlice)
-	funcName := fmt.Sprintf("Convert%sTo%s", srcElemInfo.typeName, dstElemInfo.typeName)
 
 	// Check if custom function exists
-	fieldFuncName := g.fieldFuncName(pair.from.typeName, pair.to.typeName, srcName, dstName)
+	fieldFuncName := g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), srcField.Name(), dstField.Name())
 	if g.customFuncs[fieldFuncName] {
-		return fmt.Sprintf("%s(src, dst)", fieldFuncName), nil
+		return refs.hookCall(fieldFuncName), nil
 	}
 
 	nestedPair := &conversionPair{
@@ -498,27 +1187,40 @@ func (g *generator) handleSliceField(pair *conversionPair, srcField, dstField *t
 			typ:       dstSlice,
 			isPointer: true,
 		},
+		pos:       dstField.Pos(),
+		nested:    true,
+		qualified: pair.qualified,
+		opts:      pair.opts.nested(),
+	}
+	if enum != nil {
+		nestedPair = enum
 	}
 
-	return g.createSliceMapping(funcName, srcName, dstName, dstElemInfo.typeName), nestedPair
+	funcName := g.convertFuncName(nestedPair)
+
+	return g.createSliceMapping(funcName, refs.src, refs.dst, g.typeExpr(dstSlice), pair.opts.emptyCollections), nestedPair
 }
 
-func (g *generator) handleMapField(pair *conversionPair, srcField, dstField *types.Var, srcName, dstName string) (string, *conversionPair) {
+func (g *generator) handleMapField(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) (string, *conversionPair) {
 	_, srcMapVal := getMapTypes(srcField.Type())
 	_, dstMapVal := getMapTypes(dstField.Type())
 
-	if srcMapVal == nil || dstMapVal == nil || !isStructType(srcMapVal) || !isStructType(dstMapVal) {
+	if srcMapVal == nil || dstMapVal == nil {
+		return "", nil
+	}
+
+	enum := g.enumFor(srcMapVal, dstMapVal)
+	if enum == nil && (!isStructType(srcMapVal) || !isStructType(dstMapVal)) {
 		return "", nil
 	}
 
 	srcValInfo := extractTypeInfo(srcMapVal)
 	dstValInfo := extractTypeInfo(dstMapVal)
-	funcName := fmt.Sprintf("Convert%sTo%s", srcValInfo.typeName, dstValInfo.typeName)
 
 	// Check if custom function exists
-	fieldFuncName := g.fieldFuncName(pair.from.typeName, pair.to.typeName, srcName, dstName)
+	fieldFuncName := g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), srcField.Name(), dstField.Name())
 	if g.customFuncs[fieldFuncName] {
-		return fmt.Sprintf("%s(src, dst)", fieldFuncName), nil
+		return refs.hookCall(fieldFuncName), nil
 	}
 
 	nestedPair := &conversionPair{
@@ -536,24 +1238,47 @@ func (g *generator) handleMapField(pair *conversionPair, srcField, dstField *typ
 			typ:       dstMapVal,
 			isPointer: true,
 		},
+		pos:       dstField.Pos(),
+		nested:    true,
+		patch:     pair.patch,
+		qualified: pair.qualified,
+		opts:      pair.opts.nested(),
+	}
+	if enum != nil {
+		nestedPair = enum
 	}
 
-	return g.createMapMapping(funcName, srcName, dstName, srcField.Type(), dstField.Type(), dstValInfo.typeName), nestedPair
+	funcName := g.convertFuncName(nestedPair)
+
+	if pair.patch {
+		return g.createMapMergeMapping(funcName, refs.src, refs.dst, g.typeExpr(dstField.Type()), g.typeExpr(dstMapVal)), nestedPair
+	}
+
+	return g.createMapMapping(funcName, refs.src, refs.dst, srcField.Type(), dstField.Type(), g.typeExpr(dstMapVal), pair.opts.emptyCollections), nestedPair
 }
 
-func (g *generator) handleStructField(pair *conversionPair, srcField, dstField *types.Var, srcName, dstName string) (string, *conversionPair) {
-	if !isStructType(srcField.Type()) || !isStructType(dstField.Type()) {
+func (g *generator) handleStructField(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) (string, *conversionPair) {
+	enum := g.enumFor(srcField.Type(), dstField.Type())
+	if enum == nil && (!isStructType(srcField.Type()) || !isStructType(dstField.Type())) {
+		return "", nil
+	}
+
+	// Values and pointers of the same struct are copied as a whole by
+	// createValueMapping with BuiltinPointers. Structs without exported
+	// fields, such as time.Time, are never converted field by field, which
+	// would copy nothing.
+	srcElem, dstElem := derefType(srcField.Type()), derefType(dstField.Type())
+	if enum == nil && (types.Identical(srcElem, dstElem) && pair.opts.builtins&builtinPointers != 0 || !hasExportedFields(srcElem) || !hasExportedFields(dstElem)) {
 		return "", nil
 	}
 
 	srcInfo := extractTypeInfo(srcField.Type())
 	dstInfo := extractTypeInfo(dstField.Type())
-	funcName := fmt.Sprintf("Convert%sTo%s", srcInfo.typeName, dstInfo.typeName)
 
 	// Check if custom function exists
-	fieldFuncName := g.fieldFuncName(pair.from.typeName, pair.to.typeName, srcName, dstName)
+	fieldFuncName := g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), srcField.Name(), dstField.Name())
 	if g.customFuncs[fieldFuncName] {
-		return fmt.Sprintf("%s(src, dst)", fieldFuncName), nil
+		return refs.hookCall(fieldFuncName), nil
 	}
 
 	// Create nested pair for generation (always use pointer for nested struct conversion)
@@ -572,78 +1297,101 @@ func (g *generator) handleStructField(pair *conversionPair, srcField, dstField *
 			typ:       dstInfo.typ,
 			isPointer: true,
 		},
+		pos:       dstField.Pos(),
+		nested:    true,
+		patch:     pair.patch,
+		qualified: pair.qualified,
+		opts:      pair.opts.nested(),
 	}
-
-	// For pointer fields, need nil check and allocation
-	if srcInfo.isPointer || dstInfo.isPointer {
-		return g.createPointerFieldMapping(funcName, srcName, dstName, srcInfo.isPointer, dstInfo.isPointer, dstInfo.typeName), nestedPair
+	if enum != nil {
+		nestedPair = enum
 	}
 
-	// Determine how to pass the field (with or without &)
-	srcExpr := fmt.Sprintf("&src.%s", srcName)
-	dstExpr := fmt.Sprintf("&dst.%s", dstName)
+	funcName := g.convertFuncName(nestedPair)
 
-	if srcInfo.isPointer {
-		srcExpr = fmt.Sprintf("src.%s", srcName)
+	if pair.patch && dstInfo.isPointer {
+		return g.createMergePointerFieldMapping(funcName, refs, srcInfo.isPointer, g.typeExpr(derefType(dstInfo.typ))), nestedPair
 	}
 
-	if dstInfo.isPointer {
-		dstExpr = fmt.Sprintf("dst.%s", dstName)
+	// For pointer fields, need nil check and allocation
+	if srcInfo.isPointer || dstInfo.isPointer {
+		return g.createPointerFieldMapping(funcName, refs, srcInfo.isPointer, dstInfo.isPointer, g.typeExpr(derefType(dstInfo.typ))), nestedPair
 	}
 
-	return fmt.Sprintf("%s(%s, %s)", funcName, srcExpr, dstExpr), nestedPair
+	return refs.withLoad(g.callStmt(funcName, refs.addr, "&"+refs.dst)), nestedPair
 }
 
 // createPointerFieldMapping creates mapping code for pointer struct fields.
-func (g *generator) createPointerFieldMapping(funcName, srcName, dstName string, srcIsPtr, dstIsPtr bool, dstTypeName string) string {
+func (g *generator) createPointerFieldMapping(funcName string, refs fieldRefs, srcIsPtr, dstIsPtr bool, dstTypeName string) string {
 	// Both are pointers: if src != nil, allocate dst and convert
 	if srcIsPtr && dstIsPtr {
-		return fmt.Sprintf(`if src.%s != nil {
-		dst.%s = new(%s)
-		%s(src.%s, dst.%s)
-	}`, srcName, dstName, dstTypeName, funcName, srcName, dstName)
+		return fmt.Sprintf(`if %s != nil {
+		%s = new(%s)
+		%s
+	}`, refs.src, refs.dst, dstTypeName, g.callStmt(funcName, refs.src, refs.dst))
 	}
 
 	// Only src is pointer: if src != nil, convert to non-pointer dst
 	if srcIsPtr {
-		return fmt.Sprintf(`if src.%s != nil {
-		%s(src.%s, &dst.%s)
-	}`, srcName, funcName, srcName, dstName)
+		return fmt.Sprintf(`if %s != nil {
+		%s
+	}`, refs.src, g.callStmt(funcName, refs.src, "&"+refs.dst))
 	}
 
 	// Only dst is pointer: allocate dst and convert
-	return fmt.Sprintf(`dst.%s = new(%s)
-	%s(&src.%s, dst.%s)`, dstName, dstTypeName, funcName, srcName, dstName)
+	return refs.withLoad(fmt.Sprintf(`%s = new(%s)
+	%s`, refs.dst, dstTypeName, g.callStmt(funcName, refs.addr, refs.dst)))
 }
 
-// createSliceMapping creates mapping code for slice fields.
-func (g *generator) createSliceMapping(funcName, srcName, dstName, dstElemTypeName string) string {
-	return fmt.Sprintf(`if src.%s != nil {
-		dst.%s = make([]%s, len(src.%s))
-		for i := range src.%s {
-			%s(&src.%s[i], &dst.%s[i])
+// createSliceMapping creates the code converting the slice src to dst. Unless
+// empty is set, a nil source slice leaves the destination field untouched.
+func (g *generator) createSliceMapping(funcName, src, dst, dstElemTypeName string, empty bool) string {
+	call := g.callStmt(funcName, fmt.Sprintf("&%s[i]", src), fmt.Sprintf("&%s[i]", dst))
+
+	if empty {
+		return fmt.Sprintf(`%s = make([]%s, len(%s))
+	for i := range %s {
+		%s
+	}`, dst, dstElemTypeName, src, src, call)
+	}
+
+	return fmt.Sprintf(`if %s != nil {
+		%s = make([]%s, len(%s))
+		for i := range %s {
+			%s
 		}
-	}`, srcName, dstName, dstElemTypeName, srcName, srcName, funcName, srcName, dstName)
+	}`, src, dst, dstElemTypeName, src, src, call)
 }
 
-// createMapMapping creates mapping code for map fields.
-func (g *generator) createMapMapping(funcName, srcName, dstName string, srcType, _ types.Type, dstValTypeName string) string {
+// createMapMapping creates the code converting the map src to dst. Unless
+// empty is set, a nil source map leaves the destination field untouched.
+func (g *generator) createMapMapping(funcName, src, dst string, srcType, _ types.Type, dstValTypeName string, empty bool) string {
 	// Get key type string
 	srcMap, ok := srcType.Underlying().(*types.Map)
 	if !ok {
-		return fmt.Sprintf("// Error: %s is not a map type", srcName)
+		return fmt.Sprintf("// Error: %s is not a map type", src)
 	}
 
-	keyTypeStr := srcMap.Key().String()
+	keyTypeStr := g.typeExpr(srcMap.Key())
+	call := g.callStmt(funcName, "&v", "&converted")
+
+	if empty {
+		return fmt.Sprintf(`%s = make(map[%s]%s, len(%s))
+	for k, v := range %s {
+		var converted %s
+		%s
+		%s[k] = converted
+	}`, dst, keyTypeStr, dstValTypeName, src, src, dstValTypeName, call, dst)
+	}
 
-	return fmt.Sprintf(`if src.%s != nil {
-		dst.%s = make(map[%s]%s, len(src.%s))
-		for k, v := range src.%s {
+	return fmt.Sprintf(`if %s != nil {
+		%s = make(map[%s]%s, len(%s))
+		for k, v := range %s {
 			var converted %s
-			%s(&v, &converted)
-			dst.%s[k] = converted
+			%s
+			%s[k] = converted
 		}
-	}`, srcName, dstName, keyTypeStr, dstValTypeName, srcName, srcName, dstValTypeName, funcName, dstName)
+	}`, src, dst, keyTypeStr, dstValTypeName, src, src, dstValTypeName, call, dst)
 }
 
 // getSliceElemType returns the element type if t is a slice, otherwise nil.
@@ -664,7 +1412,32 @@ func getMapTypes(t types.Type) (types.Type, types.Type) {
 	return nil, nil
 }
 
+// derefType returns the element type if t is a pointer, otherwise t.
+func derefType(t types.Type) types.Type {
+	if ptr, ok := t.(*types.Pointer); ok {
+		return ptr.Elem()
+	}
+
+	return t
+}
+
 // isStructType checks if the type is a struct (including named struct types).
+// hasExportedFields reports whether the struct type t has an exported field.
+func hasExportedFields(t types.Type) bool {
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < s.NumFields(); i++ {
+		if s.Field(i).Exported() {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isStructType(t types.Type) bool {
 	// Unwrap pointer
 	if ptr, ok := t.(*types.Pointer); ok {
@@ -691,6 +1464,36 @@ func findField(s *types.Struct, name string) *types.Var {
 	return nil
 }
 
+// typeString formats t with package names rather than import paths.
+func typeString(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string { return p.Name() })
+}
+
+// typeExpr formats t as it is written in the generated package, and records
+// the packages it refers to as imports of the output.
+func (g *generator) typeExpr(t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p.Path() == g.pkgPath {
+			return ""
+		}
+
+		if g.imports != nil {
+			g.imports[p.Path()] = true
+		}
+
+		return p.Name()
+	})
+}
+
+// qualifiedTypeName returns the package-qualified name of a type, e.g. "example.com/domain.User".
+func qualifiedTypeName(info typeInfo) string {
+	if info.pkgPath == "" {
+		return info.typeName
+	}
+
+	return info.pkgPath + "." + info.typeName
+}
+
 func formatTypeDecl(info typeInfo, pkgName string) string {
 	ptr := ""
 	if info.isPointer {
@@ -1,8 +1,12 @@
 package gonverter
 
 import (
+	"bytes"
+	"context"
 	"go/token"
 	"go/types"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -146,11 +150,6 @@ func TestFieldFuncName(t *testing.T) {
 	}
 }
 
-func TestHasBuildTag(_ *testing.T) {
-	// This would require parsing actual Go files, so we skip detailed testing here.
-	// The function is tested implicitly through integration tests.
-}
-
 func TestGetSliceElemType(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -379,7 +378,9 @@ func TestCreatePointerFieldMapping(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := g.createPointerFieldMapping(tt.funcName, tt.srcName, tt.dstName, tt.srcIsPtr, tt.dstIsPtr, tt.dstTypeName)
+			refs := fieldRefs{src: "src." + tt.srcName, addr: "&src." + tt.srcName, dst: "dst." + tt.dstName}
+
+			got := g.createPointerFieldMapping(tt.funcName, refs, tt.srcIsPtr, tt.dstIsPtr, tt.dstTypeName)
 			if !contains(got, tt.wantSubstr) {
 				t.Errorf("createPointerFieldMapping() = %q, want to contain %q", got, tt.wantSubstr)
 			}
@@ -389,7 +390,7 @@ func TestCreatePointerFieldMapping(t *testing.T) {
 
 func TestCreateSliceMapping(t *testing.T) {
 	g := &generator{}
-	got := g.createSliceMapping("ConvertItemRequestToItem", "Items", "Items", "Item")
+	got := g.createSliceMapping("ConvertItemRequestToItem", "src.Items", "dst.Items", "Item", false)
 
 	wantSubstrings := []string{
 		"src.Items != nil",
@@ -410,7 +411,7 @@ func TestCreateMapMapping(t *testing.T) {
 	srcMap := types.NewMap(types.Typ[types.String], types.NewStruct(nil, nil))
 	dstMap := types.NewMap(types.Typ[types.String], types.NewStruct(nil, nil))
 
-	got := g.createMapMapping("ConvertSettingRequestToSetting", "Settings", "Settings", srcMap, dstMap, "Setting")
+	got := g.createMapMapping("ConvertSettingRequestToSetting", "src.Settings", "dst.Settings", srcMap, dstMap, "Setting", false)
 
 	wantSubstrings := []string{
 		"src.Settings != nil",
@@ -492,3 +493,97 @@ func TestRunWithInvalidPattern(t *testing.T) {
 		t.Error("expected error for invalid pattern, got nil")
 	}
 }
+
+func TestGenerateMatchesTestdata(t *testing.T) {
+	tests := []struct {
+		dir     string // the module directory the package is resolved in
+		pattern string
+	}{
+		{"../..", "./testdata/pointer"},
+		{"../..", "./testdata/slice"},
+		{"../..", "./testdata/maptype"},
+		{"../..", "./testdata/nested"},
+		{"../..", "./testdata/bidirectional"},
+		{"../..", "./testdata/scheme"},
+		{"../..", "./testdata/hub"},
+		{"../..", "./testdata/multihop"},
+		{"../..", "./testdata/constructor"},
+		{"../..", "./testdata/methods"},
+		{"../..", "./testdata/semantics"},
+		{"../..", "./testdata/patch"},
+		{"../..", "./testdata/fieldmask"},
+		{"../..", "./testdata/hooks"},
+		{"../..", "./testdata/validation"},
+		{"../..", "./testdata/defaults"},
+		{"../..", "./testdata/enum"},
+		{"../..", "./testdata/builtin"},
+		{"../..", "./testdata/value"},
+		{"../..", "./testdata/accessor"},
+		{"../..", "./testdata/factory"},
+		{"../..", "./testdata/multisource"},
+		{"../..", "./testdata/split"},
+		{"../..", "./examples/simple/converter"},
+		// The example is a module of its own so that gonverter doesn't depend on protobuf.
+		{"../../examples/protobuf", "./converter"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			res, err := Generate(context.Background(), Options{Dir: tt.dir, Patterns: []string{tt.pattern}})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			if err := res.Err(); err != nil {
+				t.Fatalf("Err() = %v", err)
+			}
+
+			if len(res.Files) != 1 {
+				t.Fatalf("len(Files) = %d, want 1", len(res.Files))
+			}
+
+			want, err := os.ReadFile(res.Files[0].Path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !bytes.Equal(res.Files[0].Content, want) {
+				t.Errorf("%s is out of date: run go generate", res.Files[0].Path)
+			}
+		})
+	}
+}
+
+func TestGenerateReportsInvalidRegistrations(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		severity Severity
+		message  string
+	}{
+		{"../../testdata/invalid", SeverityError, "invalid hook AfterConvertSourceToTarget"},
+		{"../../testdata/invalid", SeverityError, "invalid hook BeforeConvertSourceToTarget"},
+		{"../../testdata/invalidmulti", SeverityError, "invalid hook AfterConvertBodyAndPathToMember"},
+		{"../../testdata/multisource", SeverityWarning, "ambiguous field: TenantID"},
+		{"../../testdata/invalidmulti", SeverityError, "invalid precedence invalidmulti.Query"},
+		{"../../testdata/invalidmulti", SeverityError, "cannot convert from *invalidmulti.Scalar: not a struct type"},
+		{"../../testdata/invalid", SeverityError, "WithFieldMask cannot select the fields of Profile in part"},
+		{"../../testdata/invalid", SeverityError, "cannot convert Window.Opens (time.Time) to WindowDTO.Opens (*time.Time)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			res, err := Generate(context.Background(), Options{Patterns: []string{tt.pattern}})
+			if err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			for _, d := range res.Diagnostics {
+				if d.Severity == tt.severity && strings.Contains(d.Message, tt.message) {
+					return
+				}
+			}
+
+			t.Errorf("Diagnostics = %v, want %s %q", res.Diagnostics, tt.severity, tt.message)
+		})
+	}
+}
@@ -0,0 +1,241 @@
+package gonverter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GraphFormat is an output format of [Result.WriteGraph].
+type GraphFormat string
+
+// Graph formats.
+const (
+	GraphDOT     GraphFormat = "dot"
+	GraphMermaid GraphFormat = "mermaid"
+)
+
+type graphEdgeKind int
+
+const (
+	edgeRegistered graphEdgeKind = iota
+	edgeNested
+	edgeRoute
+	edgeHook
+)
+
+// graphEdge is a conversion between two types, or a hook used by one.
+type graphEdge struct {
+	from, to int // node indexes
+	label    string
+	kind     graphEdgeKind
+	alert    bool // the edge has missing hooks or loses fields
+}
+
+// color returns the color an edge is highlighted in, if any.
+func (e graphEdge) color() string {
+	switch {
+	case e.alert:
+		return "red"
+	case e.kind == edgeHook:
+		return "blue"
+	default:
+		return ""
+	}
+}
+
+// planGraph is the graph of a plan, with types as nodes.
+type planGraph struct {
+	nodes []string // qualified type names in order of appearance
+	index map[string]int
+	edges []graphEdge
+}
+
+func newPlanGraph(plan []PairPlan) *planGraph {
+	v := &planGraph{index: make(map[string]int)}
+
+	for _, p := range plan {
+		// Many-to-one and split conversions have an edge from each source to
+		// each destination.
+		froms, tos := []string{p.From}, []string{p.To}
+		if len(p.Sources) > 0 {
+			froms = p.Sources
+		}
+
+		if len(p.Destinations) > 0 {
+			tos = p.Destinations
+		}
+
+		from, to := v.node(froms[0]), v.node(tos[0])
+
+		for _, name := range froms {
+			for _, dst := range tos {
+				v.edges = append(v.edges, newConversionEdge(p, v.node(name), v.node(dst)))
+			}
+		}
+
+		// The hooks of a WithMask variant are those of the conversion it wraps.
+		if p.Masked {
+			continue
+		}
+
+		for _, f := range p.Fields {
+			if f.Kind != MappingCustom {
+				continue
+			}
+
+			hook := graphEdge{from: from, to: to, label: f.Dst + ": " + f.Func, kind: edgeHook}
+			if f.Source != "" {
+				hook.from = v.node(f.Source)
+			}
+
+			if f.Destination != "" {
+				hook.to = v.node(f.Destination)
+			}
+
+			if f.Missing {
+				hook.alert = true
+				hook.label = f.Dst + ": missing " + f.Func
+			}
+
+			v.edges = append(v.edges, hook)
+		}
+	}
+
+	return v
+}
+
+// newConversionEdge returns the edge of the conversion p from one of its
+// sources to one of its destinations.
+func newConversionEdge(p PairPlan, from, to int) graphEdge {
+	edge := graphEdge{from: from, to: to, label: p.Func}
+
+	switch {
+	case len(p.Via) > 0:
+		edge.kind = edgeRoute
+		edge.label += " via " + shortTypeNames(p.Via)
+	case p.Nested:
+		edge.kind = edgeNested
+	}
+
+	for _, alert := range []struct {
+		name   string
+		fields []string
+	}{{"lossy", p.Lossy}, {"conflicts", p.Conflicts}, {"unmapped", p.Unmapped}} {
+		if len(alert.fields) > 0 {
+			edge.alert = true
+			edge.label += " (" + alert.name + ": " + strings.Join(alert.fields, ", ") + ")"
+		}
+	}
+
+	return edge
+}
+
+func (v *planGraph) node(name string) int {
+	if i, ok := v.index[name]; ok {
+		return i
+	}
+
+	v.index[name] = len(v.nodes)
+	v.nodes = append(v.nodes, name)
+
+	return len(v.nodes) - 1
+}
+
+// shortTypeName strips the import path of a qualified type name except for its last element.
+func shortTypeName(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+func shortTypeNames(names []string) string {
+	short := make([]string, len(names))
+	for i, n := range names {
+		short[i] = shortTypeName(n)
+	}
+
+	return strings.Join(short, ", ")
+}
+
+// WriteGraph writes the graph of the planned conversions to w. Types are
+// nodes; registered, nested and composed conversions and the hooks they call
+// are edges. Missing hooks and lossy conversions are highlighted.
+func (r *Result) WriteGraph(w io.Writer, format GraphFormat) error {
+	v := newPlanGraph(r.Plan)
+
+	var b strings.Builder
+
+	switch format {
+	case GraphDOT:
+		v.writeDOT(&b)
+	case GraphMermaid:
+		v.writeMermaid(&b)
+	default:
+		return fmt.Errorf("unknown graph format %q", format)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("failed to write graph: %w", err)
+	}
+
+	return nil
+}
+
+func (v *planGraph) writeDOT(b *strings.Builder) {
+	b.WriteString("digraph gonverter {\n\trankdir=LR;\n\tnode [shape=box];\n")
+
+	for i, n := range v.nodes {
+		fmt.Fprintf(b, "\tn%d [label=%q];\n", i, shortTypeName(n))
+	}
+
+	for _, e := range v.edges {
+		attrs := []string{fmt.Sprintf("label=%q", e.label)}
+
+		switch e.kind {
+		case edgeNested:
+			attrs = append(attrs, "style=dashed")
+		case edgeRoute:
+			attrs = append(attrs, "style=dotted")
+		case edgeRegistered, edgeHook:
+		}
+
+		if color := e.color(); color != "" {
+			attrs = append(attrs, "color="+color, "fontcolor="+color)
+		}
+
+		fmt.Fprintf(b, "\tn%d -> n%d [%s];\n", e.from, e.to, strings.Join(attrs, ", "))
+	}
+
+	b.WriteString("}\n")
+}
+
+func (v *planGraph) writeMermaid(b *strings.Builder) {
+	b.WriteString("flowchart LR\n")
+
+	for i, n := range v.nodes {
+		fmt.Fprintf(b, "    n%d[\"%s\"]\n", i, shortTypeName(n))
+	}
+
+	var styles []string
+
+	for i, e := range v.edges {
+		arrow := "-->"
+
+		switch e.kind {
+		case edgeNested:
+			arrow = "-.->"
+		case edgeRoute:
+			arrow = "==>"
+		case edgeRegistered, edgeHook:
+		}
+
+		if color := e.color(); color != "" {
+			styles = append(styles, fmt.Sprintf("    linkStyle %d stroke:%s,color:%s", i, color, color))
+		}
+
+		fmt.Fprintf(b, "    n%d %s|\"%s\"| n%d\n", e.from, arrow, e.label, e.to)
+	}
+
+	for _, s := range styles {
+		b.WriteString(s + "\n")
+	}
+}
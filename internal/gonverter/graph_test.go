@@ -0,0 +1,172 @@
+package gonverter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func testGraphResult() *Result {
+	return &Result{Plan: []PairPlan{
+		{
+			Func: "ConvertAToB", From: "example.com/p.A", To: "example.com/q.B",
+			Fields: []FieldPlan{
+				{Src: "Name", Dst: "Name", Kind: MappingCustom, Func: "ConvertANameToBName"},
+				{Dst: "Extra", Kind: MappingCustom, Func: "ConvertAExtraToBExtra", Missing: true},
+			},
+		},
+		{Func: "ConvertXToY", From: "example.com/p.X", To: "example.com/q.Y", Nested: true},
+		{Func: "ConvertAToC", From: "example.com/p.A", To: "example.com/r.C", Via: []string{"example.com/q.B"}, Lossy: []string{"ID"}},
+	}}
+}
+
+func TestWriteGraphDOT(t *testing.T) {
+	var b strings.Builder
+	if err := testGraphResult().WriteGraph(&b, GraphDOT); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `digraph gonverter {
+	rankdir=LR;
+	node [shape=box];
+	n0 [label="p.A"];
+	n1 [label="q.B"];
+	n2 [label="p.X"];
+	n3 [label="q.Y"];
+	n4 [label="r.C"];
+	n0 -> n1 [label="ConvertAToB"];
+	n0 -> n1 [label="Name: ConvertANameToBName", color=blue, fontcolor=blue];
+	n0 -> n1 [label="Extra: missing ConvertAExtraToBExtra", color=red, fontcolor=red];
+	n2 -> n3 [label="ConvertXToY", style=dashed];
+	n0 -> n4 [label="ConvertAToC via q.B (lossy: ID)", style=dotted, color=red, fontcolor=red];
+}
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteGraph() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteGraphMermaid(t *testing.T) {
+	var b strings.Builder
+	if err := testGraphResult().WriteGraph(&b, GraphMermaid); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `flowchart LR
+    n0["p.A"]
+    n1["q.B"]
+    n2["p.X"]
+    n3["q.Y"]
+    n4["r.C"]
+    n0 -->|"ConvertAToB"| n1
+    n0 -->|"Name: ConvertANameToBName"| n1
+    n0 -->|"Extra: missing ConvertAExtraToBExtra"| n1
+    n2 -.->|"ConvertXToY"| n3
+    n0 ==>|"ConvertAToC via q.B (lossy: ID)"| n4
+    linkStyle 1 stroke:blue,color:blue
+    linkStyle 2 stroke:red,color:red
+    linkStyle 4 stroke:red,color:red
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteGraph() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteGraphSeveralSources(t *testing.T) {
+	res := &Result{Plan: []PairPlan{{
+		Func: "ConvertAAndBToC", Sources: []string{"example.com/p.A", "example.com/p.B"}, To: "example.com/q.C",
+		Conflicts: []string{"ID"},
+		Fields: []FieldPlan{
+			{Src: "Name", Dst: "Name", Kind: MappingCustom, Func: "ConvertAAndBNameToCName", Source: "example.com/p.B"},
+		},
+	}}}
+
+	var b strings.Builder
+	if err := res.WriteGraph(&b, GraphMermaid); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `flowchart LR
+    n0["p.A"]
+    n1["q.C"]
+    n2["p.B"]
+    n0 -->|"ConvertAAndBToC (conflicts: ID)"| n1
+    n2 -->|"ConvertAAndBToC (conflicts: ID)"| n1
+    n2 -->|"Name: ConvertAAndBNameToCName"| n1
+    linkStyle 0 stroke:red,color:red
+    linkStyle 1 stroke:red,color:red
+    linkStyle 2 stroke:blue,color:blue
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteGraph() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteGraphSeveralDestinations(t *testing.T) {
+	res := &Result{Plan: []PairPlan{{
+		Func: "ConvertAToBAndC", From: "example.com/p.A", Destinations: []string{"example.com/q.B", "example.com/q.C"},
+		Unmapped: []string{"Note"},
+		Fields: []FieldPlan{
+			{Src: "Name", Dst: "Name", Kind: MappingCustom, Func: "ConvertANameToCName", Destination: "example.com/q.C"},
+		},
+	}}}
+
+	var b strings.Builder
+	if err := res.WriteGraph(&b, GraphMermaid); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `flowchart LR
+    n0["p.A"]
+    n1["q.B"]
+    n2["q.C"]
+    n0 -->|"ConvertAToBAndC (unmapped: Note)"| n1
+    n0 -->|"ConvertAToBAndC (unmapped: Note)"| n2
+    n0 -->|"Name: ConvertANameToCName"| n2
+    linkStyle 0 stroke:red,color:red
+    linkStyle 1 stroke:red,color:red
+    linkStyle 2 stroke:blue,color:blue
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteGraph() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteGraphUnknownFormat(t *testing.T) {
+	var b strings.Builder
+	if err := testGraphResult().WriteGraph(&b, "svg"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestGeneratePlanMarksNestedAndMissing(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/invalid"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	var missing []string
+
+	for _, p := range res.Plan {
+		for _, f := range p.Fields {
+			if f.Missing {
+				missing = append(missing, f.Func)
+			}
+		}
+	}
+
+	if want := "ConvertSourceCountToTargetCount,ConvertSourceExtraToTargetExtra,ConvertWindowOpensToWindowDTOOpens"; strings.Join(missing, ",") != want {
+		t.Errorf("missing hooks = %v, want %s", missing, want)
+	}
+
+	res, err = Generate(context.Background(), Options{Patterns: []string{"../../testdata/nested"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, p := range res.Plan {
+		if want := p.Func != "ConvertUserRequestToUser"; p.Nested != want {
+			t.Errorf("%s: Nested = %v, want %v", p.Func, p.Nested, want)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Prefixes of the whole-object hooks called around the field mappings of a
+// generated function, e.g. BeforeConvertUserRequestToUser.
+const (
+	beforePrefix = "Before"
+	afterPrefix  = "After"
+)
+
+// detectObjectHooks returns the Before and After hooks declared in the package,
+// mapped to whether they return an error, and every function named like a
+// hook, whether its signature fits or not. Those that do not fit are only
+// reported if they are named after a generated function, so other functions
+// named Before or After something are left alone.
+func (g *generator) detectObjectHooks(pkg *packages.Package, customFuncs map[string]bool) (hooks map[string]bool, funcs map[string]*types.Func) {
+	hooks = make(map[string]bool)
+	funcs = make(map[string]*types.Func)
+
+	for _, name := range sortedKeys(customFuncs) {
+		if !strings.HasPrefix(name, beforePrefix) && !strings.HasPrefix(name, afterPrefix) {
+			continue
+		}
+
+		fn, ok := pkg.Types.Scope().Lookup(name).(*types.Func)
+		if !ok {
+			continue
+		}
+
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok {
+			continue
+		}
+
+		funcs[name] = fn
+
+		// Hooks of many-to-one and split conversions take every source and
+		// destination, which hookFits checks against the conversion.
+		if res := sig.Results(); sig.Params().Len() >= 2 && sig.Params().Len() <= 4 && (res.Len() == 0 || res.Len() == 1 && isErrorType(res.At(0).Type())) {
+			hooks[name] = res.Len() == 1
+		}
+	}
+
+	return hooks, funcs
+}
+
+// hookFits reports whether hook is a Before or After hook taking the
+// arguments of the function generated for pair, in the order of hookArgs.
+func (g *generator) hookFits(hook string, pair *conversionPair) bool {
+	if _, ok := g.objectHooks[hook]; !ok {
+		return false
+	}
+
+	params, args := g.hookFuncs[hook].Type().(*types.Signature).Params(), hookArgTypes(pair)
+	if params.Len() != len(args) {
+		return false
+	}
+
+	for i, t := range args {
+		if !types.Identical(params.At(i).Type(), t) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// checkObjectHooks reports the functions named like the Before or After hook
+// of the function generated for pair whose signature does not fit.
+func (g *generator) checkObjectHooks(pair *conversionPair) {
+	name := g.convertFuncName(pair)
+
+	for _, hook := range []string{beforePrefix + name, afterPrefix + name} {
+		if fn := g.hookFuncs[hook]; fn != nil && !g.hookFits(hook, pair) {
+			params := make([]string, 0, len(hookArgs(pair)))
+			for _, t := range hookArgTypes(pair) {
+				params = append(params, typeString(t))
+			}
+
+			g.errorf(fn.Pos(), "invalid hook %s: must be func(%s) or func(%s) error", hook, strings.Join(params, ", "), strings.Join(params, ", "))
+		}
+	}
+}
+
+func isErrorType(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// objectHookNames returns the names of the Before and After hooks of the
+// function generated for pair, or empty strings for hooks that do not exist.
+func (g *generator) objectHookNames(pair *conversionPair) (before, after string) {
+	name := g.convertFuncName(pair)
+
+	if g.hookFits(beforePrefix+name, pair) {
+		before = beforePrefix + name
+	}
+
+	if g.hookFits(afterPrefix+name, pair) {
+		after = afterPrefix + name
+	}
+
+	return before, after
+}
+
+// callStmt returns a statement calling fn, a generated function or a hook,
+// that returns its error from the enclosing function if fn returns one.
+func (g *generator) callStmt(fn string, args ...string) string {
+	call := fmt.Sprintf("%s(%s)", fn, strings.Join(args, ", "))
+	if !g.fallible[fn] && !g.objectHooks[fn] {
+		return call
+	}
+
+	return fmt.Sprintf(`if err := %s; err != nil {
+		return err
+	}`, call)
+}
+
+// findFallible returns the names of the generated functions that return an
+// error: those calling a hook or built-in converter that returns one,
+// validating dst or converting enums without a fallback, and those calling
+// them in turn.
+// It walks the pairs reachable from pairs without reporting diagnostics.
+func (g *generator) findFallible(pairs []conversionPair) map[string]bool {
+	diagnostics := len(g.result.Diagnostics)
+	defer func() { g.result.Diagnostics = g.result.Diagnostics[:diagnostics] }()
+
+	fallible := make(map[string]bool)
+	calls := make(map[string][]string)
+	seen := make(map[string]bool)
+	queue := append([]conversionPair{}, pairs...)
+
+	for len(queue) > 0 {
+		pair := queue[0]
+		queue = queue[1:]
+
+		key := g.pairKey(&pair)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		name := g.convertFuncName(&pair)
+		if before, after := g.objectHookNames(&pair); !pair.masked && (g.objectHooks[before] || g.objectHooks[after] || g.validatesDst(&pair)) {
+			fallible[name] = true
+		}
+
+		if pair.enum {
+			fallible[name] = pair.opts.enumFallback == nil
+
+			continue
+		}
+
+		if pair.route {
+			steps, _ := g.resolveRoute(&pair)
+			for _, step := range steps {
+				calls[name] = append(calls[name], step.fn)
+			}
+
+			continue
+		}
+
+		mappings, _ := g.buildMappingsWithNested(&pair)
+		for _, m := range mappings {
+			if m.fallible {
+				fallible[name] = true
+			}
+
+			if m.nested != nil {
+				calls[name] = append(calls[name], g.convertFuncName(m.nested))
+				queue = append(queue, *m.nested)
+			}
+
+			for _, c := range m.calls {
+				calls[name] = append(calls[name], g.convertFuncName(&c))
+				queue = append(queue, c)
+			}
+		}
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for name, callees := range calls {
+			for _, callee := range callees {
+				if !fallible[name] && fallible[callee] {
+					fallible[name], changed = true, true
+				}
+			}
+		}
+	}
+
+	return fallible
+}
@@ -0,0 +1,60 @@
+package gonverter
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateHooksPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/hooks"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	plans := make(map[string]PairPlan)
+	for _, p := range res.Plan {
+		plans[p.Func] = p
+	}
+
+	tests := []struct {
+		name     string
+		hooks    []string
+		fallible bool
+	}{
+		{
+			name:     "ConvertUserRequestToUser",
+			hooks:    []string{"BeforeConvertUserRequestToUser", "AfterConvertUserRequestToUser"},
+			fallible: true,
+		},
+		{
+			name:     "ConvertAddressRequestToAddress",
+			hooks:    []string{"AfterConvertAddressRequestToAddress"},
+			fallible: true,
+		},
+		{
+			name:  "ConvertOrderToOrderDTO",
+			hooks: []string{"AfterConvertOrderToOrderDTO"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := plans[tt.name]
+			if !reflect.DeepEqual(p.Hooks, tt.hooks) || p.Fallible != tt.fallible {
+				t.Errorf("Hooks = %v, Fallible = %v, want %v, %v", p.Hooks, p.Fallible, tt.hooks, tt.fallible)
+			}
+		})
+	}
+
+	// Fields without a source field are left to the After hook.
+	for _, f := range plans["ConvertOrderToOrderDTO"].Fields {
+		if f.Dst == "ItemCount" && (f.Func != "AfterConvertOrderToOrderDTO" || f.Missing) {
+			t.Errorf("field ItemCount = %+v, want filled by the After hook", f)
+		}
+	}
+}
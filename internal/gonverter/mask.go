@@ -0,0 +1,218 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+const maskSuffix = "WithMask"
+
+// maskData describes how the WithMask variant of a conversion splits its mask.
+type maskData struct {
+	SplitFunc string // splits a mask by the destination fields and checks the paths below them
+	Leaves    string // fields selected only as a whole, as a Go expression
+	Nested    string // fields whose own fields can be selected, as a Go expression
+	Checks    []maskCheck
+}
+
+// maskCheck checks the paths below a nested field with the split function of its type.
+type maskCheck struct {
+	Field     string
+	SplitFunc string
+}
+
+// maskedPair returns the WithMask variant of pair.
+func maskedPair(pair conversionPair) conversionPair {
+	pair.masked = true
+	pair.opts = pair.opts.masked()
+
+	return pair
+}
+
+// splitFuncName returns the name of the function splitting the masks of a WithMask pair.
+func (g *generator) splitFuncName(pair *conversionPair) string {
+	return fmt.Sprintf("split%sTo%sMask", g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to))
+}
+
+// buildMaskedFuncData builds the WithMask variant of a conversion. Each field is
+// mapped as in the conversion when the mask selects it as a whole. Fields that
+// are converted field by field, i.e. structs and the elements of slices and
+// maps, are converted by the WithMask variant of their own conversion when the
+// mask selects some of their fields.
+func (g *generator) buildMaskedFuncData(pair *conversionPair, pkgName, pkgPath string, imports map[string]bool) (funcData, []conversionPair, bool) {
+	fd := g.newFuncData(pair, pkgName, imports)
+
+	if !pair.from.isPointer || !pair.to.isPointer {
+		g.errorf(pair.pos, "WithFieldMask requires a registration between pointer types")
+
+		return fd, nil, false
+	}
+
+	mappings, ok := g.buildMappingsWithNested(pair)
+	if !ok {
+		return fd, nil, false
+	}
+
+	fd.Mask = &maskData{SplitFunc: g.splitFuncName(pair)}
+	plan := PairPlan{
+		Func:     fd.Name,
+		PkgPath:  pkgPath,
+		From:     qualifiedTypeName(pair.from),
+		To:       qualifiedTypeName(pair.to),
+		Nested:   pair.nested,
+		Masked:   true,
+		Fallible: true,
+	}
+
+	var (
+		leaves, nested []string
+		nestedPairs    []conversionPair
+	)
+
+	for _, m := range mappings {
+		plan.Fields = append(plan.Fields, m.plan)
+		field := m.plan.Dst
+
+		if m.nested != nil {
+			nestedPairs = append(nestedPairs, *m.nested)
+		}
+
+		nestedPairs = append(nestedPairs, m.calls...)
+
+		sub := m.nested
+		if sub == nil && m.plan.Kind == MappingAssign && isMergeableStruct(m.dst.Type()) {
+			sub = g.identicalStructPair(pair, m.dst)
+		}
+
+		// Enums are selected as a whole.
+		if sub == nil || sub.enum {
+			leaves = append(leaves, field)
+			fd.Mappings = append(fd.Mappings, fmt.Sprintf(`if _, ok := fields[%q]; ok {
+		%s
+	}`, field, m.code))
+
+			continue
+		}
+
+		// The fields the mask leaves out are kept in dst, which a setter
+		// cannot do.
+		if m.setter != nil {
+			g.errorf(m.setter.field.Pos(), "WithFieldMask cannot select the fields of %s in part: %s of %s has no field to keep the others in",
+				field, m.setter.method, typeString(derefType(pair.to.typ)))
+
+			return fd, nil, false
+		}
+
+		subMasked := maskedPair(*sub)
+		nestedPairs = append(nestedPairs, subMasked)
+		nested = append(nested, field)
+		fd.Mask.Checks = append(fd.Mask.Checks, maskCheck{Field: field, SplitFunc: g.splitFuncName(&subMasked)})
+		fd.Mappings = append(fd.Mappings, fmt.Sprintf(`if m, ok := fields[%q]; ok && m.All() {
+		%s
+	} else if ok {
+		%s
+	}`, field, m.code, g.createMaskedFieldMapping(g.convertFuncName(&subMasked), m)))
+	}
+
+	fd.Mask.Leaves, fd.Mask.Nested = stringsExpr(leaves), stringsExpr(nested)
+	g.result.Plan = append(g.result.Plan, plan)
+
+	return fd, nestedPairs, true
+}
+
+// identicalStructPair returns the pair converting a struct field into a field
+// of the same type, which conversions assign as a whole.
+func (g *generator) identicalStructPair(pair *conversionPair, dstField *types.Var) *conversionPair {
+	info := extractTypeInfo(derefType(dstField.Type()))
+	info.isPointer = true
+
+	return &conversionPair{
+		from:      info,
+		to:        info,
+		pos:       dstField.Pos(),
+		nested:    true,
+		qualified: pair.qualified,
+		opts:      pair.opts.nested(),
+	}
+}
+
+// createMaskedFieldMapping creates the code converting the selected fields of
+// the struct field of m, or of the elements of its slice or map field, with
+// funcName and the field's mask m. It reads and writes the fields with the
+// refs of m, so fields read through getters are read once.
+func (g *generator) createMaskedFieldMapping(funcName string, fm fieldMapping) string {
+	src, dst := fm.refs.src, fm.refs.dst
+
+	switch t := fm.dst.Type().Underlying().(type) {
+	case *types.Slice:
+		// Existing elements keep the fields the mask does not select.
+		return fmt.Sprintf(`if %s != nil {
+		elems := make([]%s, len(%s))
+		copy(elems, %s)
+		for i := range %s {
+			if err := %s(&%s[i], &elems[i], m); err != nil {
+				return err
+			}
+		}
+		%s = elems
+	}`, src, g.typeExpr(t.Elem()), src, dst, src, funcName, src, dst)
+	case *types.Map:
+		return fmt.Sprintf(`if %s != nil {
+		if %s == nil {
+			%s = make(%s, len(%s))
+		}
+		for k, v := range %s {
+			converted := %s[k]
+			if err := %s(&v, &converted, m); err != nil {
+				return err
+			}
+			%s[k] = converted
+		}
+	}`, src, dst, dst, g.typeExpr(fm.dst.Type()), src, src, dst, funcName, dst)
+	}
+
+	_, srcIsPtr := fm.src.Type().(*types.Pointer)
+	_, dstIsPtr := fm.dst.Type().(*types.Pointer)
+
+	srcExpr, dstExpr := fm.refs.addr, "&"+dst
+	if srcIsPtr {
+		srcExpr = src
+	}
+
+	var code string
+
+	if dstIsPtr {
+		dstExpr = dst
+		code = fmt.Sprintf(`if %s == nil {
+		%s = new(%s)
+	}
+	`, dst, dst, g.typeExpr(derefType(fm.dst.Type())))
+	}
+
+	code += fmt.Sprintf(`if err := %s(%s, %s, m); err != nil {
+		return err
+	}`, funcName, srcExpr, dstExpr)
+
+	if srcIsPtr {
+		return fmt.Sprintf(`if %s != nil {
+		%s
+	}`, src, code)
+	}
+
+	return fm.refs.withLoad(code)
+}
+
+// stringsExpr formats names as a []string literal, or nil if there are none.
+func stringsExpr(names []string) string {
+	if len(names) == 0 {
+		return "nil"
+	}
+
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("%q", name)
+	}
+
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
@@ -0,0 +1,52 @@
+package gonverter
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestStringsExpr(t *testing.T) {
+	if got := stringsExpr(nil); got != "nil" {
+		t.Errorf("stringsExpr(nil) = %q, want nil", got)
+	}
+
+	if got, want := stringsExpr([]string{"Name", "City"}), `[]string{"Name", "City"}`; got != want {
+		t.Errorf("stringsExpr() = %q, want %q", got, want)
+	}
+}
+
+func TestGenerateMaskPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/fieldmask"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	var masked []string
+
+	for _, p := range res.Plan {
+		if p.Masked {
+			masked = append(masked, p.Func)
+		}
+	}
+
+	slices.Sort(masked)
+
+	want := []string{
+		"ConvertAddressRequestToAddressWithMask",
+		"ConvertContactRequestToContactWithMask",
+		"ConvertEmployeeToEmployeeWithMask",
+		"ConvertGeoRequestToGeoWithMask",
+		"ConvertProfileToProfileWithMask",
+		"ConvertSettingRequestToSettingWithMask",
+		"ConvertUserRequestToUserWithMask",
+	}
+
+	if !slices.Equal(masked, want) {
+		t.Errorf("masked functions = %v, want %v", masked, want)
+	}
+}
@@ -0,0 +1,422 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// typeParam is a source of a many-to-one conversion or a destination of a
+// split conversion, passed to its function as a parameter.
+type typeParam struct {
+	info typeInfo
+	name string // parameter name
+	rank int    // position of a source in the order fields found in several sources are taken in
+}
+
+// sourceOrder is the order of the sources of a many-to-one conversion given to
+// runtime.WithPrecedence, highest first.
+type sourceOrder struct {
+	types []types.Type
+	exprs []ast.Expr
+}
+
+// parsePrecedence reads the zero values given to runtime.WithPrecedence.
+func (g *generator) parsePrecedence(pkg *packages.Package, args []ast.Expr) *sourceOrder {
+	order := &sourceOrder{}
+
+	for _, arg := range args {
+		if t := pkg.TypesInfo.TypeOf(arg); t != nil {
+			order.types = append(order.types, pointerTo(t))
+			order.exprs = append(order.exprs, arg)
+		}
+	}
+
+	return order
+}
+
+// extractMultiPair reads a Register2 or Register3 call. Its from is the tuple
+// of the parameters of the sources, named after their types joined with And.
+func (g *generator) extractMultiPair(pkg *packages.Package, call *ast.CallExpr, typeList []types.Type) (conversionPair, bool) {
+	pair := conversionPair{
+		to:   extractTypeInfo(pointerTo(typeList[len(typeList)-1])),
+		pos:  call.Pos(),
+		opts: g.parseMultiOptions(pkg, call),
+	}
+
+	taken := importNames(pkg)
+	taken[pair.to.pkgName] = true
+
+	infos, ok := g.distinctTypes(call.Pos(), "source", typeList[:len(typeList)-1], taken)
+	if !ok {
+		return pair, false
+	}
+
+	pair.qualified = isProtoMessage(pair.to.typ)
+
+	for i, info := range infos {
+		pair.qualified = pair.qualified || isProtoMessage(info.typ)
+		pair.sources = append(pair.sources, typeParam{info: info, rank: i})
+	}
+
+	if pair.opts.precedence != nil {
+		g.rankSources(&pair)
+	}
+
+	names, from := g.paramTuple(&pair, pkg, call.Pos(), infos, taken)
+	for i, name := range names {
+		pair.sources[i].name = name
+	}
+
+	pair.from = from
+
+	return pair, true
+}
+
+// importNames returns the names of the packages pkg imports, which the
+// parameters of many-to-one and split conversions must not shadow.
+func importNames(pkg *packages.Package) map[string]bool {
+	names := make(map[string]bool)
+	for _, imp := range pkg.Imports {
+		names[imp.Name] = true
+	}
+
+	return names
+}
+
+// distinctTypes reads the sources or destinations of a many-to-one or split
+// conversion as pointer types, reporting an error unless they are distinct
+// named types. Their package names are marked taken.
+func (g *generator) distinctTypes(pos token.Pos, role string, typeList []types.Type, taken map[string]bool) ([]typeInfo, bool) {
+	infos := make([]typeInfo, 0, len(typeList))
+	seen := make(map[string]bool)
+
+	for _, t := range typeList {
+		info := extractTypeInfo(pointerTo(t))
+
+		name := qualifiedTypeName(info)
+		if info.typeName == "" || seen[name] {
+			g.errorf(pos, "invalid %s %s: must be a named type registered once", role, typeString(t))
+
+			return nil, false
+		}
+
+		seen[name] = true
+		taken[info.pkgName] = true
+		infos = append(infos, info)
+	}
+
+	return infos, true
+}
+
+// paramTuple names a parameter after the type of each of infos, avoiding taken
+// names. It returns the names and the tuple of the parameters as a typeInfo
+// named after the types of pair joined with And.
+func (g *generator) paramTuple(pair *conversionPair, pkg *packages.Package, pos token.Pos, infos []typeInfo, taken map[string]bool) ([]string, typeInfo) {
+	names := make([]string, len(infos))
+	typeNames := make([]string, len(infos))
+	params := make([]*types.Var, len(infos))
+
+	for i, info := range infos {
+		names[i] = freeLocal(lowerFirst(info.typeName), taken)
+		typeNames[i] = g.funcTypeName(pair, info)
+		params[i] = types.NewParam(pos, pkg.Types, names[i], info.typ)
+	}
+
+	return names, typeInfo{typeName: strings.Join(typeNames, "And"), typ: types.NewTuple(params...)}
+}
+
+// rankSources orders the sources of pair as given to runtime.WithPrecedence,
+// followed by the sources it leaves out in declaration order.
+func (g *generator) rankSources(pair *conversionPair) {
+	order := pair.opts.precedence
+	ranked := make(map[int]bool)
+
+	for j, t := range order.types {
+		i := sourceIndex(pair, t)
+		if i < 0 || ranked[i] {
+			g.errorf(order.exprs[j].Pos(), "invalid precedence %s: not a source of the conversion or listed twice", typeString(derefType(t)))
+
+			continue
+		}
+
+		pair.sources[i].rank = len(ranked)
+		ranked[i] = true
+	}
+
+	for i := range pair.sources {
+		if !ranked[i] {
+			pair.sources[i].rank = len(ranked)
+			ranked[i] = true
+		}
+	}
+}
+
+func sourceIndex(pair *conversionPair, t types.Type) int {
+	for i, s := range pair.sources {
+		if types.Identical(s.info.typ, t) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// byPrecedence returns the indexes of the sources of pair, highest precedence first.
+func byPrecedence(pair *conversionPair) []int {
+	order := make([]int, len(pair.sources))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool { return pair.sources[order[a]].rank < pair.sources[order[b]].rank })
+
+	return order
+}
+
+// hookArgs returns the arguments passing the sources and destinations of
+// pair to a Before or After hook.
+func hookArgs(pair *conversionPair) []string {
+	args := []string{"src"}
+	if len(pair.sources) > 0 {
+		args = paramNames(pair.sources)
+	}
+
+	if len(pair.destinations) > 0 {
+		return append(args, paramNames(pair.destinations)...)
+	}
+
+	return append(args, "dst")
+}
+
+// hookArgTypes returns the types of the arguments of hookArgs.
+func hookArgTypes(pair *conversionPair) []types.Type {
+	args := []types.Type{declType(pair.from)}
+	if len(pair.sources) > 0 {
+		args = paramTypes(pair.sources)
+	}
+
+	if len(pair.destinations) > 0 {
+		return append(args, paramTypes(pair.destinations)...)
+	}
+
+	return append(args, declType(pair.to))
+}
+
+func paramTypes(params []typeParam) []types.Type {
+	ts := make([]types.Type, len(params))
+	for i, p := range params {
+		ts[i] = declType(p.info)
+	}
+
+	return ts
+}
+
+// declType returns the type of the parameter info is declared with in
+// generated functions, as formatTypeDecl writes it.
+func declType(info typeInfo) types.Type {
+	if info.isPointer {
+		return pointerTo(info.typ)
+	}
+
+	return info.typ
+}
+
+func paramNames(params []typeParam) []string {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = p.name
+	}
+
+	return names
+}
+
+// sourcePair returns the conversion of the source s of pair alone. Its hooks
+// are named like those of pair.
+func sourcePair(pair *conversionPair, s typeParam) conversionPair {
+	return conversionPair{
+		from:      typeInfo{typeName: pair.from.typeName, typ: s.info.typ, isPointer: true},
+		to:        pair.to,
+		pos:       pair.pos,
+		qualified: pair.qualified,
+		opts:      pair.opts,
+	}
+}
+
+// sourcesWith returns the indexes of the sources of pair that have a field
+// named name or its getter, highest precedence first.
+func sourcesWith(pair *conversionPair, name string) []int {
+	var with []int
+
+	for _, i := range byPrecedence(pair) {
+		s := pair.sources[i]
+
+		fromStruct, ok := derefType(s.info.typ).Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		sub := sourcePair(pair, s)
+		if _, ok, _ := getterFor(&sub, name); ok || findField(fromStruct, name) != nil {
+			with = append(with, i)
+		}
+	}
+
+	return with
+}
+
+// buildMultiMappings maps every destination field of a many-to-one conversion
+// from the source of highest precedence that has it. The mappings of a source
+// are grouped in a block that skips them if it is nil, and field hooks are
+// passed every source.
+func (g *generator) buildMultiMappings(pair *conversionPair) ([]fieldMapping, bool) {
+	toStruct, ok := derefType(pair.to.typ).Underlying().(*types.Struct)
+	if !ok {
+		g.errorf(pair.pos, "cannot convert to %s: not a struct type", typeString(pair.to.typ))
+
+		return nil, false
+	}
+
+	for _, s := range pair.sources {
+		if _, ok := derefType(s.info.typ).Underlying().(*types.Struct); !ok {
+			g.errorf(pair.pos, "cannot convert from %s: not a struct type", typeString(s.info.typ))
+
+			return nil, false
+		}
+	}
+
+	var (
+		mappings []fieldMapping
+		blocks   = make([][]string, len(pair.sources))
+		shared   []string
+		first    = byPrecedence(pair)[0]
+		args     = strings.Join(hookArgs(pair), ", ")
+	)
+
+	mapField := func(dstField *types.Var, setter *accessor) {
+		i, with := first, sourcesWith(pair, dstField.Name())
+		if len(with) > 0 {
+			i = with[0]
+		}
+
+		sub := sourcePair(pair, pair.sources[i])
+		fromStruct, _ := derefType(sub.from.typ).Underlying().(*types.Struct)
+
+		vars := mappingVars{src: pair.sources[i].name, dst: "dst", hookArgs: args}
+		m := g.createFieldMapping(&sub, vars, fromStruct, dstField, setter)
+
+		if len(with) > 0 {
+			m.plan.Source = qualifiedTypeName(pair.sources[i].info)
+		}
+
+		// Field hooks take every source, whichever are nil.
+		switch {
+		case m.code == "":
+		case m.plan.Kind == MappingCustom:
+			shared = append(shared, m.code)
+		default:
+			blocks[i] = append(blocks[i], m.code)
+		}
+
+		m.code = ""
+		mappings = append(mappings, m)
+	}
+
+	for i := 0; i < toStruct.NumFields(); i++ {
+		if dstField := toStruct.Field(i); dstField.Exported() {
+			mapField(dstField, nil)
+		}
+	}
+
+	hasSource := func(name string) bool { return len(sourcesWith(pair, name)) > 0 }
+	for _, setter := range g.settersOf(pair, toStruct, hasSource) {
+		mapField(setter.field, &setter)
+	}
+
+	// The blocks of the sources and the hooks that take them all are set apart.
+	var code []string
+
+	for i, stmts := range blocks {
+		if len(stmts) > 0 {
+			code = append(code, fmt.Sprintf("if %s != nil {\n%s\n}", pair.sources[i].name, strings.Join(stmts, "\n")))
+		}
+	}
+
+	if len(shared) > 0 {
+		code = append(code, strings.Join(shared, "\n"))
+	}
+
+	if len(code) > 0 {
+		mappings = append(mappings, fieldMapping{code: strings.Join(code, "\n\n")})
+	}
+
+	return mappings, true
+}
+
+// checkConflicts warns about the destination fields of a many-to-one conversion
+// found in more than one source when runtime.WithPrecedence does not say which
+// one they are taken from, and returns their names.
+func (g *generator) checkConflicts(pair *conversionPair) []string {
+	toStruct, ok := derefType(pair.to.typ).Underlying().(*types.Struct)
+	if !ok || pair.opts.precedence != nil {
+		return nil
+	}
+
+	var conflicts []string
+
+	for i := 0; i < toStruct.NumFields(); i++ {
+		field := toStruct.Field(i)
+		if !field.Exported() {
+			continue
+		}
+
+		if with := sourcesWith(pair, field.Name()); len(with) > 1 {
+			names := make([]string, len(with))
+			for j, k := range with {
+				names[j] = typeString(derefType(pair.sources[k].info.typ))
+			}
+
+			g.warnf(pair.pos, "ambiguous field: %s is found in %s; it is taken from %s unless WithPrecedence says otherwise",
+				field.Name(), joinAnd(names), names[0])
+
+			conflicts = append(conflicts, field.Name())
+		}
+	}
+
+	return conflicts
+}
+
+// paramsDecl declares params.
+func (g *generator) paramsDecl(params []typeParam) string {
+	decls := make([]string, len(params))
+	for i, p := range params {
+		decls[i] = p.name + " " + g.typeExpr(p.info.typ)
+	}
+
+	return strings.Join(decls, ", ")
+}
+
+// paramsDoc lists the type names of params for a doc comment, e.g.
+// "Body, Path and Principal".
+func (g *generator) paramsDoc(params []typeParam) string {
+	names := make([]string, len(params))
+	for i, p := range params {
+		names[i] = g.typeExpr(derefType(p.info.typ))
+	}
+
+	return joinAnd(names)
+}
+
+// joinAnd joins names as in "a, b and c".
+func joinAnd(names []string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
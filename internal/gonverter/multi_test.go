@@ -0,0 +1,79 @@
+package gonverter
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestJoinAnd(t *testing.T) {
+	tests := []struct {
+		names []string
+		want  string
+	}{
+		{[]string{"A"}, "A"},
+		{[]string{"A", "B"}, "A and B"},
+		{[]string{"A", "B", "C"}, "A, B and C"},
+	}
+
+	for _, tt := range tests {
+		if got := joinAnd(tt.names); got != tt.want {
+			t.Errorf("joinAnd(%v) = %q, want %q", tt.names, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateMultiSourcePlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/multisource"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	// Only the fields of the conversion without WithPrecedence are ambiguous.
+	if len(res.Diagnostics) != 2 || !strings.Contains(res.Diagnostics[0].Message, "ambiguous field: TenantID") {
+		t.Fatalf("Diagnostics = %v, want warnings about TenantID and Role", res.Diagnostics)
+	}
+
+	const pkg = "github.com/sivchari/gonverter/testdata/multisource."
+
+	for _, p := range res.Plan {
+		switch p.Func {
+		case "ConvertBodyAndPathAndPrincipalToMember":
+			if p.From != "" || !slices.Equal(p.Sources, []string{pkg + "Body", pkg + "Path", pkg + "Principal"}) || !p.Fallible {
+				t.Errorf("plan = %+v, want the fallible conversion from Body, Path and Principal", p)
+			}
+
+			if !slices.Equal(p.Conflicts, []string{"TenantID", "Role"}) {
+				t.Errorf("Conflicts = %v, want [TenantID Role]", p.Conflicts)
+			}
+
+			if f := p.Fields[0]; f.Src != "TenantID" || f.Source != pkg+"Path" {
+				t.Errorf("Fields[0] = %+v, want TenantID from Path", f)
+			}
+
+			if f := p.Fields[5]; f.Source != "" || f.Kind != MappingCustom || f.Missing {
+				t.Errorf("Fields[5] = %+v, want the CreatedBy hook", f)
+			}
+		case "ConvertBodyAndPathToInvite":
+			if len(p.Conflicts) != 0 || p.Fields[2].Source != pkg+"Path" {
+				t.Errorf("plan = %+v, want Role from Path without conflicts", p)
+			}
+		}
+	}
+}
+
+func TestGenerateMultiSourceHookArity(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/invalidmulti"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	for _, d := range res.Diagnostics {
+		if strings.Contains(d.Message, "invalid hook AfterConvertBodyAndPathToMember") {
+			return
+		}
+	}
+
+	t.Errorf("Diagnostics = %v, want an error for the hook missing a source", res.Diagnostics)
+}
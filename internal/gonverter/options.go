@@ -0,0 +1,529 @@
+package gonverter
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Default method name patterns of runtime.WithMethods.
+const (
+	defaultToMethod   = "To{Pkg}"
+	defaultFromMethod = "From{Pkg}"
+)
+
+// Default accessor name patterns of runtime.WithAccessors.
+const (
+	defaultGetters = "{Field},Get{Field}"
+	defaultSetter  = "Set{Field}"
+)
+
+// registrationOptions are the runtime.With* options passed to a registration.
+type registrationOptions struct {
+	constructor      bool   // generate To<To>(*From) *To and its slice helper
+	valueConstructor bool   // generate To<To>(From) To and its slice helper
+	toMethod         string // name pattern of the method converting the local source type
+	fromMethod       string // name pattern of the method filling the local source type
+	resetDst         bool   // zero dst before mapping, and when src is nil unless preserveOnNil
+	preserveOnNil    bool   // leave dst untouched when src is nil
+	emptyCollections bool   // turn nil slices and maps into empty ones
+	zeroAsUnset      bool   // patches skip zero-valued source fields
+	fieldMask        bool   // also generate Convert<From>To<To>WithMask
+	validate         bool   // validate dst and the values nested in it after mapping
+	defaults         bool   // set the defaults of dst after mapping
+	nilAsError       bool   // dereferencing a nil pointer field returns an error
+	builtins         builtinSet
+	getters          string // comma-separated name patterns of the getters of source fields
+	setter           string // name pattern of the setters of destination fields
+	factory          *factorySpec
+	precedence       *sourceOrder
+
+	enumPrefixFrom, enumPrefixTo string       // prefixes stripped from enum constant names
+	enumFallback                 *types.Const // what enum values without an equivalent become
+}
+
+// nested returns the options that nested conversions of a registration inherit.
+func (o registrationOptions) nested() registrationOptions {
+	return registrationOptions{
+		resetDst:         o.resetDst,
+		preserveOnNil:    o.preserveOnNil,
+		emptyCollections: o.emptyCollections,
+		zeroAsUnset:      o.zeroAsUnset,
+		nilAsError:       o.nilAsError,
+		builtins:         o.builtins,
+		getters:          o.getters,
+		setter:           o.setter,
+	}
+}
+
+// masked returns the options of the WithMask variant of a registration. It
+// only fills the selected fields of dst, so dst is never reset as a whole.
+func (o registrationOptions) masked() registrationOptions {
+	return registrationOptions{
+		emptyCollections: o.emptyCollections,
+		nilAsError:       o.nilAsError,
+		builtins:         o.builtins,
+		getters:          o.getters,
+		setter:           o.setter,
+	}
+}
+
+// withoutMethods returns the options that apply to the reverse of a registration.
+// Methods are declared on the source type only, and factories create the
+// destination type only.
+func (o registrationOptions) withoutMethods() registrationOptions {
+	o.toMethod, o.fromMethod = "", ""
+	o.factory = nil
+
+	return o
+}
+
+// parseOptions reads the options of a registration call. Options are markers
+// too, so every argument must be a direct call to a runtime option function.
+func (g *generator) parseOptions(pkg *packages.Package, args []ast.Expr) registrationOptions {
+	var opts registrationOptions
+
+	for _, arg := range args {
+		var (
+			name    string
+			optArgs []ast.Expr
+		)
+
+		if call, ok := arg.(*ast.CallExpr); ok {
+			name, optArgs = runtimeFuncName(pkg, call.Fun), call.Args
+		}
+
+		switch name {
+		case "WithConstructor":
+			opts.constructor = true
+		case "WithValueConstructor":
+			opts.valueConstructor = true
+		case "WithResetDst":
+			opts.resetDst = true
+		case "WithPreserveOnNil":
+			opts.preserveOnNil = true
+		case "WithEmptyCollections":
+			opts.emptyCollections = true
+		case "WithZeroAsUnset":
+			opts.zeroAsUnset = true
+		case "WithFieldMask":
+			opts.fieldMask = true
+		case "WithValidation":
+			opts.validate = true
+		case "WithDefaults":
+			opts.defaults = true
+		case "WithNilAsError":
+			opts.nilAsError = true
+		case "WithBuiltins":
+			opts.builtins = g.parseBuiltins(pkg, optArgs)
+		case "WithEnumPrefix":
+			opts.enumPrefixFrom = g.stringConstant(pkg, optArgs[0])
+			opts.enumPrefixTo = g.stringConstant(pkg, optArgs[1])
+		case "WithEnumFallback":
+			opts.enumFallback = g.constantObject(pkg, optArgs[0])
+		case "WithMethods":
+			opts.toMethod, opts.fromMethod = defaultToMethod, defaultFromMethod
+		case "WithMethodNames":
+			opts.toMethod = g.stringConstant(pkg, optArgs[0])
+			opts.fromMethod = g.stringConstant(pkg, optArgs[1])
+		case "WithFactory":
+			opts.factory = g.parseFactory(pkg, optArgs)
+		case "WithPrecedence":
+			opts.precedence = g.parsePrecedence(pkg, optArgs)
+		case "WithAccessors":
+			opts.getters, opts.setter = defaultGetters, defaultSetter
+		case "WithAccessorNames":
+			opts.getters = g.accessorPatterns(optArgs[0], g.stringConstant(pkg, optArgs[0]), true)
+			opts.setter = g.accessorPatterns(optArgs[1], g.stringConstant(pkg, optArgs[1]), false)
+		default:
+			g.errorf(arg.Pos(), "unsupported registration option: options must be calls to gonverter runtime option functions")
+		}
+	}
+
+	if opts.factory != nil && opts.fieldMask {
+		g.errorf(args[0].Pos(), "WithFactory and WithFieldMask cannot be combined")
+
+		opts.fieldMask = false
+	}
+
+	if opts.constructor && opts.valueConstructor {
+		g.errorf(args[0].Pos(), "WithConstructor and WithValueConstructor cannot be combined")
+
+		opts.valueConstructor = false
+	}
+
+	return opts
+}
+
+// hasEnumOptions reports whether o has options that only apply to RegisterEnum.
+func (o registrationOptions) hasEnumOptions() bool {
+	return o.enumPrefixFrom != "" || o.enumPrefixTo != "" || o.enumFallback != nil
+}
+
+// parseRouteOptions reads the options of a RegisterPath or RegisterVia call.
+// Routes are one-way, so there is no conversion back for a From method.
+func (g *generator) parseRouteOptions(pkg *packages.Package, call *ast.CallExpr) registrationOptions {
+	opts := g.parseOptions(pkg, call.Args)
+	if opts.fromMethod != "" {
+		g.errorf(call.Pos(), "composed conversions cannot have a From method; use WithMethodNames with an empty from pattern")
+
+		opts.fromMethod = ""
+	}
+
+	if opts.zeroAsUnset {
+		g.errorf(call.Pos(), "WithZeroAsUnset requires RegisterPatch")
+	}
+
+	if opts.hasEnumOptions() {
+		g.errorf(call.Pos(), "WithEnumPrefix and WithEnumFallback require RegisterEnum")
+	}
+
+	if opts.fieldMask {
+		g.errorf(call.Pos(), "composed conversions cannot take a field mask")
+
+		opts.fieldMask = false
+	}
+
+	if opts.factory != nil {
+		g.errorf(call.Pos(), "composed conversions cannot have a factory")
+
+		opts.factory = nil
+	}
+
+	if opts.precedence != nil {
+		g.errorf(call.Pos(), "WithPrecedence requires Register2 or Register3")
+
+		opts.precedence = nil
+	}
+
+	return opts
+}
+
+// parseMultiOptions reads the options of a Register2 or Register3 call. Their
+// functions take several sources, so they fit no constructor, method or mask.
+func (g *generator) parseMultiOptions(pkg *packages.Package, call *ast.CallExpr) registrationOptions {
+	opts := g.parseOptions(pkg, call.Args)
+	if opts.constructor || opts.valueConstructor || opts.toMethod != "" || opts.fromMethod != "" {
+		g.errorf(call.Pos(), "many-to-one conversions cannot have constructors or methods")
+
+		opts.constructor, opts.valueConstructor, opts.toMethod, opts.fromMethod = false, false, "", ""
+	}
+
+	if opts.zeroAsUnset {
+		g.errorf(call.Pos(), "WithZeroAsUnset requires RegisterPatch")
+	}
+
+	if opts.hasEnumOptions() {
+		g.errorf(call.Pos(), "WithEnumPrefix and WithEnumFallback require RegisterEnum")
+	}
+
+	if opts.fieldMask {
+		g.errorf(call.Pos(), "many-to-one conversions cannot take a field mask")
+
+		opts.fieldMask = false
+	}
+
+	if opts.factory != nil {
+		g.errorf(call.Pos(), "many-to-one conversions cannot have a factory")
+
+		opts.factory = nil
+	}
+
+	return opts
+}
+
+// parseSplitOptions reads the options of a RegisterSplit2 or RegisterSplit3
+// call. Only options applying to each destination on its own are supported.
+func (g *generator) parseSplitOptions(pkg *packages.Package, call *ast.CallExpr) registrationOptions {
+	opts := g.parseOptions(pkg, call.Args)
+	splitOpts := registrationOptions{
+		emptyCollections: opts.emptyCollections,
+		nilAsError:       opts.nilAsError,
+		builtins:         opts.builtins,
+		getters:          opts.getters,
+		setter:           opts.setter,
+	}
+
+	if opts != splitOpts {
+		g.errorf(call.Pos(), "split conversions only support the WithEmptyCollections, WithNilAsError, WithBuiltins and accessor options")
+	}
+
+	return splitOpts
+}
+
+// parsePatchOptions reads the options of a RegisterPatch call. A patch fills
+// an existing value, so there is nothing to construct or reset.
+func (g *generator) parsePatchOptions(pkg *packages.Package, call *ast.CallExpr) registrationOptions {
+	opts := g.parseOptions(pkg, call.Args)
+	patchOpts := registrationOptions{zeroAsUnset: opts.zeroAsUnset}
+
+	if opts != patchOpts {
+		g.errorf(call.Pos(), "RegisterPatch only supports the WithZeroAsUnset option")
+	}
+
+	return patchOpts
+}
+
+// parseEnumOptions reads the options of a RegisterEnum call.
+func (g *generator) parseEnumOptions(pkg *packages.Package, call *ast.CallExpr) registrationOptions {
+	opts := g.parseOptions(pkg, call.Args)
+	enumOpts := registrationOptions{
+		enumPrefixFrom: opts.enumPrefixFrom,
+		enumPrefixTo:   opts.enumPrefixTo,
+		enumFallback:   opts.enumFallback,
+	}
+
+	if opts != enumOpts {
+		g.errorf(call.Pos(), "RegisterEnum only supports the WithEnumPrefix and WithEnumFallback options")
+	}
+
+	return enumOpts
+}
+
+// constantObject returns the constant an option argument names, reporting an
+// error for anything else.
+func (g *generator) constantObject(pkg *packages.Package, expr ast.Expr) *types.Const {
+	var ident *ast.Ident
+
+	switch e := expr.(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	}
+
+	c, ok := pkg.TypesInfo.Uses[ident].(*types.Const)
+	if ident == nil || !ok {
+		g.errorf(expr.Pos(), "option argument must name a constant")
+
+		return nil
+	}
+
+	return c
+}
+
+// stringConstant returns the value of a constant string expression, reporting
+// an error for anything else.
+func (g *generator) stringConstant(pkg *packages.Package, expr ast.Expr) string {
+	tv, ok := pkg.TypesInfo.Types[expr]
+	if !ok || tv.Value == nil || tv.Value.Kind() != constant.String {
+		g.errorf(expr.Pos(), "option argument must be a constant string")
+
+		return ""
+	}
+
+	return constant.StringVal(tv.Value)
+}
+
+// semanticsDoc describes how the function generated for pair treats nil and
+// zero values, one sentence per line, for its doc comment.
+func semanticsDoc(pair *conversionPair) []string {
+	var doc []string
+
+	switch {
+	case pair.from.isPointer && len(pair.destinations) > 0:
+		doc = append(doc, "If src is nil, the destinations are left unchanged.")
+	case pair.from.isPointer:
+		if resetOnNil(pair) {
+			doc = append(doc, "If src is nil, dst is reset to its zero value.")
+		} else {
+			doc = append(doc, "If src is nil, dst is left unchanged.")
+		}
+	}
+
+	if pair.patch {
+		if pair.opts.zeroAsUnset {
+			doc = append(doc, "Nil and zero-valued fields of src leave dst unchanged.")
+		} else {
+			doc = append(doc, "Nil pointers, slices and maps in src leave dst unchanged.")
+		}
+
+		doc = append(doc, "Nested structs are merged field by field and maps key by key.")
+	}
+
+	if len(pair.sources) > 0 {
+		names := make([]string, len(pair.sources))
+		for j, i := range byPrecedence(pair) {
+			names[j] = pair.sources[i].name
+		}
+
+		doc = append(doc, "Fields found in several sources are taken from "+strings.Join(names, ", then ")+".",
+			"A nil source leaves the fields taken from it unchanged.")
+	}
+
+	switch {
+	case pair.opts.resetDst && len(pair.sources) > 0:
+		doc = append(doc, "Dst is reset to its zero value before fields are mapped.")
+	case pair.opts.resetDst:
+		doc = append(doc, "Otherwise dst is reset to its zero value before fields are mapped.")
+	}
+
+	if pair.opts.emptyCollections {
+		doc = append(doc, "Nil slices and maps in src become empty ones in dst.")
+	}
+
+	if pair.opts.nilAsError && pair.opts.builtins&builtinPointers != 0 {
+		doc = append(doc, "Nil pointers in src are an error for fields of dst that are not pointers.")
+	}
+
+	return doc
+}
+
+// resetOnNil reports whether dst is reset rather than preserved when src is nil.
+func resetOnNil(pair *conversionPair) bool {
+	return pair.opts.resetDst && !pair.opts.preserveOnNil
+}
+
+// constructorData describes the constructor functions generated for a pair.
+type constructorData struct {
+	Name      string // e.g. ToUser
+	SliceName string // e.g. ToUsers
+	Value     bool   // take and return values instead of pointers
+	Convert   string // the in-place conversion function
+	Fallible  bool   // Convert returns an error
+	SrcType   string
+	DstType   string
+}
+
+// funcNames returns the names of the constructor functions, if any.
+func (c *constructorData) funcNames() []string {
+	if c == nil {
+		return nil
+	}
+
+	return []string{c.Name, c.SliceName}
+}
+
+// newConstructorData returns the constructors requested for pair, or nil.
+func (g *generator) newConstructorData(pair *conversionPair, convertFunc string) *constructorData {
+	if !pair.opts.constructor && !pair.opts.valueConstructor {
+		return nil
+	}
+
+	if !pair.from.isPointer || !pair.to.isPointer {
+		g.errorf(pair.pos, "constructors require a registration between pointer types")
+
+		return nil
+	}
+
+	c := &constructorData{
+		Name:     "To" + g.funcTypeName(pair, pair.to),
+		Value:    pair.opts.valueConstructor,
+		Convert:  convertFunc,
+		Fallible: g.fallible[convertFunc],
+		SrcType:  g.typeExpr(derefType(pair.from.typ)),
+		DstType:  g.typeExpr(derefType(pair.to.typ)),
+	}
+	c.SliceName = plural(c.Name)
+
+	if other, ok := g.constructors[c.Name]; ok {
+		g.errorf(pair.pos, "constructor %s is already generated for %s", c.Name, other)
+
+		return nil
+	}
+
+	g.constructors[c.Name] = convertFunc
+
+	return c
+}
+
+// plural returns the English plural of an identifier ending in a noun.
+func plural(name string) string {
+	switch {
+	case strings.HasSuffix(name, "s"), strings.HasSuffix(name, "x"), strings.HasSuffix(name, "z"),
+		strings.HasSuffix(name, "ch"), strings.HasSuffix(name, "sh"):
+		return name + "es"
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsRune("aeiou", rune(name[len(name)-2])):
+		return name[:len(name)-1] + "ies"
+	default:
+		return name + "s"
+	}
+}
+
+// methodData describes the methods generated on a local source type.
+type methodData struct {
+	RecvType  string // the local source type
+	OtherType string // the destination type
+	To        string // method converting the receiver, if any
+	ToFunc    string
+	From      string // method filling the receiver, if any
+	FromFunc  string
+
+	ToFallible, FromFallible bool // ToFunc and FromFunc return an error
+}
+
+// funcNames returns the names of the methods, if any.
+func (m *methodData) funcNames() []string {
+	if m == nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, name := range []string{m.To, m.From} {
+		if name != "" {
+			names = append(names, m.RecvType+"."+name)
+		}
+	}
+
+	return names
+}
+
+// newMethodData returns the methods requested for pair, or nil.
+func (g *generator) newMethodData(pair *conversionPair, convertFunc string) *methodData {
+	if pair.opts.toMethod == "" && pair.opts.fromMethod == "" {
+		return nil
+	}
+
+	if !pair.from.isPointer || !pair.to.isPointer {
+		g.errorf(pair.pos, "methods require a registration between pointer types")
+
+		return nil
+	}
+
+	if pair.from.pkgPath != g.pkgPath {
+		g.errorf(pair.pos, "methods require %s to be declared in the registration package", typeString(derefType(pair.from.typ)))
+
+		return nil
+	}
+
+	reverse := conversionPair{from: pair.to, to: pair.from, qualified: pair.qualified}
+	m := &methodData{
+		RecvType:  pair.from.typeName,
+		OtherType: g.typeExpr(derefType(pair.to.typ)),
+		To:        methodName(pair.opts.toMethod, pair.to),
+		ToFunc:    convertFunc,
+		From:      methodName(pair.opts.fromMethod, pair.to),
+		FromFunc:  g.convertFuncName(&reverse),
+	}
+
+	m.ToFallible, m.FromFallible = g.fallible[m.ToFunc], g.fallible[m.FromFunc]
+
+	for _, name := range []string{m.To, m.From} {
+		if name != "" && (!token.IsIdentifier(name) || !token.IsExported(name)) {
+			g.errorf(pair.pos, "invalid method name %q: must be an exported identifier", name)
+
+			return nil
+		}
+	}
+
+	return m
+}
+
+// methodName expands a method name pattern for the destination type info.
+func methodName(pattern string, info typeInfo) string {
+	if pattern == "" {
+		return ""
+	}
+
+	pkg := info.pkgName
+	if pkg != "" {
+		pkg = strings.ToUpper(pkg[:1]) + pkg[1:]
+	}
+
+	return strings.NewReplacer("{Pkg}", pkg, "{Type}", info.typeName).Replace(pattern)
+}
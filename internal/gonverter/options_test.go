@@ -0,0 +1,165 @@
+package gonverter
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestPlural(t *testing.T) {
+	tests := map[string]string{
+		"ToUser":    "ToUsers",
+		"ToAddress": "ToAddresses",
+		"ToBox":     "ToBoxes",
+		"ToBatch":   "ToBatches",
+		"ToCompany": "ToCompanies",
+		"ToKey":     "ToKeys",
+	}
+
+	for in, want := range tests {
+		if got := plural(in); got != want {
+			t.Errorf("plural(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSemanticsDoc(t *testing.T) {
+	ptr := typeInfo{isPointer: true}
+	tests := []struct {
+		name string
+		pair conversionPair
+		want []string
+	}{
+		{
+			name: "default",
+			pair: conversionPair{from: ptr, to: ptr},
+			want: []string{"If src is nil, dst is left unchanged."},
+		},
+		{
+			name: "value source",
+			pair: conversionPair{to: ptr},
+			want: nil,
+		},
+		{
+			name: "reset",
+			pair: conversionPair{from: ptr, to: ptr, opts: registrationOptions{resetDst: true, emptyCollections: true}},
+			want: []string{
+				"If src is nil, dst is reset to its zero value.",
+				"Otherwise dst is reset to its zero value before fields are mapped.",
+				"Nil slices and maps in src become empty ones in dst.",
+			},
+		},
+		{
+			name: "reset preserving on nil",
+			pair: conversionPair{from: ptr, to: ptr, opts: registrationOptions{resetDst: true, preserveOnNil: true}},
+			want: []string{
+				"If src is nil, dst is left unchanged.",
+				"Otherwise dst is reset to its zero value before fields are mapped.",
+			},
+		},
+		{
+			name: "several sources",
+			pair: conversionPair{
+				to:      ptr,
+				sources: []typeParam{{name: "body", rank: 1}, {name: "path", rank: 0}},
+				opts:    registrationOptions{resetDst: true},
+			},
+			want: []string{
+				"Fields found in several sources are taken from path, then body.",
+				"A nil source leaves the fields taken from it unchanged.",
+				"Dst is reset to its zero value before fields are mapped.",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := semanticsDoc(&tt.pair); !slices.Equal(got, tt.want) {
+				t.Errorf("semanticsDoc() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateConstructorPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/constructor"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got := make(map[string][]string)
+	for _, p := range res.Plan {
+		got[p.Func] = p.Constructors
+	}
+
+	want := map[string][]string{
+		"ConvertUserRequestToUser":       {"ToUser", "ToUsers"},
+		"ConvertUserToUserRequest":       {"ToUserRequest", "ToUserRequests"},
+		"ConvertAddressRequestToAddress": {"ToAddress", "ToAddresses"},
+		"ConvertMoneyToPrice":            {"ToPrice", "ToPrices"},
+	}
+
+	for fn, w := range want {
+		if !slices.Equal(got[fn], w) {
+			t.Errorf("%s constructors = %v, want %v", fn, got[fn], w)
+		}
+	}
+}
+
+func TestMethodName(t *testing.T) {
+	info := typeInfo{pkgName: "domain", typeName: "User"}
+
+	tests := map[string]string{
+		defaultToMethod:   "ToDomain",
+		defaultFromMethod: "FromDomain",
+		"As{Type}":        "AsUser",
+		"To{Pkg}{Type}":   "ToDomainUser",
+		"":                "",
+	}
+
+	for pattern, want := range tests {
+		if got := methodName(pattern, info); got != want {
+			t.Errorf("methodName(%q) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestGenerateMethods(t *testing.T) {
+	const dir = "../../testdata/methods"
+
+	if err := Run(dir); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	res, err := Generate(context.Background(), Options{Patterns: []string{dir}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	got := make(map[string][]string)
+	for _, p := range res.Plan {
+		got[p.Func] = p.Methods
+	}
+
+	if want := []string{"UserRequest.ToDomain", "UserRequest.FromDomain"}; !slices.Equal(got["ConvertUserRequestToUser"], want) {
+		t.Errorf("ConvertUserRequestToUser methods = %v, want %v", got["ConvertUserRequestToUser"], want)
+	}
+
+	if _, ok := got["ConvertUserToUserRequest"]; !ok {
+		t.Error("FromDomain requires the reverse conversion to be generated")
+	}
+
+	if want := []string{"ProfileRequest.AsProfile"}; !slices.Equal(got["ConvertProfileRequestToProfile"], want) {
+		t.Errorf("ConvertProfileRequestToProfile methods = %v, want %v", got["ConvertProfileRequestToProfile"], want)
+	}
+
+	// Generated methods are part of the output, so they must not make the file stale.
+	status, err := Status(context.Background(), Options{Patterns: []string{dir}})
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	if !status.UpToDate() {
+		t.Errorf("Status() = %+v, want up to date", status.Packages)
+	}
+}
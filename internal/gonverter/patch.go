@@ -0,0 +1,157 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/types"
+)
+
+// createPatchMapping creates the mapping of a field that a patch applies
+// differently from a conversion. It reports false for fields that are mapped
+// as in a conversion, which includes every field with a hook.
+func (g *generator) createPatchMapping(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) (fieldMapping, bool) {
+	srcName, dstName := srcField.Name(), dstField.Name()
+	srcType, dstType := srcField.Type(), dstField.Type()
+
+	if g.customFuncs[g.fieldFuncName(g.funcTypeName(pair, pair.from), g.funcTypeName(pair, pair.to), srcName, dstName)] {
+		return fieldMapping{}, false
+	}
+
+	// Structs of the same type are merged like structs of different types.
+	if types.Identical(derefType(srcType), derefType(dstType)) && isMergeableStruct(dstType) {
+		mapping, nested := g.handleStructField(pair, refs, srcField, dstField)
+
+		return g.newFieldMapping(pair, srcName, dstName, structMappingKind(srcField, dstField), mapping, nested), true
+	}
+
+	var (
+		mapping string
+		kind    = MappingAssign
+	)
+
+	switch {
+	case types.Identical(srcType, dstType):
+		switch dstType.Underlying().(type) {
+		case *types.Map:
+			mapping, kind = g.createMapMergeMapping("", refs.src, refs.dst, g.typeExpr(dstType), ""), MappingMap
+		case *types.Pointer, *types.Slice:
+			mapping = fmt.Sprintf(`if %s != nil {
+		%s = %s
+	}`, refs.src, refs.dst, refs.src)
+		default:
+			cond := ""
+			if pair.opts.zeroAsUnset {
+				cond = g.isSetExpr(refs.src, srcType)
+			}
+
+			if cond == "" {
+				return fieldMapping{}, false
+			}
+
+			mapping = fmt.Sprintf(`if %s {
+		%s = %s
+	}`, cond, refs.dst, refs.src)
+		}
+	case isPointerTo(srcType, dstType):
+		mapping, kind = fmt.Sprintf(`if %s != nil {
+		%s = *%s
+	}`, refs.src, refs.dst, refs.src), MappingPointer
+	default:
+		return fieldMapping{}, false
+	}
+
+	return g.newFieldMapping(pair, srcName, dstName, kind, mapping, nil), true
+}
+
+// createMergePointerFieldMapping creates patch code for struct fields with a
+// pointer destination, which is allocated only if it is nil so that the
+// fields it already has are kept.
+func (g *generator) createMergePointerFieldMapping(funcName string, refs fieldRefs, srcIsPtr bool, dstTypeName string) string {
+	if srcIsPtr {
+		return fmt.Sprintf(`if %s != nil {
+		if %s == nil {
+			%s = new(%s)
+		}
+		%s
+	}`, refs.src, refs.dst, refs.dst, dstTypeName, g.callStmt(funcName, refs.src, refs.dst))
+	}
+
+	return refs.withLoad(fmt.Sprintf(`if %s == nil {
+		%s = new(%s)
+	}
+	%s`, refs.dst, refs.dst, dstTypeName, g.callStmt(funcName, refs.addr, refs.dst)))
+}
+
+// createMapMergeMapping creates patch code merging the map src into dst, which
+// sets the keys of src in dst and keeps the others. Values are patched with
+// funcName, or replaced if it is empty.
+func (g *generator) createMapMergeMapping(funcName, src, dst, dstMapTypeName, dstValTypeName string) string {
+	set := fmt.Sprintf("%s[k] = v", dst)
+	if funcName != "" {
+		set = fmt.Sprintf(`merged := %s[k]
+			%s
+			%s[k] = merged`, dst, g.callStmt(funcName, "&v", "&merged"), dst)
+	}
+
+	return fmt.Sprintf(`if %s != nil {
+		if %s == nil {
+			%s = make(%s, len(%s))
+		}
+		for k, v := range %s {
+			%s
+		}
+	}`, src, dst, dst, dstMapTypeName, src, src, set)
+}
+
+// isSetExpr returns a condition that holds when expr, of type t, is not the
+// zero value, or an empty string if t has no zero value to compare with.
+func (g *generator) isSetExpr(expr string, t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch info := u.Info(); {
+		case info&types.IsBoolean != 0:
+			return expr
+		case info&types.IsString != 0:
+			return expr + ` != ""`
+		case info&types.IsNumeric != 0:
+			return expr + " != 0"
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return expr + " != nil"
+	case *types.Struct, *types.Array:
+		if types.Comparable(t) {
+			return fmt.Sprintf("%s != (%s{})", expr, g.typeExpr(t))
+		}
+	}
+
+	return ""
+}
+
+// isPointerTo reports whether ptr is a pointer to elem.
+func isPointerTo(ptr, elem types.Type) bool {
+	p, ok := ptr.(*types.Pointer)
+
+	return ok && types.Identical(p.Elem(), elem)
+}
+
+// isMergeableStruct reports whether a patch merges values of t, a named struct
+// or a pointer to one, field by field. Structs with unexported fields, such as
+// time.Time, are replaced as a whole.
+func isMergeableStruct(t types.Type) bool {
+	named, ok := derefType(t).(*types.Named)
+	if !ok {
+		return false
+	}
+
+	s, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < s.NumFields(); i++ {
+		if !s.Field(i).Exported() {
+			return false
+		}
+	}
+
+	return true
+}
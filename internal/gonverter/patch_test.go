@@ -0,0 +1,73 @@
+package gonverter
+
+import (
+	"context"
+	"go/types"
+	"testing"
+)
+
+func TestIsSetExpr(t *testing.T) {
+	g := &generator{}
+	point := types.NewStruct([]*types.Var{types.NewField(0, nil, "X", types.Typ[types.Int], false)}, nil)
+	list := types.NewStruct([]*types.Var{types.NewField(0, nil, "S", types.NewSlice(types.Typ[types.Int]), false)}, nil)
+
+	tests := []struct {
+		typ  types.Type
+		want string
+	}{
+		{types.Typ[types.Bool], "v"},
+		{types.Typ[types.String], `v != ""`},
+		{types.Typ[types.Float64], "v != 0"},
+		{types.NewPointer(types.Typ[types.Int]), "v != nil"},
+		{types.NewMap(types.Typ[types.String], types.Typ[types.Int]), "v != nil"},
+		{point, "v != (struct{X int}{})"},
+		{list, ""},
+	}
+
+	for _, tt := range tests {
+		if got := g.isSetExpr("v", tt.typ); got != tt.want {
+			t.Errorf("isSetExpr(%s) = %q, want %q", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestGeneratePatchPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/patch"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	plans := make(map[string]PairPlan)
+	for _, p := range res.Plan {
+		plans[p.Func] = p
+	}
+
+	for _, name := range []string{"ApplyUserPatchToUser", "ApplyAddressPatchToAddress", "ApplyProfileToProfile", "ApplyLimitsToLimits"} {
+		if p, ok := plans[name]; !ok || !p.Patch {
+			t.Errorf("plan of %s = %+v, want a patch", name, p)
+		}
+	}
+
+	kinds := make(map[string]FieldPlan)
+	for _, f := range plans["ApplyUserPatchToUser"].Fields {
+		kinds[f.Dst] = f
+	}
+
+	want := map[string]FieldPlan{
+		"Name":     {Src: "Name", Dst: "Name", Kind: MappingPointer},
+		"Labels":   {Src: "Labels", Dst: "Labels", Kind: MappingMap},
+		"Settings": {Src: "Settings", Dst: "Settings", Kind: MappingMap, Func: "ApplySettingPatchToSetting"},
+		"Profile":  {Src: "Profile", Dst: "Profile", Kind: MappingNested, Func: "ApplyProfileToProfile"},
+		"Role":     {Src: "Role", Dst: "Role", Kind: MappingCustom, Func: "ConvertUserPatchRoleToUserRole"},
+	}
+
+	for dst, w := range want {
+		if got := kinds[dst]; got != w {
+			t.Errorf("field %s = %+v, want %+v", dst, got, w)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/types"
+)
+
+// createValueMapping creates the mapping of a field whose type differs from
+// that of the destination field only by a pointer, by a conversion, or both,
+// such as *int32 and int64. Nil pointers become nil or, for fields of dst that
+// are not pointers, the zero value or an error with runtime.WithNilAsError.
+// Dereferencing and allocating need the BuiltinPointers category of
+// runtime.WithBuiltins. It reports false for other fields.
+func (g *generator) createValueMapping(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) (fieldMapping, bool) {
+	srcName, dstName := srcField.Name(), dstField.Name()
+	srcElem, srcIsPtr := pointerElem(srcField.Type())
+	dstElem, dstIsPtr := pointerElem(dstField.Type())
+
+	if !types.Identical(srcElem, dstElem) && !convertible(srcElem, dstElem) {
+		return fieldMapping{}, false
+	}
+
+	if srcIsPtr != dstIsPtr && pair.opts.builtins&builtinPointers == 0 {
+		return fieldMapping{}, false
+	}
+
+	// Values of the same type -> runtime.Deref and runtime.Ref
+	if types.Identical(srcElem, dstElem) && srcIsPtr != dstIsPtr && (dstIsPtr || !pair.opts.nilAsError) {
+		c := builtinConverter{builtinPointers, "Deref", false}
+		if dstIsPtr {
+			c.fn = "Ref"
+		}
+
+		return fieldMapping{
+			code:    createBuiltinMapping(c, refs, dstName),
+			runtime: true,
+			plan:    FieldPlan{Src: srcName, Dst: dstName, Kind: MappingBuiltin, Func: "runtime." + c.fn},
+		}, true
+	}
+
+	value := func(expr string) string {
+		if types.Identical(srcElem, dstElem) {
+			return expr
+		}
+
+		return fmt.Sprintf("%s(%s)", g.typeExpr(dstElem), expr)
+	}
+
+	var (
+		mapping  string
+		fallible bool
+		kind     = MappingPointer
+		src, dst = refs.src, refs.dst
+	)
+
+	switch {
+	case srcIsPtr && dstIsPtr:
+		mapping = fmt.Sprintf(`%s = nil
+	if %s != nil {
+		%s = new(%s)
+		*%s = %s
+	}`, dst, src, dst, g.typeExpr(dstElem), dst, value("*"+src))
+	case srcIsPtr && pair.opts.nilAsError:
+		mapping, fallible = fmt.Sprintf(`if %s == nil {
+		return &runtime.FieldError{Path: %q, Err: runtime.ErrNilPointer}
+	}
+	%s = %s`, src, dstName, dst, value("*"+src)), true
+	case srcIsPtr:
+		mapping = fmt.Sprintf(`%s = %s
+	if %s != nil {
+		%s = %s
+	}`, dst, g.zeroValue(dstElem), src, dst, value("*"+src))
+	case dstIsPtr:
+		mapping = fmt.Sprintf(`%s = new(%s)
+	*%s = %s`, dst, g.typeExpr(dstElem), dst, value(src))
+	default:
+		mapping, kind = fmt.Sprintf("%s = %s", dst, value(src)), MappingAssign
+	}
+
+	m := g.newFieldMapping(pair, srcName, dstName, kind, mapping, nil)
+	m.fallible, m.runtime = fallible, fallible
+
+	return m, true
+}
+
+// pointerElem returns the type t points to and true, or t and false if t is
+// not a pointer.
+func pointerElem(t types.Type) (types.Type, bool) {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem(), true
+	}
+
+	return t, false
+}
+
+// convertible reports whether values of src are converted to dst without loss
+// or change of meaning: src and dst have the same underlying type, like UserID
+// and string, or are numbers of the same kind and dst is at least as wide.
+// Two named types either of which has constants, such as two enums, are not
+// convertible, since their values are mapped by name with RegisterEnum; a
+// named type with constants converts to and from unnamed types only, like
+// Status and int32.
+func convertible(src, dst types.Type) bool {
+	srcNamed, _ := src.(*types.Named)
+	dstNamed, _ := dst.(*types.Named)
+
+	if srcNamed != nil && dstNamed != nil && (len(enumConstants(srcNamed, "")) > 0 || len(enumConstants(dstNamed, "")) > 0) {
+		return false
+	}
+
+	if types.Identical(src.Underlying(), dst.Underlying()) {
+		return true
+	}
+
+	srcBasic, ok := src.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+
+	dstBasic, ok := dst.Underlying().(*types.Basic)
+	if !ok {
+		return false
+	}
+
+	return widens(srcBasic, dstBasic)
+}
+
+// widens reports whether every value of the number type src fits in dst. int
+// and uint are taken to be 64 bits wide as a source and 32 bits wide as a
+// destination, so that the answer holds on every platform.
+func widens(src, dst *types.Basic) bool {
+	srcBits, dstBits := basicBits(src, 64), basicBits(dst, 32)
+	srcInfo, dstInfo := src.Info(), dst.Info()
+
+	switch {
+	case srcBits == 0 || dstBits == 0:
+		return false
+	case srcInfo&types.IsFloat != 0 && dstInfo&types.IsFloat != 0:
+		return srcBits <= dstBits
+	case srcInfo&types.IsInteger == 0 || dstInfo&types.IsInteger == 0:
+		return false
+	case srcInfo&types.IsUnsigned == dstInfo&types.IsUnsigned:
+		return srcBits <= dstBits
+	default:
+		// Unsigned values fit in wider signed types only.
+		return srcInfo&types.IsUnsigned != 0 && srcBits < dstBits
+	}
+}
+
+// basicBits returns the size in bits of the integer or float type t, intBits
+// for int and uint, or 0 for other types.
+func basicBits(t *types.Basic, intBits int) int {
+	switch t.Kind() {
+	case types.Int8, types.Uint8:
+		return 8
+	case types.Int16, types.Uint16:
+		return 16
+	case types.Int32, types.Uint32, types.Float32:
+		return 32
+	case types.Int64, types.Uint64, types.Float64:
+		return 64
+	case types.Int, types.Uint:
+		return intBits
+	default:
+		return 0
+	}
+}
+
+// zeroValue returns the zero value of t as written in the generated package.
+func (g *generator) zeroValue(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return `""`
+		case u.Info()&types.IsBoolean != 0:
+			return "false"
+		case u.Info()&types.IsNumeric != 0:
+			return "0"
+		default:
+			return "nil"
+		}
+	case *types.Pointer, *types.Slice, *types.Map, *types.Chan, *types.Signature, *types.Interface:
+		return "nil"
+	default:
+		return g.typeExpr(t) + "{}"
+	}
+}
@@ -0,0 +1,83 @@
+package gonverter
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestConvertible(t *testing.T) {
+	pkg := types.NewPackage("example.com/domain", "domain")
+	named := func(name string, underlying types.Type) *types.Named {
+		return types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), underlying, nil)
+	}
+
+	userID, accountID := named("UserID", types.Typ[types.String]), named("AccountID", types.Typ[types.String])
+	status := named("Status", types.Typ[types.Int32])
+	pkg.Scope().Insert(types.NewConst(token.NoPos, pkg, "StatusActive", status, nil))
+
+	tests := []struct {
+		src, dst types.Type
+		want     bool
+	}{
+		{userID, types.Typ[types.String], true},
+		{types.Typ[types.String], userID, true},
+		{userID, accountID, true},
+		{status, types.Typ[types.Int64], true},
+		{status, named("Role", types.Typ[types.Int32]), false},
+		{types.Typ[types.Int32], types.Typ[types.Int64], true},
+		{types.Typ[types.Int32], types.Typ[types.Int], true},
+		{types.Typ[types.Int], types.Typ[types.Int64], true},
+		{types.Typ[types.Int64], types.Typ[types.Int], false},
+		{types.Typ[types.Int64], types.Typ[types.Int32], false},
+		{types.Typ[types.Uint16], types.Typ[types.Int32], true},
+		{types.Typ[types.Uint32], types.Typ[types.Int32], false},
+		{types.Typ[types.Int8], types.Typ[types.Uint64], false},
+		{types.Typ[types.Float32], types.Typ[types.Float64], true},
+		{types.Typ[types.Int32], types.Typ[types.Float64], false},
+		{types.Typ[types.String], types.Typ[types.Int], false},
+		{types.NewSlice(types.Typ[types.String]), named("Tags", types.NewSlice(types.Typ[types.String])), true},
+	}
+
+	for _, tt := range tests {
+		if got := convertible(tt.src, tt.dst); got != tt.want {
+			t.Errorf("convertible(%s, %s) = %v, want %v", tt.src, tt.dst, got, tt.want)
+		}
+	}
+}
+
+func TestCreateValueMappingNeedsBuiltinPointers(t *testing.T) {
+	str, i32, i64 := types.Typ[types.String], types.Typ[types.Int32], types.Typ[types.Int64]
+	field := func(typ types.Type) *types.Var {
+		return types.NewField(token.NoPos, nil, "Value", typ, false)
+	}
+
+	tests := []struct {
+		opts     registrationOptions
+		src, dst types.Type
+		want     MappingKind // empty if the field is not mapped
+		fn       string
+	}{
+		{registrationOptions{}, types.NewPointer(str), str, "", ""},
+		{registrationOptions{}, str, types.NewPointer(str), "", ""},
+		{registrationOptions{}, types.NewPointer(i32), i64, "", ""},
+		{registrationOptions{}, types.NewPointer(i32), types.NewPointer(i64), MappingPointer, ""},
+		{registrationOptions{}, i32, i64, MappingAssign, ""},
+		{registrationOptions{builtins: builtinPointers}, types.NewPointer(str), str, MappingBuiltin, "runtime.Deref"},
+		{registrationOptions{builtins: builtinPointers}, str, types.NewPointer(str), MappingBuiltin, "runtime.Ref"},
+		{registrationOptions{builtins: builtinPointers}, types.NewPointer(i32), i64, MappingPointer, ""},
+		{registrationOptions{builtins: builtinPointers, nilAsError: true}, types.NewPointer(str), str, MappingPointer, ""},
+	}
+
+	for _, tt := range tests {
+		g := &generator{}
+		pair := &conversionPair{opts: tt.opts}
+
+		refs := fieldRefs{vars: pairVars, src: "src.Value", addr: "&src.Value", dst: "dst.Value"}
+
+		m, ok := g.createValueMapping(pair, refs, field(tt.src), field(tt.dst))
+		if ok != (tt.want != "") || ok && (m.plan.Kind != tt.want || m.plan.Func != tt.fn) {
+			t.Errorf("createValueMapping(%s, %s) with %+v = %+v, %v, want %s %s", tt.src, tt.dst, tt.opts, m.plan, ok, tt.want, tt.fn)
+		}
+	}
+}
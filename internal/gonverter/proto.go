@@ -0,0 +1,480 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Packages of the protobuf runtime and of the well-known types.
+const (
+	protobufModule  = "google.golang.org/protobuf/"
+	timestamppbPath = protobufModule + "types/known/timestamppb"
+	durationpbPath  = protobufModule + "types/known/durationpb"
+	wrapperspbPath  = protobufModule + "types/known/wrapperspb"
+)
+
+// isProtoMessage reports whether t, or the type t points to, is a message
+// struct generated by protoc-gen-go, which keeps its state in a field of type
+// protoimpl.MessageState.
+func isProtoMessage(t types.Type) bool {
+	st, ok := derefType(t).Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+
+	for i := 0; i < st.NumFields(); i++ {
+		if f := st.Field(i); f.Name() == "state" {
+			named, ok := types.Unalias(f.Type()).(*types.Named)
+
+			return ok && named.Obj().Name() == "MessageState" && named.Obj().Pkg() != nil &&
+				strings.HasPrefix(named.Obj().Pkg().Path(), protobufModule)
+		}
+	}
+
+	return false
+}
+
+// hasGetter reports whether msg has a Get method for field f returning its type.
+func hasGetter(msg types.Type, f *types.Var) bool {
+	obj, _, _ := types.LookupFieldOrMethod(pointerTo(derefType(msg)), false, f.Pkg(), "Get"+f.Name())
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig := fn.Type().(*types.Signature)
+
+	return sig.Params().Len() == 0 && sig.Results().Len() == 1 && types.Identical(sig.Results().At(0).Type(), f.Type())
+}
+
+// createWellKnownMapping creates the mapping of a field holding a well-known
+// protobuf type from or to the native Go type it stands for: Timestamp and
+// time.Time, Duration and time.Duration, and the wrappers such as StringValue
+// and pointers to their values. It reports false for other fields.
+func (g *generator) createWellKnownMapping(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) (fieldMapping, bool) {
+	srcName, dstName := srcField.Name(), dstField.Name()
+	srcType, dstType := srcField.Type(), dstField.Type()
+
+	var (
+		code, fn string
+		src, dst = refs.src, refs.dst
+	)
+
+	switch {
+	case isWellKnown(srcType, timestamppbPath, "Timestamp") && isTimeType(dstType):
+		fn = g.typeExpr(srcType) + ".AsTime"
+		code = fmt.Sprintf(`%s = %s{}
+	if %s != nil {
+		%s = %s.AsTime()
+	}`, dst, g.typeExpr(dstType), src, dst, src)
+	case isTimeType(srcType) && isWellKnown(dstType, timestamppbPath, "Timestamp"):
+		fn = g.wellKnownQualifier(dstType) + "New"
+		code = fmt.Sprintf(`%s = nil
+	if !%s.IsZero() {
+		%s = %s(%s)
+	}`, dst, src, dst, fn, src)
+	case isWellKnown(srcType, durationpbPath, "Duration") && isDurationType(dstType):
+		fn = g.typeExpr(srcType) + ".AsDuration"
+		code = fmt.Sprintf("%s = %s.AsDuration()", dst, src)
+	case isDurationType(srcType) && isWellKnown(dstType, durationpbPath, "Duration"):
+		fn = g.wellKnownQualifier(dstType) + "New"
+		code = fmt.Sprintf("%s = %s(%s)", dst, fn, src)
+	case wrapperValue(srcType) != nil:
+		value := wrapperValue(srcType)
+		fn = g.typeExpr(srcType) + ".GetValue"
+
+		switch {
+		case isPointerTo(dstType, value):
+			code = fmt.Sprintf(`%s = nil
+	if %s != nil {
+		%s = new(%s)
+		*%s = %s.GetValue()
+	}`, dst, src, dst, g.typeExpr(value), dst, src)
+		case types.Identical(dstType, value):
+			code = fmt.Sprintf("%s = %s.GetValue()", dst, src)
+		}
+	case wrapperValue(dstType) != nil:
+		value := wrapperValue(dstType)
+		fn = g.wellKnownQualifier(dstType) + strings.TrimSuffix(derefType(dstType).(*types.Named).Obj().Name(), "Value")
+
+		switch {
+		case isPointerTo(srcType, value):
+			code = fmt.Sprintf(`%s = nil
+	if %s != nil {
+		%s = %s(*%s)
+	}`, dst, src, dst, fn, src)
+		case types.Identical(srcType, value):
+			code = fmt.Sprintf("%s = %s(%s)", dst, fn, src)
+		}
+	}
+
+	if code == "" {
+		return fieldMapping{}, false
+	}
+
+	return fieldMapping{
+		code: code,
+		plan: FieldPlan{Src: srcName, Dst: dstName, Kind: MappingBuiltin, Func: fn},
+	}, true
+}
+
+// isWellKnown reports whether t is a pointer to the type name of the package path.
+func isWellKnown(t types.Type, path, name string) bool {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return false
+	}
+
+	named, ok := p.Elem().(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == path && named.Obj().Name() == name
+}
+
+// wrapperValue returns the type of the value held by t, a pointer to a
+// wrapperspb type such as StringValue, or nil if t is something else.
+func wrapperValue(t types.Type) types.Type {
+	p, ok := t.(*types.Pointer)
+	if !ok {
+		return nil
+	}
+
+	named, ok := p.Elem().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != wrapperspbPath {
+		return nil
+	}
+
+	if f := findField(named.Underlying().(*types.Struct), "Value"); f != nil {
+		return f.Type()
+	}
+
+	return nil
+}
+
+func isTimeType(t types.Type) bool {
+	return isNamedType(t, "time", "Time")
+}
+
+func isDurationType(t types.Type) bool {
+	return isNamedType(t, "time", "Duration")
+}
+
+func isNamedType(t types.Type, path, name string) bool {
+	named, ok := t.(*types.Named)
+
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == path && named.Obj().Name() == name
+}
+
+// wellKnownQualifier returns the qualifier of the package of t, a pointer to
+// a well-known type, e.g. "timestamppb.", and records the import.
+func (g *generator) wellKnownQualifier(t types.Type) string {
+	named := derefType(t).(*types.Named)
+
+	return strings.TrimSuffix(g.typeExpr(named), named.Obj().Name())
+}
+
+// oneofCase is a case of a oneof field of a protoc-gen-go message: a wrapper
+// struct such as User_Email, whose only field holds the value.
+type oneofCase struct {
+	wrapper *types.Named
+	field   *types.Var
+}
+
+// oneofCases returns the cases of f, a field of msg, in order of declaration,
+// or nil if f is not a oneof field. Oneof fields have an unexported interface
+// type that the pointers to the wrappers of the cases implement.
+func oneofCases(msg types.Type, f *types.Var) []oneofCase {
+	named, ok := f.Type().(*types.Named)
+	if !ok || named.Obj().Exported() || named.Obj().Pkg() == nil || !isProtoMessage(msg) {
+		return nil
+	}
+
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+
+	var cases []oneofCase
+
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		tn, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+
+		wrapper, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+
+		st, ok := wrapper.Underlying().(*types.Struct)
+		if ok && st.NumFields() == 1 && types.Implements(types.NewPointer(wrapper), iface) {
+			cases = append(cases, oneofCase{wrapper: wrapper, field: st.Field(0)})
+		}
+	}
+
+	sort.Slice(cases, func(i, j int) bool { return cases[i].wrapper.Obj().Pos() < cases[j].wrapper.Obj().Pos() })
+
+	return cases
+}
+
+// createOneofCaseMapping creates the mapping of a destination field without a
+// source field, which takes the value of the oneof case of the same name of
+// the source message, e.g. Email *string from the case User_Email.
+func (g *generator) createOneofCaseMapping(pair *conversionPair, refs fieldRefs, dstField *types.Var) (fieldMapping, bool) {
+	st, ok := derefType(pair.from.typ).Underlying().(*types.Struct)
+	if !ok {
+		return fieldMapping{}, false
+	}
+
+	dstName := dstField.Name()
+
+	for i := 0; i < st.NumFields(); i++ {
+		oneof := st.Field(i)
+
+		for _, c := range oneofCases(pair.from.typ, oneof) {
+			if c.field.Name() != dstName {
+				continue
+			}
+
+			assign, nested, ok := g.oneofValueStmt(pair, "c."+dstName, c.field.Type(), refs.dst, dstField.Type(), dstField.Pos())
+			if !ok {
+				return fieldMapping{}, false
+			}
+
+			m := fieldMapping{
+				code: fmt.Sprintf(`%s = %s
+	if c, ok := %s.(*%s); ok {
+		%s
+	}`, refs.dst, g.zeroValue(dstField.Type()), g.sourceRead(pair, refs.vars, oneof), g.typeExpr(c.wrapper), assign),
+				plan: FieldPlan{Src: oneof.Name(), Dst: dstName, Kind: MappingOneof},
+			}
+
+			if nested != nil {
+				m.calls = append(m.calls, *nested)
+			}
+
+			return m, true
+		}
+	}
+
+	return fieldMapping{}, false
+}
+
+// createOneofFieldMapping creates the mapping of a oneof field of the
+// destination message without a source field, which is set to the case whose
+// field of the same name is set in the source, e.g. User_Email from
+// Email *string. The last set field wins.
+func (g *generator) createOneofFieldMapping(pair *conversionPair, refs fieldRefs, dstField *types.Var) (fieldMapping, bool) {
+	st, ok := derefType(pair.from.typ).Underlying().(*types.Struct)
+	if !ok {
+		return fieldMapping{}, false
+	}
+
+	dstName := dstField.Name()
+	m := fieldMapping{plan: FieldPlan{Dst: dstName, Kind: MappingOneof}}
+	stmts := []string{refs.dst + " = nil"}
+
+	for _, c := range oneofCases(pair.to.typ, dstField) {
+		srcField := findField(st, c.field.Name())
+		if srcField == nil {
+			continue
+		}
+
+		if _, ok := srcField.Type().(*types.Pointer); !ok {
+			continue
+		}
+
+		src := g.sourceRead(pair, refs.vars, srcField)
+
+		assign, nested, ok := g.oneofValueStmt(pair, src, srcField.Type(), "w."+c.field.Name(), c.field.Type(), srcField.Pos())
+		if !ok {
+			continue
+		}
+
+		stmts = append(stmts, fmt.Sprintf(`if %s != nil {
+		w := new(%s)
+		%s
+		%s = w
+	}`, src, g.typeExpr(c.wrapper), assign, refs.dst))
+
+		if nested != nil {
+			m.calls = append(m.calls, *nested)
+		}
+	}
+
+	if len(stmts) == 1 {
+		return fieldMapping{}, false
+	}
+
+	m.code = strings.Join(stmts, "\n")
+
+	return m, true
+}
+
+// oneofValueStmt returns the statement setting dst, of type dstType, to the
+// value src of type srcType, where one of them is the field of a oneof case.
+// Messages are converted by the returned nested pair, and other values must
+// differ only by a pointer or a conversion.
+func (g *generator) oneofValueStmt(pair *conversionPair, src string, srcType types.Type, dst string, dstType types.Type, pos token.Pos) (string, *conversionPair, bool) {
+	srcElem, srcIsPtr := pointerElem(srcType)
+	dstElem, dstIsPtr := pointerElem(dstType)
+
+	if srcIsPtr {
+		src = "*" + src
+	}
+
+	if srcIsPtr && dstIsPtr && isStructType(srcElem) && isStructType(dstElem) {
+		nested := &conversionPair{
+			from:      extractTypeInfo(srcType),
+			to:        extractTypeInfo(dstType),
+			pos:       pos,
+			nested:    true,
+			qualified: pair.qualified,
+			opts:      pair.opts.nested(),
+		}
+
+		return fmt.Sprintf(`%s = new(%s)
+		%s`, dst, g.typeExpr(dstElem), g.callStmt(g.convertFuncName(nested), strings.TrimPrefix(src, "*"), dst)), nested, true
+	}
+
+	if !types.Identical(srcElem, dstElem) && !convertible(srcElem, dstElem) {
+		return "", nil, false
+	}
+
+	value := src
+	if !types.Identical(srcElem, dstElem) {
+		value = fmt.Sprintf("%s(%s)", g.typeExpr(dstElem), src)
+	}
+
+	if dstIsPtr {
+		return fmt.Sprintf(`%s = new(%s)
+		*%s = %s`, dst, g.typeExpr(dstElem), dst, value), nil, true
+	}
+
+	return fmt.Sprintf("%s = %s", dst, value), nil, true
+}
+
+// createSealedMapping creates the mapping between a oneof field of a message
+// and a field of the same name holding a sealed interface, whose
+// implementations stand for the cases. The implementation of the case Email
+// of the interface Contact is named Email or EmailContact, and is converted
+// from and to the wrapper of the case by a nested conversion. It reports
+// false if neither field is such a pair.
+func (g *generator) createSealedMapping(pair *conversionPair, refs fieldRefs, srcField, dstField *types.Var) (fieldMapping, bool) {
+	toProto := true
+
+	cases := oneofCases(pair.to.typ, dstField)
+	sealed, _ := srcField.Type().(*types.Named)
+
+	if cases == nil {
+		toProto = false
+		cases = oneofCases(pair.from.typ, srcField)
+		sealed, _ = dstField.Type().(*types.Named)
+	}
+
+	if cases == nil || sealed == nil || !types.IsInterface(sealed) || sealed.Obj().Pkg() == nil {
+		return fieldMapping{}, false
+	}
+
+	iface := sealed.Underlying().(*types.Interface)
+	m := fieldMapping{plan: FieldPlan{Src: srcField.Name(), Dst: dstField.Name(), Kind: MappingOneof}}
+
+	var stmts []string
+
+	for _, c := range cases {
+		impl, ptr := sealedImpl(sealed, iface, c.field.Name())
+		if impl == nil {
+			g.errorf(dstField.Pos(), "oneof case %s of %s has no implementation of %s; name it %s or %s",
+				c.wrapper.Obj().Name(), typeString(derefType(pair.from.typ)), typeString(sealed), c.field.Name(), c.field.Name()+sealed.Obj().Name())
+
+			continue
+		}
+
+		if toProto {
+			nested := g.sealedPair(pair, impl, c.wrapper, dstField.Pos())
+			call := func(src string) string {
+				return fmt.Sprintf(`w := new(%s)
+		%s
+		%s = w`, g.typeExpr(c.wrapper), g.callStmt(g.convertFuncName(nested), src, "w"), refs.dst)
+			}
+
+			if !ptr {
+				stmts = append(stmts, fmt.Sprintf(`case %s:
+		%s`, g.typeExpr(impl), call("&c")))
+			}
+
+			stmts = append(stmts, fmt.Sprintf(`case *%s:
+		%s`, g.typeExpr(impl), call("c")))
+			m.calls = append(m.calls, *nested)
+
+			continue
+		}
+
+		nested := g.sealedPair(pair, c.wrapper, impl, dstField.Pos())
+		value := "v"
+
+		if !ptr {
+			value = "*v"
+		}
+
+		stmts = append(stmts, fmt.Sprintf(`case *%s:
+		v := new(%s)
+		%s
+		%s = %s`, g.typeExpr(c.wrapper), g.typeExpr(impl), g.callStmt(g.convertFuncName(nested), "c", "v"), refs.dst, value))
+		m.calls = append(m.calls, *nested)
+	}
+
+	m.code = fmt.Sprintf(`%s = nil
+	switch c := %s.(type) {
+	%s
+	}`, refs.dst, refs.src, strings.Join(stmts, "\n"))
+
+	return m, true
+}
+
+// sealedImpl returns the implementation of the sealed interface iface, named
+// sealed, standing for the oneof case name, and whether only a pointer to it
+// implements iface.
+func sealedImpl(sealed *types.Named, iface *types.Interface, name string) (*types.Named, bool) {
+	scope := sealed.Obj().Pkg().Scope()
+
+	for _, candidate := range []string{name, name + sealed.Obj().Name()} {
+		tn, ok := scope.Lookup(candidate).(*types.TypeName)
+		if !ok {
+			continue
+		}
+
+		impl, ok := tn.Type().(*types.Named)
+		if !ok || types.IsInterface(impl) {
+			continue
+		}
+
+		if types.Implements(impl, iface) {
+			return impl, false
+		}
+
+		if types.Implements(types.NewPointer(impl), iface) {
+			return impl, true
+		}
+	}
+
+	return nil, false
+}
+
+// sealedPair returns the nested pair converting between the wrapper of a
+// oneof case and the implementation of a sealed interface.
+func (g *generator) sealedPair(pair *conversionPair, from, to *types.Named, pos token.Pos) *conversionPair {
+	return &conversionPair{
+		from:      extractTypeInfo(types.NewPointer(from)),
+		to:        extractTypeInfo(types.NewPointer(to)),
+		pos:       pos,
+		nested:    true,
+		qualified: pair.qualified,
+		opts:      pair.opts.nested(),
+	}
+}
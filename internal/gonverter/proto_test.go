@@ -0,0 +1,63 @@
+package gonverter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerateProtoPlan(t *testing.T) {
+	// The example is a module of its own so that gonverter doesn't depend on protobuf.
+	res, err := Generate(context.Background(), Options{Dir: "../../examples/protobuf", Patterns: []string{"./converter"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	kinds := map[string]map[string]FieldPlan{}
+
+	for _, p := range res.Plan {
+		kinds[p.Func] = map[string]FieldPlan{}
+		for _, f := range p.Fields {
+			kinds[p.Func][f.Dst] = f
+		}
+	}
+
+	tests := []struct {
+		fn, dst string
+		kind    MappingKind
+		call    string
+	}{
+		{"ConvertPbUserToDomainUser", "CreatedAt", MappingBuiltin, "*timestamppb.Timestamp.AsTime"},
+		{"ConvertPbUserToDomainUser", "Nickname", MappingBuiltin, "*wrapperspb.StringValue.GetValue"},
+		{"ConvertPbUserToDomainUser", "Contact", MappingOneof, ""},
+		{"ConvertPbUserToDomainUser", "Webhook", MappingOneof, ""},
+		{"ConvertDomainUserToPbUser", "CreatedAt", MappingBuiltin, "timestamppb.New"},
+		{"ConvertDomainUserToPbUser", "Age", MappingBuiltin, "wrapperspb.Int32"},
+		{"ConvertDomainUserToPbUser", "Notify", MappingOneof, ""},
+	}
+
+	for _, tt := range tests {
+		f, ok := kinds[tt.fn][tt.dst]
+		if !ok || f.Kind != tt.kind || f.Func != tt.call {
+			t.Errorf("%s field %s = %+v, want %s %q", tt.fn, tt.dst, f, tt.kind, tt.call)
+		}
+	}
+
+	// The cases of oneofs are converted by functions of their own.
+	for _, fn := range []string{"ConvertPbUser_PhoneToDomainPhoneContact", "ConvertDomainWebhookToPbWebhook"} {
+		if _, ok := kinds[fn]; !ok {
+			t.Errorf("no plan for %s", fn)
+		}
+	}
+
+	content := string(res.Files[0].Content)
+	for _, want := range []string{"dst.Name = src.GetName()", "ConvertPbAddressToDomainAddress(src.GetAddress(), dst.Address)", "dst.Name = src.Name"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated code does not contain %q", want)
+		}
+	}
+}
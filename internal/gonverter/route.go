@@ -0,0 +1,246 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// conversionEdge is a function converting *from into *to.
+type conversionEdge struct {
+	from, to typeInfo
+	fn       string
+}
+
+// conversionGraph holds the conversions of a package, keyed by the qualified
+// name of their source type. Routes are composed by walking it.
+type conversionGraph map[string][]conversionEdge
+
+// newConversionGraph builds the graph of the pointer-to-pointer pairs
+// registered in a package and its hand-written whole-type conversions.
+func (g *generator) newConversionGraph(in *packageInput) conversionGraph {
+	graph := make(conversionGraph)
+
+	for i := range in.pairs {
+		pair := &in.pairs[i]
+		if pair.route || pair.patch || pair.masked || pair.enum || !pair.from.isPointer || !pair.to.isPointer {
+			continue
+		}
+
+		graph.add(conversionEdge{from: pair.from, to: pair.to, fn: g.convertFuncName(pair)})
+	}
+
+	for _, hook := range in.hooks {
+		graph.add(hook)
+	}
+
+	return graph
+}
+
+func (cg conversionGraph) add(e conversionEdge) {
+	from := qualifiedTypeName(e.from)
+
+	for _, existing := range cg[from] {
+		if qualifiedTypeName(existing.to) == qualifiedTypeName(e.to) {
+			return
+		}
+	}
+
+	cg[from] = append(cg[from], e)
+}
+
+// shortestPath returns the conversions leading from one type to another with
+// the fewest hops, or nil if there is no such path.
+func (cg conversionGraph) shortestPath(from, to typeInfo) []conversionEdge {
+	start, goal := qualifiedTypeName(from), qualifiedTypeName(to)
+	prev := map[string]conversionEdge{}
+	visited := map[string]bool{start: true}
+	queue := []string{start}
+
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+
+		if cur == goal {
+			break
+		}
+
+		for _, e := range cg[cur] {
+			next := qualifiedTypeName(e.to)
+			if visited[next] {
+				continue
+			}
+
+			visited[next] = true
+			prev[next] = e
+			queue = append(queue, next)
+		}
+	}
+
+	if !visited[goal] || start == goal {
+		return nil
+	}
+
+	var path []conversionEdge
+
+	for cur := goal; cur != start; {
+		e := prev[cur]
+		path = append([]conversionEdge{e}, path...)
+		cur = qualifiedTypeName(e.from)
+	}
+
+	return path
+}
+
+// detectConversionHooks returns the hand-written functions converting one
+// struct into another, i.e. Convert<From>To<To>(src *From, dst *To). Field
+// hooks share the signature but not the name, so they are left out.
+func (g *generator) detectConversionHooks(pkg *packages.Package, customFuncs map[string]bool) []conversionEdge {
+	var hooks []conversionEdge
+
+	for _, name := range sortedKeys(customFuncs) {
+		fn, ok := pkg.Types.Scope().Lookup(name).(*types.Func)
+		if !ok {
+			continue
+		}
+
+		sig, ok := fn.Type().(*types.Signature)
+		if !ok || sig.Params().Len() != 2 || sig.Results().Len() != 0 {
+			continue
+		}
+
+		from := extractTypeInfo(sig.Params().At(0).Type())
+		to := extractTypeInfo(sig.Params().At(1).Type())
+
+		if !from.isPointer || !to.isPointer || from.typeName == "" || to.typeName == "" {
+			continue
+		}
+
+		plain := conversionPair{from: from, to: to}
+		qualified := conversionPair{from: from, to: to, qualified: true}
+
+		if name == g.convertFuncName(&plain) || name == g.convertFuncName(&qualified) {
+			hooks = append(hooks, conversionEdge{from: from, to: to, fn: name})
+		}
+	}
+
+	return hooks
+}
+
+// resolveRoute finds the conversions a route is composed of: the shortest path
+// to each type in pair.via in turn, then on to the destination.
+func (g *generator) resolveRoute(pair *conversionPair) ([]conversionEdge, bool) {
+	stops := append(append([]typeInfo{}, pair.via...), pair.to)
+	from := pair.from
+
+	var steps []conversionEdge
+
+	for _, to := range stops {
+		path := g.graph.shortestPath(from, to)
+		if path == nil {
+			g.errorf(pair.pos, "no conversion path from %s to %s", typeString(derefType(from.typ)), typeString(derefType(to.typ)))
+
+			return nil, false
+		}
+
+		steps = append(steps, path...)
+		from = to
+	}
+
+	return steps, true
+}
+
+// checkLossyRoute warns about fields that the source and destination of a
+// route have in common but that an intermediate type drops along the way,
+// and returns their names.
+func (g *generator) checkLossyRoute(pair *conversionPair, steps []conversionEdge) []string {
+	fromStruct, ok := derefType(pair.from.typ).Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	toStruct, ok := derefType(pair.to.typ).Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	var lossy []string
+
+	for i := 0; i < toStruct.NumFields(); i++ {
+		field := toStruct.Field(i)
+		if !field.Exported() || findField(fromStruct, field.Name()) == nil {
+			continue
+		}
+
+		for _, step := range steps[:len(steps)-1] {
+			mid, ok := derefType(step.to.typ).Underlying().(*types.Struct)
+			if ok && findField(mid, field.Name()) == nil {
+				g.warnf(pair.pos, "lossy conversion path: %s is dropped converting %s to %s through %s",
+					field.Name(), typeString(derefType(pair.from.typ)), typeString(derefType(pair.to.typ)), typeString(derefType(step.to.typ)))
+
+				lossy = append(lossy, field.Name())
+
+				break
+			}
+		}
+	}
+
+	return lossy
+}
+
+// buildRouteFuncData builds a function that converts pair by chaining the
+// conversions of its route, keeping each intermediate value in a temporary.
+func (g *generator) buildRouteFuncData(pair *conversionPair, pkgName, pkgPath string, imports map[string]bool) (funcData, bool) {
+	fd := g.newFuncData(pair, pkgName, imports)
+
+	steps, ok := g.resolveRoute(pair)
+	if !ok {
+		return fd, false
+	}
+
+	if len(steps) == 1 {
+		g.warnf(pair.pos, "redundant route: %s already converts %s to %s",
+			steps[0].fn, typeString(derefType(pair.from.typ)), typeString(derefType(pair.to.typ)))
+
+		return fd, false
+	}
+
+	plan := PairPlan{
+		Func:         fd.Name,
+		PkgPath:      pkgPath,
+		From:         qualifiedTypeName(pair.from),
+		To:           qualifiedTypeName(pair.to),
+		Lossy:        g.checkLossyRoute(pair, steps),
+		Hooks:        fd.hooks,
+		Fallible:     fd.Fallible,
+		Validated:    fd.Validate != "",
+		Defaulter:    fd.Defaults,
+		Constructors: fd.Constructor.funcNames(),
+		Methods:      fd.Methods.funcNames(),
+	}
+
+	src := "src"
+
+	for i, step := range steps[:len(steps)-1] {
+		mid := step.to
+		if mid.pkgPath != "" && mid.pkgName != pkgName {
+			imports[mid.pkgPath] = true
+		}
+
+		tmp := fmt.Sprintf("tmp%d", i)
+
+		fd.Mappings = append(fd.Mappings,
+			fmt.Sprintf("var %s %s", tmp, g.typeExpr(derefType(mid.typ))),
+			g.callStmt(step.fn, src, "&"+tmp),
+		)
+		plan.Via = append(plan.Via, qualifiedTypeName(mid))
+
+		src = "&" + tmp
+	}
+
+	fd.Mappings = append(fd.Mappings, g.callStmt(steps[len(steps)-1].fn, src, "dst"))
+	g.result.Plan = append(g.result.Plan, plan)
+
+	return fd, true
+}
@@ -0,0 +1,74 @@
+package gonverter
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestConversionGraphShortestPath(t *testing.T) {
+	a := typeInfo{pkgPath: "p", typeName: "A"}
+	b := typeInfo{pkgPath: "p", typeName: "B"}
+	c := typeInfo{pkgPath: "p", typeName: "C"}
+	d := typeInfo{pkgPath: "p", typeName: "D"}
+
+	graph := make(conversionGraph)
+	graph.add(conversionEdge{from: a, to: b, fn: "AToB"})
+	graph.add(conversionEdge{from: b, to: c, fn: "BToC"})
+	graph.add(conversionEdge{from: c, to: d, fn: "CToD"})
+	graph.add(conversionEdge{from: a, to: c, fn: "AToC"})
+
+	tests := []struct {
+		name     string
+		from, to typeInfo
+		want     []string
+	}{
+		{name: "shortest of two paths", from: a, to: d, want: []string{"AToC", "CToD"}},
+		{name: "single hop", from: b, to: c, want: []string{"BToC"}},
+		{name: "unreachable", from: d, to: a, want: nil},
+		{name: "same type", from: a, to: a, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, e := range graph.shortestPath(tt.from, tt.to) {
+				got = append(got, e.fn)
+			}
+
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("shortestPath() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateRoutes(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/multihop"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	const pkg = "github.com/sivchari/gonverter/testdata/multihop"
+
+	var via []string
+
+	for _, p := range res.Plan {
+		if p.Func == "ConvertAPIUserToRow" {
+			via = p.Via
+		}
+	}
+
+	if want := pkg + ".DomainUser," + pkg + ".DBUser"; strings.Join(via, ",") != want {
+		t.Errorf("ConvertAPIUserToRow Via = %v, want %s", via, want)
+	}
+
+	if len(res.Diagnostics) != 1 {
+		t.Fatalf("len(Diagnostics) = %d, want 1: %v", len(res.Diagnostics), res.Diagnostics)
+	}
+
+	d := res.Diagnostics[0]
+	if d.Severity != SeverityWarning || d.Line != 11 || !strings.Contains(d.Message, "Nickname is dropped") {
+		t.Errorf("Diagnostics[0] = %s, want lossy warning for Nickname at line 11", d)
+	}
+}
@@ -0,0 +1,221 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// extractSplitPair reads a RegisterSplit2 or RegisterSplit3 call. Its to is
+// the tuple of the parameters of the destinations, named after their types
+// joined with And.
+func (g *generator) extractSplitPair(pkg *packages.Package, call *ast.CallExpr, typeList []types.Type) (conversionPair, bool) {
+	pair := conversionPair{
+		from: extractTypeInfo(pointerTo(typeList[0])),
+		pos:  call.Pos(),
+		opts: g.parseSplitOptions(pkg, call),
+	}
+
+	taken := importNames(pkg)
+	taken[pair.from.pkgName] = true
+
+	infos, ok := g.distinctTypes(call.Pos(), "destination", typeList[1:], taken)
+	if !ok {
+		return pair, false
+	}
+
+	pair.qualified = isProtoMessage(pair.from.typ)
+	for _, info := range infos {
+		pair.qualified = pair.qualified || isProtoMessage(info.typ)
+	}
+
+	names, to := g.paramTuple(&pair, pkg, call.Pos(), infos, taken)
+	for i, info := range infos {
+		pair.destinations = append(pair.destinations, typeParam{info: info, name: names[i]})
+	}
+
+	pair.to = to
+
+	return pair, true
+}
+
+// destinationPair returns the conversion of pair to its destination d alone.
+func destinationPair(pair *conversionPair, d typeParam) conversionPair {
+	return conversionPair{
+		from:      pair.from,
+		to:        d.info,
+		pos:       pair.pos,
+		qualified: pair.qualified,
+		opts:      pair.opts,
+	}
+}
+
+// buildSplitMappings maps the fields of every destination of a split
+// conversion as if it were converted alone, with field hooks of its own.
+func (g *generator) buildSplitMappings(pair *conversionPair) ([]fieldMapping, bool) {
+	fromStruct, ok := derefType(pair.from.typ).Underlying().(*types.Struct)
+	if !ok {
+		g.errorf(pair.pos, "cannot convert from %s: not a struct type", typeString(pair.from.typ))
+
+		return nil, false
+	}
+
+	for _, d := range pair.destinations {
+		if _, ok := derefType(d.info.typ).Underlying().(*types.Struct); !ok {
+			g.errorf(pair.pos, "cannot convert to %s: not a struct type", typeString(d.info.typ))
+
+			return nil, false
+		}
+	}
+
+	reads, decls := g.sharedReads(pair, fromStruct)
+
+	mappings := g.destinationMappings(pair, fromStruct, reads)
+	if len(decls) > 0 {
+		mappings = append([]fieldMapping{{code: strings.Join(decls, "\n")}}, mappings...)
+	}
+
+	// The mappings of each destination are set apart.
+	last := -1
+
+	for i := range mappings {
+		m := &mappings[i]
+		if m.code == "" {
+			continue
+		}
+
+		if last >= 0 && m.plan.Destination != mappings[last].plan.Destination {
+			m.code = "\n" + m.code
+		}
+
+		last = i
+	}
+
+	return mappings, true
+}
+
+// destinationMappings maps the fields of every destination of pair, reading
+// the source fields of reads from their variables.
+func (g *generator) destinationMappings(pair *conversionPair, fromStruct *types.Struct, reads map[string]string) []fieldMapping {
+	var mappings []fieldMapping
+
+	for _, d := range pair.destinations {
+		toStruct, _ := derefType(d.info.typ).Underlying().(*types.Struct)
+		sub := destinationPair(pair, d)
+		vars := mappingVars{src: "src", dst: d.name, hookArgs: "src, " + d.name, reads: reads}
+
+		for _, m := range g.buildFieldMappings(&sub, vars, fromStruct, toStruct) {
+			m.plan.Destination = qualifiedTypeName(d.info)
+			mappings = append(mappings, m)
+		}
+	}
+
+	return mappings
+}
+
+// sharedReads finds the source fields the mappings of several destinations
+// of pair read, which are read once into variables declared ahead of them. It
+// returns the fields to the variables, and the declarations of the variables.
+// It builds the mappings without reporting diagnostics.
+func (g *generator) sharedReads(pair *conversionPair, fromStruct *types.Struct) (map[string]string, []string) {
+	diagnostics := len(g.result.Diagnostics)
+	defer func() { g.result.Diagnostics = g.result.Diagnostics[:diagnostics] }()
+
+	var (
+		names   []string
+		readers = make(map[string]map[string]bool)
+	)
+
+	for _, m := range g.destinationMappings(pair, fromStruct, nil) {
+		// Hooks read the source themselves.
+		if m.plan.Src == "" || m.code == "" || m.plan.Kind == MappingCustom {
+			continue
+		}
+
+		if readers[m.plan.Src] == nil {
+			readers[m.plan.Src] = make(map[string]bool)
+			names = append(names, m.plan.Src)
+		}
+
+		readers[m.plan.Src][m.plan.Destination] = true
+	}
+
+	// Variables must not shadow the parameters and packages of the generated code.
+	taken := make(map[string]bool)
+	for path := range g.imports {
+		taken[path[strings.LastIndex(path, "/")+1:]] = true
+	}
+
+	for _, d := range pair.destinations {
+		taken[d.name] = true
+	}
+
+	var (
+		reads map[string]string
+		decls []string
+	)
+
+	for _, name := range names {
+		value := g.fieldRead(pair, fromStruct, name)
+		if len(readers[name]) < 2 || value == "" {
+			continue
+		}
+
+		if reads == nil {
+			reads = make(map[string]string)
+		}
+
+		reads[name] = freeLocal(lowerFirst(name), taken)
+		decls = append(decls, fmt.Sprintf("%s := %s", reads[name], value))
+	}
+
+	return reads, decls
+}
+
+// fieldRead returns the expression reading the source field name of pair, or
+// "" if it is neither a field nor a getter of the source.
+func (g *generator) fieldRead(pair *conversionPair, fromStruct *types.Struct, name string) string {
+	if f := findField(fromStruct, name); f != nil {
+		return g.sourceRead(pair, pairVars, f)
+	}
+
+	if getter, ok, _ := getterFor(pair, name); ok {
+		return "src." + getter.method + "()"
+	}
+
+	return ""
+}
+
+// checkUnmapped warns about the exported source fields of a split conversion
+// that none of its destinations reads, given the plans of its fields, and
+// returns their names.
+func (g *generator) checkUnmapped(pair *conversionPair, fields []FieldPlan) []string {
+	fromStruct, ok := derefType(pair.from.typ).Underlying().(*types.Struct)
+	if !ok {
+		return nil
+	}
+
+	mapped := make(map[string]bool)
+	for _, f := range fields {
+		mapped[f.Src] = true
+	}
+
+	var unmapped []string
+
+	for i := 0; i < fromStruct.NumFields(); i++ {
+		field := fromStruct.Field(i)
+		if !field.Exported() || mapped[field.Name()] {
+			continue
+		}
+
+		g.warnf(pair.pos, "unmapped field: %s of %s lands in none of %s",
+			field.Name(), typeString(derefType(pair.from.typ)), g.paramsDoc(pair.destinations))
+
+		unmapped = append(unmapped, field.Name())
+	}
+
+	return unmapped
+}
@@ -0,0 +1,55 @@
+package gonverter
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLowerFirst(t *testing.T) {
+	tests := map[string]string{
+		"":           "",
+		"User":       "user",
+		"ID":         "id",
+		"URLPath":    "urlPath",
+		"CustomerID": "customerID",
+		"name":       "name",
+	}
+
+	for in, want := range tests {
+		if got := lowerFirst(in); got != want {
+			t.Errorf("lowerFirst(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestGenerateSplitPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/split"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 1 || !strings.Contains(res.Diagnostics[0].Message, "unmapped field: Note") {
+		t.Fatalf("Diagnostics = %v, want a warning about Note", res.Diagnostics)
+	}
+
+	const pkg = "github.com/sivchari/gonverter/testdata/split."
+
+	p := res.Plan[0]
+	if p.Func != "ConvertOrderToOrderRowAndOrderItems" || p.To != "" || !slices.Equal(p.Destinations, []string{pkg + "OrderRow", pkg + "OrderItems"}) {
+		t.Fatalf("plan = %+v, want the conversion to OrderRow and OrderItems", p)
+	}
+
+	if !slices.Equal(p.Unmapped, []string{"Note"}) || !p.Fallible || len(p.Fields) != 6 {
+		t.Errorf("plan = %+v, want 6 fallible fields without Note", p)
+	}
+
+	if f := p.Fields[3]; f.Dst != "ItemCount" || f.Destination != pkg+"OrderRow" || f.Kind != MappingCustom {
+		t.Errorf("Fields[3] = %+v, want the ItemCount hook of OrderRow", f)
+	}
+
+	if f := p.Fields[4]; f.Src != "ID" || f.Destination != pkg+"OrderItems" {
+		t.Errorf("Fields[4] = %+v, want ID of OrderItems", f)
+	}
+}
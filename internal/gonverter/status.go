@@ -0,0 +1,190 @@
+package gonverter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// FileState is the state of a generated file relative to its inputs.
+type FileState string
+
+// File states.
+const (
+	// StateUpToDate means the file matches its inputs and has not been edited.
+	StateUpToDate FileState = "up-to-date"
+	// StateStale means the inputs or the generator version changed since the file was generated.
+	StateStale FileState = "stale"
+	// StateModified means the file was edited by hand after generation.
+	StateModified FileState = "modified"
+	// StateMissing means the package has registrations but no generated file.
+	StateMissing FileState = "missing"
+)
+
+// PackageStatus is the state of the generated file of one package.
+type PackageStatus struct {
+	PkgPath string    `json:"pkgPath"`
+	Path    string    `json:"path"`
+	State   FileState `json:"state"`
+	Reason  string    `json:"reason,omitempty"`
+}
+
+// StatusResult holds the outcome of a status check.
+type StatusResult struct {
+	// Packages lists every matched package that contains registrations.
+	Packages []PackageStatus
+	// Diagnostics are the problems found while loading the packages.
+	Diagnostics []Diagnostic
+}
+
+// UpToDate reports whether every package is up to date.
+func (r *StatusResult) UpToDate() bool {
+	for _, p := range r.Packages {
+		if p.State != StateUpToDate {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Status compares the fingerprint recorded in each generated file with the
+// current inputs of its package. Nothing is generated, so this is cheap enough
+// to run from a pre-commit hook. With opts.CacheDir, packages whose sources are
+// unchanged since they were last generated are checked against the cached
+// output, from a listing of their files; only the others are type-checked.
+func Status(ctx context.Context, opts Options) (*StatusResult, error) {
+	if err := opts.setDefaults(); err != nil {
+		return nil, err
+	}
+
+	g := &generator{
+		fset:   token.NewFileSet(),
+		logger: opts.Logger,
+	}
+
+	var (
+		order    []string
+		statuses = make(map[string]PackageStatus)
+		patterns = opts.Patterns
+	)
+
+	if opts.CacheDir != "" {
+		c := &cache{dir: opts.CacheDir}
+
+		keys, err := packageKeys(ctx, &opts)
+		if err != nil {
+			return nil, err
+		}
+
+		patterns = nil
+
+		for _, k := range keys {
+			order = append(order, k.pkgPath)
+
+			if k.key == "" {
+				patterns = append(patterns, k.pkgPath)
+
+				continue
+			}
+
+			cached, ok := c.get(k.key)
+			if !ok {
+				patterns = append(patterns, k.pkgPath)
+
+				continue
+			}
+
+			// Packages without registrations have no generated file.
+			for _, f := range cached.Files {
+				current, _, _ := readHeader(f.Content)
+
+				st, err := fileStatus(f.PkgPath, f.Path, current)
+				if err != nil {
+					return nil, err
+				}
+
+				statuses[k.pkgPath] = st
+			}
+		}
+	}
+
+	res := &StatusResult{}
+
+	if len(patterns) > 0 {
+		pkgs, err := g.load(ctx, &opts, patterns)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range pkgs {
+			g.result = &Result{}
+
+			in, ok := g.parse(pkg, &opts)
+			res.Diagnostics = append(res.Diagnostics, g.result.Diagnostics...)
+
+			if !ok || len(in.pairs) == 0 && len(in.defaults) == 0 {
+				continue
+			}
+
+			st, err := fileStatus(in.pkgPath, filepath.Join(in.dir, opts.OutputName), g.fingerprint(pkg, &in, &opts))
+			if err != nil {
+				return nil, err
+			}
+
+			if opts.CacheDir == "" {
+				order = append(order, in.pkgPath)
+			}
+
+			statuses[in.pkgPath] = st
+		}
+	}
+
+	for _, pkgPath := range order {
+		if st, ok := statuses[pkgPath]; ok {
+			res.Packages = append(res.Packages, st)
+		}
+	}
+
+	return res, nil
+}
+
+// fileStatus compares the fingerprint recorded in the generated file at path
+// with current, the fingerprint of the inputs of its package.
+func fileStatus(pkgPath, path string, current fingerprint) (PackageStatus, error) {
+	st := PackageStatus{PkgPath: pkgPath, Path: path}
+
+	src, err := os.ReadFile(st.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		st.State = StateMissing
+
+		return st, nil
+	}
+
+	if err != nil {
+		return st, fmt.Errorf("failed to read generated file: %w", err)
+	}
+
+	recorded, sum, ok := readHeader(src)
+
+	switch {
+	case !ok:
+		st.State, st.Reason = StateStale, "no gonverter fingerprint header"
+	case sum != checksum(src):
+		st.State, st.Reason = StateModified, "edited after generation"
+	case recorded.version != current.version:
+		st.State, st.Reason = StateStale, fmt.Sprintf("generated by gonverter %s, current is %s", recorded.version, current.version)
+	case recorded.registrations != current.registrations:
+		st.State, st.Reason = StateStale, "registration files changed"
+	case recorded.inputs != current.inputs:
+		st.State, st.Reason = StateStale, "types or hooks changed"
+	default:
+		st.State = StateUpToDate
+	}
+
+	return st, nil
+}
@@ -0,0 +1,148 @@
+package gonverter
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+)
+
+// statusOutputName keeps the status fixtures out of the Go build.
+const statusOutputName = "status_output.txt"
+
+func writeStatusFixture(t *testing.T, dir string) string {
+	t.Helper()
+
+	opts := Options{Patterns: []string{dir}, OutputName: statusOutputName}
+
+	res, err := Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if err := res.WriteFiles(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := res.Files[0].Path
+	t.Cleanup(func() { _ = os.Remove(path) })
+
+	return path
+}
+
+func statusOf(t *testing.T, dir string) PackageStatus {
+	t.Helper()
+
+	res, err := Status(context.Background(), Options{Patterns: []string{dir}, OutputName: statusOutputName})
+	if err != nil {
+		t.Fatalf("Status() error = %v", err)
+	}
+
+	if len(res.Packages) != 1 {
+		t.Fatalf("len(Packages) = %d, want 1", len(res.Packages))
+	}
+
+	return res.Packages[0]
+}
+
+func TestStatusUpToDate(t *testing.T) {
+	writeStatusFixture(t, "../../testdata/nested")
+
+	if st := statusOf(t, "../../testdata/nested"); st.State != StateUpToDate {
+		t.Errorf("State = %q (%s), want %q", st.State, st.Reason, StateUpToDate)
+	}
+}
+
+func TestStatusModified(t *testing.T) {
+	path := writeStatusFixture(t, "../../testdata/nested")
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, append(src, "// hand edit\n"...), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if st := statusOf(t, "../../testdata/nested"); st.State != StateModified {
+		t.Errorf("State = %q (%s), want %q", st.State, st.Reason, StateModified)
+	}
+}
+
+func TestStatusStaleVersion(t *testing.T) {
+	path := writeStatusFixture(t, "../../testdata/nested")
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Rewrite the header as if an older gonverter had produced the file, keeping the checksum consistent.
+	_, oldSum, _ := readHeader(src)
+	src = bytes.Replace(src, []byte(headerVersion+Version), []byte(headerVersion+"0.0.0-old"), 1)
+	src = bytes.Replace(src, []byte(oldSum), []byte(checksum(src)), 1)
+
+	if err := os.WriteFile(path, src, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if st := statusOf(t, "../../testdata/nested"); st.State != StateStale {
+		t.Errorf("State = %q (%s), want %q", st.State, st.Reason, StateStale)
+	}
+}
+
+func TestStatusMissing(t *testing.T) {
+	if st := statusOf(t, "../../testdata/nested"); st.State != StateMissing {
+		t.Errorf("State = %q, want %q", st.State, StateMissing)
+	}
+}
+
+func TestStatusFromCache(t *testing.T) {
+	opts := Options{Patterns: []string{"../../testdata/nested"}, OutputName: statusOutputName, CacheDir: t.TempDir()}
+
+	res, err := Generate(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if err := res.WriteFiles(); err != nil {
+		t.Fatal(err)
+	}
+
+	path := res.Files[0].Path
+	t.Cleanup(func() { _ = os.Remove(path) })
+
+	defaulted := opts
+	if err := defaulted.setDefaults(); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := packageKeys(context.Background(), &defaulted)
+	if err != nil || len(keys) != 1 {
+		t.Fatalf("packageKeys() = %v, %v", keys, err)
+	}
+
+	c := &cache{dir: opts.CacheDir}
+
+	cached, ok := c.get(keys[0].key)
+	if !ok {
+		t.Fatal("Generate() did not cache its result")
+	}
+
+	st, err := Status(context.Background(), opts)
+	if err != nil || len(st.Packages) != 1 || st.Packages[0].State != StateUpToDate {
+		t.Fatalf("Status() = %+v, %v, want the package up to date", st, err)
+	}
+
+	// Tamper with the cached output: a status served from the cache must report it.
+	cached.Files[0].Content = bytes.Replace(cached.Files[0].Content, []byte(headerInputs), []byte(headerInputs+"x"), 1)
+	if err := c.put(keys[0].key, cached); err != nil {
+		t.Fatal(err)
+	}
+
+	st, err = Status(context.Background(), opts)
+	if err != nil || len(st.Packages) != 1 || st.Packages[0].State != StateStale {
+		t.Fatalf("Status() = %+v, %v, want the package stale against the cached output", st, err)
+	}
+}
@@ -0,0 +1,266 @@
+package gonverter
+
+import (
+	"fmt"
+	"go/types"
+)
+
+const validateMethod = "Validate"
+
+// validatorData describes the function validating a destination struct and the
+// values nested in it, for conversions registered with runtime.WithValidation.
+type validatorData struct {
+	Name   string
+	Type   string
+	Check  bool     // the struct has a Validate method itself
+	Fields []string // statements validating the values nested in its fields
+}
+
+// newValidation returns the function validating the destination of pair, a
+// registration with runtime.WithValidation, or an empty string if there is
+// nothing to validate.
+func (g *generator) newValidation(pair *conversionPair) string {
+	if !pair.to.isPointer {
+		g.errorf(pair.pos, "validation requires a pointer destination")
+
+		return ""
+	}
+
+	fn := g.validatorFor(pair, derefType(pair.to.typ))
+	if fn == "" {
+		g.warnf(pair.pos, "WithValidation has no effect: neither %s nor the values nested in it have a Validate method", typeString(derefType(pair.to.typ)))
+	}
+
+	return fn
+}
+
+// validatesDst reports whether the function generated for pair validates dst.
+func (g *generator) validatesDst(pair *conversionPair) bool {
+	named, ok := derefType(pair.to.typ).(*types.Named)
+
+	return pair.opts.validate && pair.to.isPointer && ok && g.needsValidation(named)
+}
+
+// validatorFor returns the function validating values of t, the destination of
+// pair, generating it and the functions of the types nested in t on first use.
+// It returns an empty string if neither t nor a value nested in it has a
+// Validate method.
+func (g *generator) validatorFor(pair *conversionPair, t types.Type) string {
+	named, ok := t.(*types.Named)
+	if !ok || !g.needsValidation(named) {
+		return ""
+	}
+
+	st, ok := named.Underlying().(*types.Struct)
+	if !ok {
+		return ""
+	}
+
+	name := "validate" + g.funcTypeName(pair, extractTypeInfo(named))
+	if g.validators[name] {
+		return name
+	}
+
+	g.validators[name] = true
+	v := validatorData{
+		Name:  name,
+		Type:  g.typeExpr(named),
+		Check: hasValidateMethod(named),
+	}
+
+	// Reserve the position of v before generating the functions it calls.
+	idx := len(g.validatorFuncs)
+	g.validatorFuncs = append(g.validatorFuncs, v)
+
+	for i := 0; i < st.NumFields(); i++ {
+		if f := st.Field(i); f.Exported() {
+			if stmt := g.validateFieldStmt(pair, f); stmt != "" {
+				v.Fields = append(v.Fields, stmt)
+			}
+		}
+	}
+
+	g.validatorFuncs[idx] = v
+
+	return name
+}
+
+// validateFieldStmt returns the statement validating the values held by field f,
+// or an empty string if they have nothing to validate.
+func (g *generator) validateFieldStmt(pair *conversionPair, f *types.Var) string {
+	elem := nestedNamed(f.Type())
+	if elem == nil || !g.needsValidation(elem) {
+		return ""
+	}
+
+	fn := g.validatorFor(pair, elem)
+	path := fmt.Sprintf("runtime.FieldPath(path, %q)", f.Name())
+
+	return nestedValuesStmt("dst", f, false, func(ptr, key string) string {
+		elemPath := path
+
+		switch key {
+		case "i":
+			elemPath = fmt.Sprintf("runtime.IndexPath(%s, i)", path)
+		case "k":
+			elemPath = fmt.Sprintf("runtime.KeyPath(%s, k)", path)
+		}
+
+		if fn == "" {
+			return fmt.Sprintf("v.Check(%s, %s)", elemPath, ptr)
+		}
+
+		return fmt.Sprintf("%s(v, %s, %s)", fn, elemPath, ptr)
+	})
+}
+
+// nestedValuesStmt returns a statement applying call to the values that field f
+// of recv holds: the field itself, the value it points to, or each element of a
+// slice or map of those. call receives a pointer to the value and the index
+// variable, "i" for slices and "k" for maps, or an empty string. If update is
+// set, map elements held by value are stored back after the call.
+func nestedValuesStmt(recv string, f *types.Var, update bool, call func(ptr, key string) string) string {
+	field := recv + "." + f.Name()
+
+	switch t := f.Type().Underlying().(type) {
+	case *types.Slice:
+		if _, ok := t.Elem().(*types.Pointer); ok {
+			return fmt.Sprintf(`for i, elem := range %s {
+		if elem != nil {
+			%s
+		}
+	}`, field, call("elem", "i"))
+		}
+
+		return fmt.Sprintf(`for i := range %s {
+		%s
+	}`, field, call(fmt.Sprintf("&%s[i]", field), "i"))
+	case *types.Map:
+		if _, ok := t.Elem().(*types.Pointer); ok {
+			return fmt.Sprintf(`for k, elem := range %s {
+		if elem != nil {
+			%s
+		}
+	}`, field, call("elem", "k"))
+		}
+
+		stmt := call("&elem", "k")
+		if update {
+			stmt += fmt.Sprintf("\n%s[k] = elem", field)
+		}
+
+		return fmt.Sprintf(`for k, elem := range %s {
+		%s
+	}`, field, stmt)
+	case *types.Pointer:
+		return fmt.Sprintf(`if %s != nil {
+		%s
+	}`, field, call(field, ""))
+	default:
+		return call("&"+field, "")
+	}
+}
+
+// needsValidation reports whether values of t, or values nested in them, have
+// a Validate method.
+func (g *generator) needsValidation(t *types.Named) bool {
+	return reachesAny(t, g.validates, hasValidateMethod)
+}
+
+// reachesAny reports whether own holds for t or for a type of the values
+// nested in t, recording the answers in memo. Types may be recursive, so the
+// answer is found for all types reachable from t at once.
+func reachesAny(t *types.Named, memo map[*types.Named]bool, own func(*types.Named) bool) bool {
+	if needs, ok := memo[t]; ok {
+		return needs
+	}
+
+	var (
+		reachable = []*types.Named{t}
+		children  = make(map[*types.Named][]*types.Named)
+		seen      = map[*types.Named]bool{t: true}
+	)
+
+	for i := 0; i < len(reachable); i++ {
+		st, ok := reachable[i].Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		for j := 0; j < st.NumFields(); j++ {
+			f := st.Field(j)
+			if !f.Exported() {
+				continue
+			}
+
+			if elem := nestedNamed(f.Type()); elem != nil {
+				children[reachable[i]] = append(children[reachable[i]], elem)
+
+				if !seen[elem] {
+					seen[elem] = true
+					reachable = append(reachable, elem)
+				}
+			}
+		}
+	}
+
+	needs := make(map[*types.Named]bool, len(reachable))
+	for _, r := range reachable {
+		needs[r] = own(r)
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		for _, r := range reachable {
+			for _, c := range children[r] {
+				if !needs[r] && needs[c] {
+					needs[r], changed = true, true
+				}
+			}
+		}
+	}
+
+	for _, r := range reachable {
+		if _, ok := memo[r]; !ok {
+			memo[r] = needs[r]
+		}
+	}
+
+	return needs[t]
+}
+
+// nestedNamed returns the named type of the values a field of type t holds:
+// t itself, the type t points to, or the elements of a slice or map of those,
+// including named slice and map types such as type Items []Item. It returns
+// nil for values of other types.
+func nestedNamed(t types.Type) *types.Named {
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		t = u.Elem()
+	case *types.Map:
+		t = u.Elem()
+	}
+
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+
+	named, _ := t.(*types.Named)
+
+	return named
+}
+
+// hasValidateMethod reports whether *t implements runtime.Validator.
+func hasValidateMethod(t *types.Named) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), false, t.Obj().Pkg(), validateMethod)
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+
+	sig, ok := fn.Type().(*types.Signature)
+
+	return ok && sig.Params().Len() == 0 && sig.Results().Len() == 1 && isErrorType(sig.Results().At(0).Type())
+}
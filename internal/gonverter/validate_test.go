@@ -0,0 +1,62 @@
+package gonverter
+
+import (
+	"context"
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func TestNeedsValidation(t *testing.T) {
+	pkg := types.NewPackage("example.com/p", "p")
+	newNamed := func(name string) *types.Named {
+		return types.NewNamed(types.NewTypeName(token.NoPos, pkg, name, nil), nil, nil)
+	}
+	field := func(name string, typ types.Type) *types.Var {
+		return types.NewField(token.NoPos, pkg, name, typ, false)
+	}
+
+	// A refers to B, which refers back to A, and to C, which has a Validate method. E
+	// holds C in a named slice type.
+	a, b, c, d, e, cs := newNamed("A"), newNamed("B"), newNamed("C"), newNamed("D"), newNamed("E"), newNamed("Cs")
+	a.SetUnderlying(types.NewStruct([]*types.Var{field("B", b), field("C", types.NewSlice(c))}, nil))
+	b.SetUnderlying(types.NewStruct([]*types.Var{field("A", types.NewPointer(a))}, nil))
+	c.SetUnderlying(types.NewStruct(nil, nil))
+	d.SetUnderlying(types.NewStruct([]*types.Var{field("Children", types.NewSlice(d))}, nil))
+	cs.SetUnderlying(types.NewSlice(c))
+	e.SetUnderlying(types.NewStruct([]*types.Var{field("Cs", cs)}, nil))
+
+	errType := types.Universe.Lookup("error").Type()
+	sig := types.NewSignatureType(types.NewVar(token.NoPos, pkg, "c", c), nil, nil, nil,
+		types.NewTuple(types.NewVar(token.NoPos, pkg, "", errType)), false)
+	c.AddMethod(types.NewFunc(token.NoPos, pkg, "Validate", sig))
+
+	// B is checked first, while A is still being walked.
+	g := &generator{validates: make(map[*types.Named]bool)}
+	for _, tt := range []struct {
+		typ  *types.Named
+		want bool
+	}{{b, true}, {a, true}, {c, true}, {d, false}, {e, true}} {
+		if got := g.needsValidation(tt.typ); got != tt.want {
+			t.Errorf("needsValidation(%s) = %v, want %v", tt.typ, got, tt.want)
+		}
+	}
+}
+
+func TestGenerateValidationPlan(t *testing.T) {
+	res, err := Generate(context.Background(), Options{Patterns: []string{"../../testdata/validation"}})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	if len(res.Diagnostics) != 0 {
+		t.Fatalf("Diagnostics = %v, want none", res.Diagnostics)
+	}
+
+	for _, p := range res.Plan {
+		want := p.Func == "ConvertOrderRequestToOrder"
+		if p.Validated != want || p.Fallible != want {
+			t.Errorf("plan of %s: Validated = %v, Fallible = %v, want %v", p.Func, p.Validated, p.Fallible, want)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+package gonverter
+
+// Version is the current version of gonverter.
+const Version = "0.1.0"
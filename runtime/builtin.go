@@ -0,0 +1,306 @@
+package runtime
+
+import (
+	"database/sql"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// Builtin is a category of built-in converters, enabled with [WithBuiltins].
+// Generated code calls the converter functions of this file for fields whose
+// types they convert.
+type Builtin int
+
+// Categories of built-in converters.
+const (
+	// BuiltinTime converts time.Time to and from string in RFC 3339 format and
+	// int64 in Unix seconds, and time.Duration to and from int64 in
+	// nanoseconds and string in the format of time.ParseDuration.
+	BuiltinTime Builtin = iota + 1
+	// BuiltinPointers converts pointers to the values they point to and back,
+	// e.g. *string to string and *int32 to int64. nil becomes the zero value,
+	// or an error with [WithNilAsError].
+	BuiltinPointers
+	// BuiltinSQL converts the sql.Null types to and from pointers, e.g.
+	// sql.NullString to *string. Invalid values become nil.
+	BuiltinSQL
+	// BuiltinBytes converts []byte to and from string.
+	BuiltinBytes
+	// BuiltinNet converts url.URL, *url.URL and netip.Addr to and from string.
+	BuiltinNet
+)
+
+// TimeToRFC3339 formats src in RFC 3339 format with nanoseconds if non-zero.
+// The zero time becomes an empty string.
+func TimeToRFC3339(src time.Time, dst *string) {
+	if src.IsZero() {
+		*dst = ""
+
+		return
+	}
+
+	*dst = src.Format(time.RFC3339Nano)
+}
+
+// RFC3339ToTime parses src in RFC 3339 format. An empty string becomes the zero time.
+func RFC3339ToTime(src string, dst *time.Time) error {
+	if src == "" {
+		*dst = time.Time{}
+
+		return nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, src)
+	if err != nil {
+		return err
+	}
+
+	*dst = t
+
+	return nil
+}
+
+// TimeToUnix converts src to Unix seconds. The zero time becomes 0.
+func TimeToUnix(src time.Time, dst *int64) {
+	if src.IsZero() {
+		*dst = 0
+
+		return
+	}
+
+	*dst = src.Unix()
+}
+
+// UnixToTime converts src in Unix seconds to a time in UTC. 0 becomes the zero time.
+func UnixToTime(src int64, dst *time.Time) {
+	if src == 0 {
+		*dst = time.Time{}
+
+		return
+	}
+
+	*dst = time.Unix(src, 0).UTC()
+}
+
+// DurationToInt64 converts src to nanoseconds.
+func DurationToInt64(src time.Duration, dst *int64) {
+	*dst = int64(src)
+}
+
+// Int64ToDuration converts src in nanoseconds to a duration.
+func Int64ToDuration(src int64, dst *time.Duration) {
+	*dst = time.Duration(src)
+}
+
+// DurationToString formats src like "1h30m0s".
+func DurationToString(src time.Duration, dst *string) {
+	*dst = src.String()
+}
+
+// StringToDuration parses src with time.ParseDuration. An empty string becomes 0.
+func StringToDuration(src string, dst *time.Duration) error {
+	if src == "" {
+		*dst = 0
+
+		return nil
+	}
+
+	d, err := time.ParseDuration(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = d
+
+	return nil
+}
+
+// Deref sets dst to the value src points to, or to the zero value if src is nil.
+func Deref[T any](src *T, dst *T) {
+	if src == nil {
+		var zero T
+
+		*dst = zero
+
+		return
+	}
+
+	*dst = *src
+}
+
+// Ref sets dst to a pointer to a copy of src.
+func Ref[T any](src T, dst **T) {
+	*dst = &src
+}
+
+// NullStringToPointer converts src to a pointer, or nil if src is not valid.
+func NullStringToPointer(src sql.NullString, dst **string) {
+	nullToPointer(src.String, src.Valid, dst)
+}
+
+// PointerToNullString converts src to a sql.NullString, which is not valid if src is nil.
+func PointerToNullString(src *string, dst *sql.NullString) {
+	dst.String, dst.Valid = pointerToNull(src)
+}
+
+// NullInt64ToPointer converts src to a pointer, or nil if src is not valid.
+func NullInt64ToPointer(src sql.NullInt64, dst **int64) {
+	nullToPointer(src.Int64, src.Valid, dst)
+}
+
+// PointerToNullInt64 converts src to a sql.NullInt64, which is not valid if src is nil.
+func PointerToNullInt64(src *int64, dst *sql.NullInt64) {
+	dst.Int64, dst.Valid = pointerToNull(src)
+}
+
+// NullInt32ToPointer converts src to a pointer, or nil if src is not valid.
+func NullInt32ToPointer(src sql.NullInt32, dst **int32) {
+	nullToPointer(src.Int32, src.Valid, dst)
+}
+
+// PointerToNullInt32 converts src to a sql.NullInt32, which is not valid if src is nil.
+func PointerToNullInt32(src *int32, dst *sql.NullInt32) {
+	dst.Int32, dst.Valid = pointerToNull(src)
+}
+
+// NullFloat64ToPointer converts src to a pointer, or nil if src is not valid.
+func NullFloat64ToPointer(src sql.NullFloat64, dst **float64) {
+	nullToPointer(src.Float64, src.Valid, dst)
+}
+
+// PointerToNullFloat64 converts src to a sql.NullFloat64, which is not valid if src is nil.
+func PointerToNullFloat64(src *float64, dst *sql.NullFloat64) {
+	dst.Float64, dst.Valid = pointerToNull(src)
+}
+
+// NullBoolToPointer converts src to a pointer, or nil if src is not valid.
+func NullBoolToPointer(src sql.NullBool, dst **bool) {
+	nullToPointer(src.Bool, src.Valid, dst)
+}
+
+// PointerToNullBool converts src to a sql.NullBool, which is not valid if src is nil.
+func PointerToNullBool(src *bool, dst *sql.NullBool) {
+	dst.Bool, dst.Valid = pointerToNull(src)
+}
+
+// NullTimeToPointer converts src to a pointer, or nil if src is not valid.
+func NullTimeToPointer(src sql.NullTime, dst **time.Time) {
+	nullToPointer(src.Time, src.Valid, dst)
+}
+
+// PointerToNullTime converts src to a sql.NullTime, which is not valid if src is nil.
+func PointerToNullTime(src *time.Time, dst *sql.NullTime) {
+	dst.Time, dst.Valid = pointerToNull(src)
+}
+
+func nullToPointer[T any](v T, valid bool, dst **T) {
+	if !valid {
+		*dst = nil
+
+		return
+	}
+
+	*dst = &v
+}
+
+func pointerToNull[T any](src *T) (T, bool) {
+	if src == nil {
+		var zero T
+
+		return zero, false
+	}
+
+	return *src, true
+}
+
+// BytesToString converts src to a string.
+func BytesToString(src []byte, dst *string) {
+	*dst = string(src)
+}
+
+// StringToBytes converts src to a byte slice. An empty string becomes nil.
+func StringToBytes(src string, dst *[]byte) {
+	if src == "" {
+		*dst = nil
+
+		return
+	}
+
+	*dst = []byte(src)
+}
+
+// URLToString formats src with url.URL.String.
+func URLToString(src url.URL, dst *string) {
+	*dst = src.String()
+}
+
+// StringToURL parses src with url.Parse. An empty string becomes the zero URL.
+func StringToURL(src string, dst *url.URL) error {
+	u, err := url.Parse(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = *u
+
+	return nil
+}
+
+// URLPointerToString formats src with url.URL.String. nil becomes an empty string.
+func URLPointerToString(src *url.URL, dst *string) {
+	if src == nil {
+		*dst = ""
+
+		return
+	}
+
+	*dst = src.String()
+}
+
+// StringToURLPointer parses src with url.Parse. An empty string becomes nil.
+func StringToURLPointer(src string, dst **url.URL) error {
+	if src == "" {
+		*dst = nil
+
+		return nil
+	}
+
+	u, err := url.Parse(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = u
+
+	return nil
+}
+
+// AddrToString formats src with netip.Addr.String. The zero Addr becomes an empty string.
+func AddrToString(src netip.Addr, dst *string) {
+	if !src.IsValid() {
+		*dst = ""
+
+		return
+	}
+
+	*dst = src.String()
+}
+
+// StringToAddr parses src with netip.ParseAddr. An empty string becomes the zero Addr.
+func StringToAddr(src string, dst *netip.Addr) error {
+	if src == "" {
+		*dst = netip.Addr{}
+
+		return nil
+	}
+
+	addr, err := netip.ParseAddr(src)
+	if err != nil {
+		return err
+	}
+
+	*dst = addr
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package runtime
+
+import (
+	"database/sql"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestTimeRFC3339(t *testing.T) {
+	want := time.Date(2024, 5, 1, 12, 30, 0, 500, time.UTC)
+
+	var s string
+
+	TimeToRFC3339(want, &s)
+
+	if s != "2024-05-01T12:30:00.0000005Z" {
+		t.Fatalf("TimeToRFC3339() = %q", s)
+	}
+
+	var got time.Time
+	if err := RFC3339ToTime(s, &got); err != nil || !got.Equal(want) {
+		t.Errorf("RFC3339ToTime(%q) = %v, %v, want %v", s, got, err, want)
+	}
+
+	TimeToRFC3339(time.Time{}, &s)
+
+	if s != "" {
+		t.Errorf("TimeToRFC3339(zero) = %q, want empty", s)
+	}
+
+	if err := RFC3339ToTime("", &got); err != nil || !got.IsZero() {
+		t.Errorf("RFC3339ToTime(\"\") = %v, %v, want the zero time", got, err)
+	}
+
+	if err := RFC3339ToTime("yesterday", &got); err == nil {
+		t.Error("RFC3339ToTime(\"yesterday\") error = nil, want an error")
+	}
+}
+
+func TestTimeUnix(t *testing.T) {
+	var n int64
+
+	TimeToUnix(time.Time{}, &n)
+
+	if n != 0 {
+		t.Errorf("TimeToUnix(zero) = %d, want 0", n)
+	}
+
+	var got time.Time
+
+	UnixToTime(1700000000, &got)
+
+	if got.Location() != time.UTC || got.Unix() != 1700000000 {
+		t.Errorf("UnixToTime(1700000000) = %v", got)
+	}
+
+	UnixToTime(0, &got)
+
+	if !got.IsZero() {
+		t.Errorf("UnixToTime(0) = %v, want the zero time", got)
+	}
+}
+
+func TestStringToDuration(t *testing.T) {
+	var d time.Duration
+	if err := StringToDuration("1m30s", &d); err != nil || d != 90*time.Second {
+		t.Errorf("StringToDuration(\"1m30s\") = %v, %v", d, err)
+	}
+
+	if err := StringToDuration("soon", &d); err == nil {
+		t.Error("StringToDuration(\"soon\") error = nil, want an error")
+	}
+}
+
+func TestDerefAndRef(t *testing.T) {
+	s := "x"
+
+	Deref(&s, &s)
+	Deref(nil, &s)
+
+	if s != "" {
+		t.Errorf("Deref(nil) = %q, want empty", s)
+	}
+
+	var p *int
+
+	Ref(3, &p)
+
+	if p == nil || *p != 3 {
+		t.Errorf("Ref(3) = %v", p)
+	}
+}
+
+func TestNullConversions(t *testing.T) {
+	var p *string
+
+	NullStringToPointer(sql.NullString{String: "a", Valid: true}, &p)
+
+	if p == nil || *p != "a" {
+		t.Errorf("NullStringToPointer(valid) = %v", p)
+	}
+
+	NullStringToPointer(sql.NullString{String: "a"}, &p)
+
+	if p != nil {
+		t.Errorf("NullStringToPointer(invalid) = %v, want nil", p)
+	}
+
+	var n sql.NullInt64
+
+	PointerToNullInt64(nil, &n)
+
+	if n.Valid {
+		t.Errorf("PointerToNullInt64(nil) = %+v, want invalid", n)
+	}
+}
+
+func TestStringToAddr(t *testing.T) {
+	var addr netip.Addr
+	if err := StringToAddr("192.0.2.1", &addr); err != nil || addr != netip.MustParseAddr("192.0.2.1") {
+		t.Errorf("StringToAddr() = %v, %v", addr, err)
+	}
+
+	var s string
+
+	AddrToString(netip.Addr{}, &s)
+
+	if s != "" {
+		t.Errorf("AddrToString(zero) = %q, want empty", s)
+	}
+}
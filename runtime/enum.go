@@ -0,0 +1,16 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownEnumValue is returned by enum conversions for values that have no
+// equivalent in the destination type.
+var ErrUnknownEnumValue = errors.New("unknown enum value")
+
+// UnknownEnumValue returns an error wrapping [ErrUnknownEnumValue] for value,
+// which has no equivalent in the enum type named to. Generated code calls it.
+func UnknownEnumValue(value any, to string) error {
+	return fmt.Errorf("%w: %v has no %s equivalent", ErrUnknownEnumValue, value, to)
+}
@@ -0,0 +1,18 @@
+package runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestUnknownEnumValue(t *testing.T) {
+	err := UnknownEnumValue(7, "domain.Status")
+
+	if !errors.Is(err, ErrUnknownEnumValue) {
+		t.Errorf("errors.Is(%v, ErrUnknownEnumValue) = false, want true", err)
+	}
+
+	if want := "unknown enum value: 7 has no domain.Status equivalent"; err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+}
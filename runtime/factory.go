@@ -0,0 +1,7 @@
+package runtime
+
+import "errors"
+
+// ErrNilFactoryResult is returned by conversions generated with [WithFactory] when a
+// factory returning a pointer returns nil without an error.
+var ErrNilFactoryResult = errors.New("factory returned nil")
@@ -0,0 +1,85 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnknownFieldPath is returned when a field mask path does not name a field.
+var ErrUnknownFieldPath = errors.New("unknown field path")
+
+// FieldMask selects fields of a destination type by dotted paths such as
+// "Address.City", like a protobuf FieldMask. A path segment matches a field
+// name ignoring case and underscores, so "display_name" and "displayName" both
+// select DisplayName. A mask without paths selects every field.
+type FieldMask struct {
+	Paths []string
+
+	prefix string // path of the masked value within the top-level mask, for errors
+}
+
+// NewFieldMask returns a mask selecting the given paths.
+func NewFieldMask(paths ...string) FieldMask {
+	return FieldMask{Paths: paths}
+}
+
+// All reports whether m selects every field.
+func (m FieldMask) All() bool {
+	return len(m.Paths) == 0
+}
+
+// Split splits m by the fields of a struct: leaves are fields selected only as
+// a whole, and nested are fields whose own fields can be selected. It returns
+// the selected fields with the mask that applies to each, which selects every
+// field when the field is selected as a whole. Generated code calls Split.
+func (m FieldMask) Split(leaves, nested []string) (map[string]FieldMask, error) {
+	fields := make(map[string]FieldMask)
+
+	if m.All() {
+		for _, name := range append(append([]string{}, leaves...), nested...) {
+			fields[name] = FieldMask{prefix: m.prefix + name + "."}
+		}
+
+		return fields, nil
+	}
+
+	known := make(map[string]string, len(leaves)+len(nested))
+	for _, name := range leaves {
+		known[normalizeFieldName(name)] = name
+	}
+
+	isNested := make(map[string]bool, len(nested))
+	for _, name := range nested {
+		known[normalizeFieldName(name)] = name
+		isNested[name] = true
+	}
+
+	for _, path := range m.Paths {
+		head, rest, hasRest := strings.Cut(path, ".")
+
+		name, ok := known[normalizeFieldName(head)]
+		if !ok || (hasRest && (!isNested[name] || rest == "")) {
+			return nil, fmt.Errorf("%w: %s%s", ErrUnknownFieldPath, m.prefix, path)
+		}
+
+		sub, seen := fields[name]
+
+		switch {
+		case !hasRest:
+			fields[name] = FieldMask{prefix: m.prefix + head + "."}
+		case seen && sub.All():
+			// Already selected as a whole.
+		default:
+			sub.Paths = append(sub.Paths, rest)
+			sub.prefix = m.prefix + head + "."
+			fields[name] = sub
+		}
+	}
+
+	return fields, nil
+}
+
+func normalizeFieldName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,77 @@
+package runtime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestFieldMaskSplit(t *testing.T) {
+	leaves, nested := []string{"Name", "DisplayName"}, []string{"Address"}
+
+	tests := []struct {
+		name  string
+		paths []string
+		want  map[string][]string // selected fields to their sub-paths
+	}{
+		{
+			name:  "empty mask selects all fields",
+			paths: nil,
+			want:  map[string][]string{"Name": nil, "DisplayName": nil, "Address": nil},
+		},
+		{
+			name:  "names ignore case and underscores",
+			paths: []string{"name", "display_name", "displayName"},
+			want:  map[string][]string{"Name": nil, "DisplayName": nil},
+		},
+		{
+			name:  "sub-paths are grouped by field",
+			paths: []string{"Address.City", "Address.Geo.Lat"},
+			want:  map[string][]string{"Address": {"City", "Geo.Lat"}},
+		},
+		{
+			name:  "whole field wins over its sub-paths",
+			paths: []string{"Address.City", "Address", "Address.Street"},
+			want:  map[string][]string{"Address": nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := NewFieldMask(tt.paths...).Split(leaves, nested)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string][]string, len(fields))
+			for name, sub := range fields {
+				got[name] = sub.Paths
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldMaskSplitUnknownPath(t *testing.T) {
+	leaves, nested := []string{"Name"}, []string{"Address"}
+
+	for _, path := range []string{"Age", "Name.First", "Address.", ""} {
+		if _, err := NewFieldMask(path).Split(leaves, nested); !errors.Is(err, ErrUnknownFieldPath) {
+			t.Errorf("Split(%q) error = %v, want ErrUnknownFieldPath", path, err)
+		}
+	}
+
+	// Errors name the full path of nested masks.
+	fields, err := NewFieldMask("address.zip").Split(leaves, nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = fields["Address"].Split([]string{"City"}, nil)
+	if err == nil || err.Error() != "unknown field path: address.zip" {
+		t.Errorf("error = %v, want unknown field path: address.zip", err)
+	}
+}
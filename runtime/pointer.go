@@ -0,0 +1,8 @@
+package runtime
+
+import "errors"
+
+// ErrNilPointer is returned, wrapped in a [*FieldError], by conversions generated with
+// [WithNilAsError] when a pointer field of the source is nil but the destination field
+// is not a pointer.
+var ErrNilPointer = errors.New("nil pointer")
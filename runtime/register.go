@@ -4,15 +4,248 @@ package runtime
 // Registration represents conversion registration type.
 type Registration struct{}
 
+// Option configures how gonverter generates a registered conversion.
+// Options are read from the source code by gonverter and have no effect at runtime.
+type Option struct{}
+
+// WithConstructor also generates To<To>(src *From) *To, which returns nil for a nil src,
+// and To<To>s(src []From) []To, which converts every element of a slice.
+func WithConstructor() Option {
+	return Option{}
+}
+
+// WithValueConstructor also generates To<To>(src From) To, which is convenient for small
+// structs that are cheap to copy, and To<To>s(src []From) []To.
+func WithValueConstructor() Option {
+	return Option{}
+}
+
+// WithResetDst makes the generated function reset dst to its zero value before mapping
+// fields, so that no stale values survive when dst is reused. A nil src then also resets
+// dst, unless [WithPreserveOnNil] is given. It applies to nested conversions too.
+func WithResetDst() Option {
+	return Option{}
+}
+
+// WithPreserveOnNil makes the generated function leave dst untouched when src is nil.
+// This is the default unless [WithResetDst] is given.
+func WithPreserveOnNil() Option {
+	return Option{}
+}
+
+// WithEmptyCollections makes the generated function turn nil slices and maps of src into
+// empty ones in dst, so that they encode as [] and {} rather than null in JSON. It applies
+// to nested conversions too.
+func WithEmptyCollections() Option {
+	return Option{}
+}
+
+// WithNilAsError makes the generated function return an error wrapping [ErrNilPointer]
+// when a pointer field of src is nil and the destination field is not a pointer, instead
+// of setting the destination field to its zero value. It applies to the fields converted
+// by [BuiltinPointers], in nested conversions too.
+func WithNilAsError() Option {
+	return Option{}
+}
+
+// WithFieldMask also generates Convert<From>To<To>WithMask(src *From, dst *To, mask FieldMask) error,
+// which converts only the fields selected by mask, recursing through nested structs and
+// the elements of slices and maps. It returns an error wrapping [ErrUnknownFieldPath] if a
+// path in mask does not name a field of To.
+func WithFieldMask() Option {
+	return Option{}
+}
+
+// WithValidation makes the generated function validate dst once its fields are mapped:
+// it calls Validate on dst and on the values nested in it that implement [Validator],
+// and returns their errors as [ValidationErrors] annotated with field paths. The
+// generated function returns an error then.
+func WithValidation() Option {
+	return Option{}
+}
+
+// WithDefaults makes the generated function set the defaults of dst once its fields are
+// mapped, by calling the SetDefaults<To> function [RegisterDefaults] generates. The
+// function is generated for To if it is not registered itself.
+func WithDefaults() Option {
+	return Option{}
+}
+
+// WithBuiltins makes the generated function convert fields with the built-in converters
+// of the given categories, such as [BuiltinTime] for time.Time to string, when their types
+// differ and no field hook converts them. Without categories, all of them are enabled.
+// Converters that parse strings make the generated function return an error, a
+// [*FieldError] with the name of the destination field. It applies to nested conversions too.
+func WithBuiltins(categories ...Builtin) Option {
+	return Option{}
+}
+
+// WithEnumPrefix makes a [RegisterEnum] conversion strip prefix from the names of the
+// constants of From and to from those of To before pairing them, e.g. "STATUS_" and
+// "Status" to pair STATUS_ACTIVE with StatusActive. Prefixes are matched ignoring case
+// and underscores. Without it, leading repetitions of the type name are stripped.
+func WithEnumPrefix(from, to string) Option {
+	return Option{}
+}
+
+// WithEnumFallback makes a [RegisterEnum] conversion convert values of From without an
+// equivalent to value, a constant of To, instead of returning an error.
+func WithEnumFallback(value any) Option {
+	return Option{}
+}
+
+// WithZeroAsUnset makes a [RegisterPatch] function treat zero values of src fields as
+// unset, so that they leave the destination field unchanged like nil pointers do.
+func WithZeroAsUnset() Option {
+	return Option{}
+}
+
+// WithMethods also generates methods on the source type, which must be declared in the
+// registration package: To<Pkg>() returns the receiver converted to a new destination, and
+// From<Pkg>(src) sets the receiver from a destination value. <Pkg> is the package name of
+// the destination type, e.g. ToDomain and FromDomain for domain.User. The conversion back
+// from the destination is generated for From<Pkg>.
+func WithMethods() Option {
+	return Option{}
+}
+
+// WithMethodNames is like [WithMethods] with the method names given as patterns, in which
+// {Pkg} and {Type} stand for the package and type name of the destination type. An empty
+// pattern leaves out that method.
+func WithMethodNames(to, from string) Option {
+	return Option{}
+}
+
+// WithFactory makes the generated function create the destination with fn, such as
+// NewUser(name string, email Email) (*User, error), rather than set its fields, which
+// may be unexported. fn returns the destination type or a pointer to it, and optionally
+// an error, which the generated function returns. If fn returns a nil pointer, the
+// generated function returns [ErrNilFactoryResult] instead. Its parameters are filled from the
+// source fields of the same name, ignoring case, like destination fields. params are
+// functions such as NewEmail(string) (Email, error) creating the values of parameters of
+// the types they return, whose errors are returned as a [*FieldError].
+func WithFactory(fn any, params ...any) Option {
+	return Option{}
+}
+
+// WithAccessors makes the generated function read source fields through getter methods,
+// Name() or GetName(), and set destination fields through setter methods, SetName(v), when
+// the type has no exported field of that name. Setters may return an error, which the
+// generated function returns as a [*FieldError].
+func WithAccessors() Option {
+	return Option{}
+}
+
+// WithAccessorNames is like [WithAccessors] with the method names given as patterns, in
+// which {Field} stands for the field name. getter may list several patterns separated by
+// commas, tried in order. An empty pattern leaves out getters or setters.
+func WithAccessorNames(getter, setter string) Option {
+	return Option{}
+}
+
+// WithPrecedence sets the order in which a [Register2] or [Register3] conversion takes
+// fields found in more than one source, given as zero values of the source types such as
+// Path{}, highest first. Sources left out follow in the order of the type parameters,
+// which is the order without this option.
+func WithPrecedence(sources ...any) Option {
+	return Option{}
+}
+
 // Register registers conversion between two types.
 // This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
-func Register[From, To any]() Registration {
+func Register[From, To any](opts ...Option) Registration {
 	return Registration{}
 }
 
 // RegisterBidirectional registers bidirectional conversion between two types.
-// This generates both From→To and To→From conversion functions.
+// This generates both From→To and To→From conversion functions; opts apply to both.
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterBidirectional[From, To any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// Register2 registers a conversion to To from two source types. This generates
+// Convert<A>And<B>To<To>(a *A, b *B, dst *To), which fills each field of To from the
+// source that has it, taking fields found in both from the first one unless
+// [WithPrecedence] says otherwise. A nil source leaves the fields taken from it unchanged.
+// Field hooks and Before and After hooks take every source, as in func(a *A, b *B, dst *To).
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func Register2[A, B, To any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// Register3 is like [Register2] with three source types, generating
+// Convert<A>And<B>And<C>To<To>(a *A, b *B, c *C, dst *To).
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func Register3[A, B, C, To any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// RegisterSplit2 registers a conversion from From to two destination types, such as the
+// rows an aggregate is stored in. This generates Convert<From>To<A>And<B>(src *From, a *A, b *B),
+// which fills each destination as [Register] would, reading the fields of src several
+// destinations need only once. Field hooks fill a single destination, as in
+// func(src *From, dst *A), while Before and After hooks take every destination.
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterSplit2[From, A, B any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// RegisterSplit3 is like [RegisterSplit2] with three destination types, generating
+// Convert<From>To<A>And<B>And<C>(src *From, a *A, b *B, c *C).
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterSplit3[From, A, B, C any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// RegisterHub registers Hub as the hub of a set of versioned spoke types, given as
+// zero values such as v1.User{}. This generates conversions between every spoke and
+// the hub, and conversions between every two spokes that go through the hub.
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterHub[Hub any](spokes ...any) Registration {
+	return Registration{}
+}
+
+// RegisterVia registers a conversion from From to To that goes through Via. It is
+// composed of the conversions from From to Via and from Via to To, which may in turn
+// go through other types.
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterVia[From, To, Via any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// RegisterPath registers a conversion from From to To composed of the shortest chain of
+// registered conversions and hand-written Convert<A>To<B> functions leading from one to the other.
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterPath[From, To any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// RegisterPatch registers a patch of To with the fields set in From. This generates
+// Apply<From>To<To>(src *From, dst *To), which applies pointer fields of src only when
+// they are non-nil, merges nested structs recursively and merges maps key by key.
+// Fields are matched and field hooks are called as for [Register].
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterPatch[From, To any](opts ...Option) Registration {
+	return Registration{}
+}
+
+// RegisterDefaults registers a defaulter for T. This generates SetDefaults<T>(obj *T), which
+// sets zero-valued fields tagged `default:"..."` to the tag value, calls a hand-written
+// SetDefaults_<T>(obj *T) if the package declares one, and then sets the defaults of the
+// structs nested in obj, including the elements of slices and maps.
+// This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
+func RegisterDefaults[T any]() Registration {
+	return Registration{}
+}
+
+// RegisterEnum registers a conversion between two enum types, named string or integer
+// types with constants. The constants of each type are paired by name, ignoring case,
+// underscores and prefixes as described in [WithEnumPrefix]. This generates
+// Convert<From>To<To>(src *From, dst *To), which converts values without an equivalent to
+// the [WithEnumFallback] value or, without one, returns an error wrapping
+// [ErrUnknownEnumValue]. Conversions of structs use it for fields of these types.
 // This function does nothing at runtime; it's used as a marker for gonverter to extract type information for code generation.
-func RegisterBidirectional[From, To any]() Registration {
+func RegisterEnum[From, To any](opts ...Option) Registration {
 	return Registration{}
 }
@@ -0,0 +1,151 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrNotRegistered is returned when a scheme has no conversion between two types.
+var ErrNotRegistered = errors.New("conversion not registered")
+
+// ConversionFunc converts src into dst. Both arguments are pointers.
+type ConversionFunc func(src, dst any) error
+
+// Scheme maps pairs of types to the functions converting between them, so that
+// callers can convert values without knowing the generated function names.
+// It is safe for concurrent use.
+type Scheme struct {
+	mu    sync.RWMutex
+	funcs map[typePair]ConversionFunc
+}
+
+type typePair struct {
+	src, dst reflect.Type
+}
+
+// DefaultScheme is the scheme generated code registers its conversions in.
+var DefaultScheme = NewScheme()
+
+// NewScheme returns an empty scheme.
+func NewScheme() *Scheme {
+	return &Scheme{funcs: make(map[typePair]ConversionFunc)}
+}
+
+// AddConversion registers fn as the conversion from From to To in s,
+// replacing any previously registered conversion between the two types.
+func AddConversion[From, To any](s *Scheme, fn func(src *From, dst *To)) {
+	AddFallibleConversion(s, func(src *From, dst *To) error {
+		fn(src, dst)
+
+		return nil
+	})
+}
+
+// AddFallibleConversion is like [AddConversion] for conversions that can fail, such as
+// those calling hooks that return an error. Errors of fn are returned by [Scheme.Convert].
+func AddFallibleConversion[From, To any](s *Scheme, fn func(src *From, dst *To) error) {
+	s.add(reflect.TypeFor[*From](), reflect.TypeFor[*To](), func(src, dst any) error {
+		from, ok := src.(*From)
+		if !ok {
+			return fmt.Errorf("unexpected source type %T", src)
+		}
+
+		to, ok := dst.(*To)
+		if !ok {
+			return fmt.Errorf("unexpected destination type %T", dst)
+		}
+
+		return fn(from, to)
+	})
+}
+
+func (s *Scheme) add(src, dst reflect.Type, fn ConversionFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.funcs[typePair{src: src, dst: dst}] = fn
+}
+
+func (s *Scheme) lookup(src, dst reflect.Type) (ConversionFunc, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fn, ok := s.funcs[typePair{src: src, dst: dst}]
+
+	return fn, ok
+}
+
+// Converts reports whether s can convert values of type src into values of type dst.
+// Both types are pointer types, e.g. reflect.TypeFor[*User]().
+func (s *Scheme) Converts(src, dst reflect.Type) bool {
+	_, ok := s.lookup(src, dst)
+
+	return ok
+}
+
+// Convert converts src into dst using the conversion registered for their types.
+// dst must be a non-nil pointer. src may be a value or a pointer; a nil pointer
+// leaves dst untouched, like the generated functions do.
+func (s *Scheme) Convert(src, dst any) error {
+	dv := reflect.ValueOf(dst)
+	if dv.Kind() != reflect.Pointer || dv.IsNil() {
+		return fmt.Errorf("destination must be a non-nil pointer, got %T", dst)
+	}
+
+	sv := reflect.ValueOf(src)
+	if !sv.IsValid() {
+		return errors.New("source must not be nil")
+	}
+
+	if sv.Kind() != reflect.Pointer {
+		p := reflect.New(sv.Type())
+		p.Elem().Set(sv)
+		sv = p
+	}
+
+	fn, ok := s.lookup(sv.Type(), dv.Type())
+	if !ok {
+		return fmt.Errorf("%w: %v to %v", ErrNotRegistered, sv.Type().Elem(), dv.Type().Elem())
+	}
+
+	return fn(sv.Interface(), dst)
+}
+
+// Convert converts src into a new To using [DefaultScheme].
+// From and To may be pointer or value types; for example
+// Convert[*handler.UserRequest, *domain.User](req) returns a newly allocated *domain.User.
+func Convert[From, To any](src From) (To, error) {
+	return ConvertWith[From, To](DefaultScheme, src)
+}
+
+// ConvertWith converts src into a new To using s. See [Convert].
+func ConvertWith[From, To any](s *Scheme, src From) (To, error) {
+	var to To
+
+	if sv := reflect.ValueOf(&src).Elem(); sv.Kind() == reflect.Pointer && sv.IsNil() {
+		return to, nil
+	}
+
+	dst := any(&to)
+
+	if t := reflect.TypeFor[To](); t.Kind() == reflect.Pointer {
+		v := reflect.New(t.Elem()).Interface()
+
+		p, ok := v.(To)
+		if !ok {
+			return to, fmt.Errorf("unexpected destination type %T", v)
+		}
+
+		to, dst = p, v
+	}
+
+	if err := s.Convert(src, dst); err != nil {
+		var zero To
+
+		return zero, err
+	}
+
+	return to, nil
+}
@@ -0,0 +1,145 @@
+package runtime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type source struct {
+	Name string
+}
+
+type target struct {
+	Name string
+}
+
+func convertSourceToTarget(src *source, dst *target) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+}
+
+func newTestScheme() *Scheme {
+	s := NewScheme()
+	AddConversion(s, convertSourceToTarget)
+
+	return s
+}
+
+func TestSchemeConvert(t *testing.T) {
+	s := newTestScheme()
+
+	t.Run("pointer source", func(t *testing.T) {
+		var dst target
+		if err := s.Convert(&source{Name: "a"}, &dst); err != nil {
+			t.Fatal(err)
+		}
+
+		if dst.Name != "a" {
+			t.Errorf("Name = %q, want %q", dst.Name, "a")
+		}
+	})
+
+	t.Run("value source", func(t *testing.T) {
+		var dst target
+		if err := s.Convert(source{Name: "b"}, &dst); err != nil {
+			t.Fatal(err)
+		}
+
+		if dst.Name != "b" {
+			t.Errorf("Name = %q, want %q", dst.Name, "b")
+		}
+	})
+
+	t.Run("non-pointer destination", func(t *testing.T) {
+		if err := s.Convert(&source{}, target{}); err == nil {
+			t.Error("expected error for non-pointer destination")
+		}
+	})
+
+	t.Run("unregistered pair", func(t *testing.T) {
+		var dst source
+
+		err := s.Convert(&target{}, &dst)
+		if !errors.Is(err, ErrNotRegistered) {
+			t.Errorf("error = %v, want ErrNotRegistered", err)
+		}
+	})
+}
+
+func TestSchemeConverts(t *testing.T) {
+	s := newTestScheme()
+
+	if !s.Converts(reflect.TypeFor[*source](), reflect.TypeFor[*target]()) {
+		t.Error("expected source -> target to be registered")
+	}
+
+	if s.Converts(reflect.TypeFor[*target](), reflect.TypeFor[*source]()) {
+		t.Error("expected target -> source not to be registered")
+	}
+}
+
+func TestAddFallibleConversion(t *testing.T) {
+	errEmpty := errors.New("empty name")
+	s := NewScheme()
+	AddFallibleConversion(s, func(src *target, dst *source) error {
+		if src.Name == "" {
+			return errEmpty
+		}
+
+		dst.Name = src.Name
+
+		return nil
+	})
+
+	var dst source
+	if err := s.Convert(&target{Name: "a"}, &dst); err != nil || dst.Name != "a" {
+		t.Errorf("Convert() = %v, Name %q; want nil, %q", err, dst.Name, "a")
+	}
+
+	if _, err := ConvertWith[*target, *source](s, &target{}); !errors.Is(err, errEmpty) {
+		t.Errorf("error = %v, want %v", err, errEmpty)
+	}
+}
+
+func TestConvertWith(t *testing.T) {
+	s := newTestScheme()
+
+	t.Run("pointer types", func(t *testing.T) {
+		got, err := ConvertWith[*source, *target](s, &source{Name: "p"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got == nil || got.Name != "p" {
+			t.Errorf("got %+v, want Name %q", got, "p")
+		}
+	})
+
+	t.Run("value types", func(t *testing.T) {
+		got, err := ConvertWith[source, target](s, source{Name: "v"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got.Name != "v" {
+			t.Errorf("Name = %q, want %q", got.Name, "v")
+		}
+	})
+
+	t.Run("nil source", func(t *testing.T) {
+		got, err := ConvertWith[*source, *target](s, nil)
+		if err != nil || got != nil {
+			t.Errorf("got %v, %v; want nil, nil", got, err)
+		}
+	})
+
+	t.Run("unregistered", func(t *testing.T) {
+		if _, err := ConvertWith[target, source](s, target{}); !errors.Is(err, ErrNotRegistered) {
+			t.Errorf("error = %v, want ErrNotRegistered", err)
+		}
+	})
+}
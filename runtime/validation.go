@@ -0,0 +1,100 @@
+package runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Validator is implemented by types that check their own values. Conversions
+// registered with [WithValidation] call Validate on the destination and on the
+// values nested in it.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError is an error of a conversion located at Path, a field path such as
+// "Address.City" or "Items[0]": the error a Validate method returned for the
+// value at Path, or the error of filling the field at Path, such as a string
+// that does not parse, a nil pointer with [WithNilAsError], or the error of a
+// setter or of a function given to [WithFactory]. Path is empty for the
+// destination of the conversion itself.
+type FieldError struct {
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	if e.Path == "" {
+		return e.Err.Error()
+	}
+
+	return e.Path + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors are the errors of every value that failed validation after
+// a conversion, in field order. [errors.Is] and [errors.As] see each of them.
+type ValidationErrors []*FieldError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+
+	return errs
+}
+
+// Validation collects the errors of Validate methods across the values of a
+// destination. Generated code uses it.
+type Validation struct {
+	errs ValidationErrors
+}
+
+// Check calls val.Validate and records its error, if any, under path.
+func (v *Validation) Check(path string, val Validator) {
+	if err := val.Validate(); err != nil {
+		v.errs = append(v.errs, &FieldError{Path: path, Err: err})
+	}
+}
+
+// Err returns the recorded errors as [ValidationErrors], or nil if there are none.
+func (v *Validation) Err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
+
+// FieldPath returns the path of the field name of the value at path.
+func FieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+// IndexPath returns the path of element i of the slice at path.
+func IndexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// KeyPath returns the path of the element with the given key of the map at path.
+func KeyPath(path string, key any) string {
+	return fmt.Sprintf("%s[%v]", path, key)
+}
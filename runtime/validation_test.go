@@ -0,0 +1,45 @@
+package runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+var errInvalid = errors.New("invalid")
+
+type validatorFunc func() error
+
+func (f validatorFunc) Validate() error {
+	return f()
+}
+
+func TestValidation(t *testing.T) {
+	valid := validatorFunc(func() error { return nil })
+	invalid := validatorFunc(func() error { return errInvalid })
+
+	var v Validation
+
+	v.Check("", valid)
+
+	if err := v.Err(); err != nil {
+		t.Fatalf("Err() = %v, want nil", err)
+	}
+
+	v.Check("", invalid)
+	v.Check(KeyPath(IndexPath(FieldPath("", "Items"), 1), "a"), invalid)
+	v.Check(FieldPath("Address", "City"), valid)
+
+	err := v.Err()
+	if want := "invalid; Items[1][a]: invalid"; err == nil || err.Error() != want {
+		t.Fatalf("Err() = %v, want %s", err, want)
+	}
+
+	if !errors.Is(err, errInvalid) {
+		t.Errorf("errors.Is(%v, errInvalid) = false, want true", err)
+	}
+
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "" {
+		t.Errorf("errors.As() = %+v, want the error of the destination", fieldErr)
+	}
+}
@@ -0,0 +1,59 @@
+package accessor
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+)
+
+func TestConvertCustomerToCustomerDTO(t *testing.T) {
+	src := &Customer{name: "jd", email: "jd@example.com", address: Address{City: "Tokyo"}, tags: []string{"a"}}
+
+	var dst CustomerDTO
+
+	ConvertCustomerToCustomerDTO(src, &dst)
+
+	if dst.Name != "jd" || dst.Email != "jd@example.com" || dst.Nickname != "jd" || dst.Address.City != "Tokyo" || len(dst.Tags) != 1 {
+		t.Errorf("ConvertCustomerToCustomerDTO() = %+v", dst)
+	}
+}
+
+func TestConvertCustomerDTOToCustomer(t *testing.T) {
+	var dst Customer
+
+	err := ConvertCustomerDTOToCustomer(&CustomerDTO{Name: "jd", Email: "jd@example.com", Address: AddressDTO{City: "Tokyo"}}, &dst)
+	if err != nil || dst.name != "jd" || dst.email != "jd@example.com" || dst.address.City != "Tokyo" {
+		t.Fatalf("ConvertCustomerDTOToCustomer() = %+v, %v", dst, err)
+	}
+
+	err = ConvertCustomerDTOToCustomer(&CustomerDTO{Email: "jd"}, &dst)
+
+	var fieldErr *runtime.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Email" {
+		t.Errorf("error = %v, want a FieldError for Email", err)
+	}
+}
+
+func TestConvertProductToProductView(t *testing.T) {
+	var dst ProductView
+
+	ConvertProductToProductView(NewProduct("p-1", 100), &dst)
+
+	if dst.SKU != "p-1" || dst.Price == nil || *dst.Price != 100 {
+		t.Errorf("ConvertProductToProductView() = %+v", dst)
+	}
+}
+
+func TestConvertOrderToOrderDTOWithMask(t *testing.T) {
+	dst := OrderDTO{ID: "o-0", Shipping: AddressDTO{City: "Osaka", Zip: "530"}}
+
+	err := ConvertOrderToOrderDTOWithMask(NewOrder("o-1", Address{City: "Tokyo", Zip: "100"}), &dst, runtime.NewFieldMask("Shipping.City"))
+	if err != nil {
+		t.Fatalf("ConvertOrderToOrderDTOWithMask() error = %v", err)
+	}
+
+	if dst.ID != "o-0" || dst.Shipping.City != "Tokyo" || dst.Shipping.Zip != "530" {
+		t.Errorf("ConvertOrderToOrderDTOWithMask() = %+v", dst)
+	}
+}
@@ -0,0 +1,7 @@
+package accessor
+
+// ConvertCustomerNicknameToCustomerDTONickname fills the nickname, which has
+// no getter.
+func ConvertCustomerNicknameToCustomerDTONickname(src *Customer, dst *CustomerDTO) {
+	dst.Nickname = src.Nickname(src.Name())
+}
@@ -0,0 +1,195 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:8fd0153242286fc9d37a45ff422d6d9971fd047d8a2a566e08bb0d58b6afa6f7
+// Checksum:      sha256:711a14c3f48d4be97cfb7d6dcc214c4f6ee1bae7836791d597a2ee597946ced8
+
+package accessor
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertCustomerToCustomerDTO converts Customer to CustomerDTO.
+// If src is nil, dst is left unchanged.
+func ConvertCustomerToCustomerDTO(src *Customer, dst *CustomerDTO) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name()
+	dst.Email = src.GetEmail()
+	ConvertCustomerNicknameToCustomerDTONickname(src, dst)
+	{
+		srcAddress := src.Address()
+		ConvertAddressToAddressDTO(&srcAddress, &dst.Address)
+	}
+	dst.Tags = src.Tags()
+}
+
+// ConvertCustomerDTOToCustomer converts CustomerDTO to Customer.
+// If src is nil, dst is left unchanged.
+func ConvertCustomerDTOToCustomer(src *CustomerDTO, dst *Customer) error {
+	if src == nil {
+		return nil
+	}
+
+	{
+		var dstAddress Address
+		ConvertAddressDTOToAddress(&src.Address, &dstAddress)
+		dst.SetAddress(dstAddress)
+	}
+	if err := dst.SetEmail(src.Email); err != nil {
+		return &runtime.FieldError{Path: "Email", Err: err}
+	}
+	dst.SetName(src.Name)
+	dst.SetTags(src.Tags)
+
+	return nil
+}
+
+// ConvertProductToProductView converts Product to ProductView.
+// If src is nil, dst is left unchanged.
+func ConvertProductToProductView(src *Product, dst *ProductView) {
+	if src == nil {
+		return
+	}
+
+	dst.SKU = src.FetchSKU()
+	runtime.Ref(src.FetchPrice(), &dst.Price)
+}
+
+// ConvertOrderToOrderDTO converts Order to OrderDTO.
+// If src is nil, dst is left unchanged.
+func ConvertOrderToOrderDTO(src *Order, dst *OrderDTO) {
+	if src == nil {
+		return
+	}
+
+	dst.ID = src.ID()
+	{
+		srcShipping := src.Shipping()
+		ConvertAddressToAddressDTO(&srcShipping, &dst.Shipping)
+	}
+}
+
+// ConvertOrderToOrderDTOWithMask converts the fields selected by mask from Order to OrderDTO.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertOrderToOrderDTOWithMask(src *Order, dst *OrderDTO, mask runtime.FieldMask) error {
+	fields, err := splitOrderToOrderDTOMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["ID"]; ok {
+		dst.ID = src.ID()
+	}
+	if m, ok := fields["Shipping"]; ok && m.All() {
+		{
+			srcShipping := src.Shipping()
+			ConvertAddressToAddressDTO(&srcShipping, &dst.Shipping)
+		}
+	} else if ok {
+		{
+			srcShipping := src.Shipping()
+			if err := ConvertAddressToAddressDTOWithMask(&srcShipping, &dst.Shipping, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitOrderToOrderDTOMask splits mask by the fields of OrderDTO and checks the paths below them.
+func splitOrderToOrderDTOMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"ID"}, []string{"Shipping"})
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := fields["Shipping"]; ok && !m.All() {
+		if _, err := splitAddressToAddressDTOMask(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
+// ConvertAddressToAddressDTO converts Address to AddressDTO.
+// If src is nil, dst is left unchanged.
+func ConvertAddressToAddressDTO(src *Address, dst *AddressDTO) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+	dst.Zip = src.Zip
+}
+
+// ConvertAddressDTOToAddress converts AddressDTO to Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressDTOToAddress(src *AddressDTO, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+	dst.Zip = src.Zip
+}
+
+// ConvertAddressToAddressDTOWithMask converts the fields selected by mask from Address to AddressDTO.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertAddressToAddressDTOWithMask(src *Address, dst *AddressDTO, mask runtime.FieldMask) error {
+	fields, err := splitAddressToAddressDTOMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["City"]; ok {
+		dst.City = src.City
+	}
+	if _, ok := fields["Zip"]; ok {
+		dst.Zip = src.Zip
+	}
+
+	return nil
+}
+
+// splitAddressToAddressDTOMask splits mask by the fields of AddressDTO and checks the paths below them.
+func splitAddressToAddressDTOMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"City", "Zip"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertCustomerToCustomerDTO)
+	runtime.AddFallibleConversion(s, ConvertCustomerDTOToCustomer)
+	runtime.AddConversion(s, ConvertProductToProductView)
+	runtime.AddConversion(s, ConvertOrderToOrderDTO)
+	runtime.AddConversion(s, ConvertAddressToAddressDTO)
+	runtime.AddConversion(s, ConvertAddressDTOToAddress)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
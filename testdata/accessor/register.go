@@ -0,0 +1,11 @@
+//go:build gonverter
+
+package accessor
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterBidirectional[*Customer, *CustomerDTO](runtime.WithAccessors())
+var _ = runtime.Register[*Product, *ProductView](runtime.WithAccessorNames("Fetch{Field}", ""), runtime.WithBuiltins(runtime.BuiltinPointers))
+var _ = runtime.Register[*Order, *OrderDTO](runtime.WithAccessors(), runtime.WithFieldMask())
@@ -0,0 +1,130 @@
+package accessor
+
+import (
+	"errors"
+	"strings"
+)
+
+// Customer keeps its fields behind accessors.
+type Customer struct {
+	name     string
+	email    string
+	nickname string
+	address  Address
+	tags     []string
+}
+
+func (c *Customer) Name() string {
+	return c.name
+}
+
+func (c *Customer) SetName(name string) {
+	c.name = name
+}
+
+func (c *Customer) GetEmail() string {
+	return c.email
+}
+
+// SetEmail rejects addresses without an @.
+func (c *Customer) SetEmail(email string) error {
+	if !strings.Contains(email, "@") {
+		return errors.New("invalid email")
+	}
+
+	c.email = email
+
+	return nil
+}
+
+// Nickname is not a getter: it takes an argument.
+func (c *Customer) Nickname(fallback string) string {
+	if c.nickname == "" {
+		return fallback
+	}
+
+	return c.nickname
+}
+
+func (c *Customer) Address() Address {
+	return c.address
+}
+
+func (c *Customer) SetAddress(address Address) {
+	c.address = address
+}
+
+func (c *Customer) Tags() []string {
+	return c.tags
+}
+
+func (c *Customer) SetTags(tags []string) {
+	c.tags = tags
+}
+
+// Setup is not a setter of a field.
+func (c *Customer) Setup() {}
+
+type Address struct {
+	City string
+	Zip  string
+}
+
+type CustomerDTO struct {
+	Name     string
+	Email    string
+	Nickname string
+	Address  AddressDTO
+	Tags     []string
+}
+
+type AddressDTO struct {
+	City string
+	Zip  string
+}
+
+// Product has getters named Fetch<Field>.
+type Product struct {
+	sku   string
+	price int64
+}
+
+func NewProduct(sku string, price int64) *Product {
+	return &Product{sku: sku, price: price}
+}
+
+func (p *Product) FetchSKU() string {
+	return p.sku
+}
+
+func (p *Product) FetchPrice() int64 {
+	return p.price
+}
+
+type ProductView struct {
+	SKU   string
+	Price *int64
+}
+
+// Order is converted with a field mask, and ships to an address behind a getter.
+type Order struct {
+	id       string
+	shipping Address
+}
+
+func NewOrder(id string, shipping Address) *Order {
+	return &Order{id: id, shipping: shipping}
+}
+
+func (o *Order) ID() string {
+	return o.id
+}
+
+func (o *Order) Shipping() Address {
+	return o.shipping
+}
+
+type OrderDTO struct {
+	ID       string
+	Shipping AddressDTO
+}
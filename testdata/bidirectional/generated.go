@@ -1,8 +1,18 @@
 // Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:fcd6de9db0af64b17e4e62e25b1e95899ba4ee4b2c67f9ded0c5d728d32f59cf
+// Checksum:      sha256:5e79e85e287141927fae6dc54159d05cfec7f80d66cea2e37cebe597f027c01b
 
 package bidirectional
 
-// ConvertUserAPIToUserDomain converts UserAPI to UserDomain
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertUserAPIToUserDomain converts UserAPI to UserDomain.
+// If src is nil, dst is left unchanged.
 func ConvertUserAPIToUserDomain(src *UserAPI, dst *UserDomain) {
 	if src == nil {
 		return
@@ -13,7 +23,8 @@ func ConvertUserAPIToUserDomain(src *UserAPI, dst *UserDomain) {
 	dst.Email = src.Email
 }
 
-// ConvertUserDomainToUserAPI converts UserDomain to UserAPI
+// ConvertUserDomainToUserAPI converts UserDomain to UserAPI.
+// If src is nil, dst is left unchanged.
 func ConvertUserDomainToUserAPI(src *UserDomain, dst *UserAPI) {
 	if src == nil {
 		return
@@ -24,7 +35,8 @@ func ConvertUserDomainToUserAPI(src *UserDomain, dst *UserAPI) {
 	dst.Email = src.Email
 }
 
-// ConvertOrderAPIToOrderDomain converts OrderAPI to OrderDomain
+// ConvertOrderAPIToOrderDomain converts OrderAPI to OrderDomain.
+// If src is nil, dst is left unchanged.
 func ConvertOrderAPIToOrderDomain(src *OrderAPI, dst *OrderDomain) {
 	if src == nil {
 		return
@@ -39,7 +51,8 @@ func ConvertOrderAPIToOrderDomain(src *OrderAPI, dst *OrderDomain) {
 	}
 }
 
-// ConvertOrderDomainToOrderAPI converts OrderDomain to OrderAPI
+// ConvertOrderDomainToOrderAPI converts OrderDomain to OrderAPI.
+// If src is nil, dst is left unchanged.
 func ConvertOrderDomainToOrderAPI(src *OrderDomain, dst *OrderAPI) {
 	if src == nil {
 		return
@@ -54,7 +67,8 @@ func ConvertOrderDomainToOrderAPI(src *OrderDomain, dst *OrderAPI) {
 	}
 }
 
-// ConvertItemAPIToItemDomain converts ItemAPI to ItemDomain
+// ConvertItemAPIToItemDomain converts ItemAPI to ItemDomain.
+// If src is nil, dst is left unchanged.
 func ConvertItemAPIToItemDomain(src *ItemAPI, dst *ItemDomain) {
 	if src == nil {
 		return
@@ -64,7 +78,8 @@ func ConvertItemAPIToItemDomain(src *ItemAPI, dst *ItemDomain) {
 	dst.Quantity = src.Quantity
 }
 
-// ConvertItemDomainToItemAPI converts ItemDomain to ItemAPI
+// ConvertItemDomainToItemAPI converts ItemDomain to ItemAPI.
+// If src is nil, dst is left unchanged.
 func ConvertItemDomainToItemAPI(src *ItemDomain, dst *ItemAPI) {
 	if src == nil {
 		return
@@ -73,3 +88,17 @@ func ConvertItemDomainToItemAPI(src *ItemDomain, dst *ItemAPI) {
 	dst.Name = src.Name
 	dst.Quantity = src.Quantity
 }
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserAPIToUserDomain)
+	runtime.AddConversion(s, ConvertUserDomainToUserAPI)
+	runtime.AddConversion(s, ConvertOrderAPIToOrderDomain)
+	runtime.AddConversion(s, ConvertOrderDomainToOrderAPI)
+	runtime.AddConversion(s, ConvertItemAPIToItemDomain)
+	runtime.AddConversion(s, ConvertItemDomainToItemAPI)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
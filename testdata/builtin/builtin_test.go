@@ -0,0 +1,91 @@
+package builtin
+
+import (
+	"database/sql"
+	"errors"
+	"net/netip"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/sivchari/gonverter/runtime"
+)
+
+func TestConvertEventRowToEvent(t *testing.T) {
+	priority := 2
+	row := EventRow{
+		ID:        []byte("ev-1"),
+		Title:     sql.NullString{String: "Launch", Valid: true},
+		StartsAt:  "2024-05-01T09:00:00Z",
+		CreatedAt: 1700000000,
+		Timeout:   "1m30s",
+		Retry:     int64(5 * time.Second),
+		Link:      "https://example.com/launch",
+		Host:      "192.0.2.1",
+		Priority:  &priority,
+		Venue:     VenueRow{Name: "Hall", Capacity: sql.NullInt64{Int64: 300, Valid: true}},
+	}
+
+	var got Event
+	if err := ConvertEventRowToEvent(&row, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ID != "ev-1" || got.Title == nil || *got.Title != "Launch" ||
+		!got.StartsAt.Equal(time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)) || got.CreatedAt.Unix() != 1700000000 ||
+		got.Timeout != 90*time.Second || got.Retry != 5*time.Second || got.Link.Host != "example.com" ||
+		got.Host != netip.MustParseAddr("192.0.2.1") || got.Priority != 2 || got.DeletedAt != nil ||
+		got.Venue.Capacity == nil || *got.Venue.Capacity != 300 {
+		t.Fatalf("ConvertEventRowToEvent() = %+v", got)
+	}
+
+	// The conversion back needs no error check.
+	var back EventRow
+
+	ConvertEventToEventRow(&got, &back)
+
+	if !reflect.DeepEqual(back, row) {
+		t.Errorf("ConvertEventToEventRow() = %+v, want %+v", back, row)
+	}
+}
+
+func TestConvertEventRowToEventInvalid(t *testing.T) {
+	var got Event
+
+	err := ConvertEventRowToEvent(&EventRow{StartsAt: "2024-05-01T09:00:00Z", Timeout: "soon"}, &got)
+
+	var fieldErr *runtime.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Timeout" {
+		t.Errorf("error = %v, want a FieldError for Timeout", err)
+	}
+}
+
+func TestConvertSummaryToSummaryDTO(t *testing.T) {
+	var got SummaryDTO
+
+	ConvertSummaryToSummaryDTO(&Summary{StartsAt: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)}, &got)
+
+	if want := (SummaryDTO{StartsAt: "2024-05-01T09:00:00Z", Title: "untitled"}); got != want {
+		t.Errorf("ConvertSummaryToSummaryDTO() = %+v, want %+v", got, want)
+	}
+}
+
+func TestConvertScheduleToScheduleDTO(t *testing.T) {
+	starts, ends := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, 5, 1, 17, 0, 0, 0, time.UTC)
+
+	var dto ScheduleDTO
+
+	ConvertScheduleToScheduleDTO(&Schedule{StartsAt: starts, EndsAt: &ends}, &dto)
+
+	if dto.StartsAt == nil || !dto.StartsAt.Equal(starts) || !dto.EndsAt.Equal(ends) {
+		t.Fatalf("ConvertScheduleToScheduleDTO() = %+v", dto)
+	}
+
+	var back Schedule
+
+	ConvertScheduleDTOToSchedule(&dto, &back)
+
+	if !back.StartsAt.Equal(starts) || back.EndsAt == nil || !back.EndsAt.Equal(ends) {
+		t.Errorf("ConvertScheduleDTOToSchedule() = %+v", back)
+	}
+}
@@ -0,0 +1,10 @@
+package builtin
+
+// ConvertSummaryTitleToSummaryDTOTitle is needed because Summary does not
+// enable BuiltinPointers.
+func ConvertSummaryTitleToSummaryDTOTitle(src *Summary, dst *SummaryDTO) {
+	dst.Title = "untitled"
+	if src.Title != nil {
+		dst.Title = *src.Title
+	}
+}
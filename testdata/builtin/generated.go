@@ -0,0 +1,132 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:d0340507eae89df19d390c3020110fac613ca93b3a10a060e4325f42d2bf8d42
+// Checksum:      sha256:615bcb0c3f9c081a7ebd99fd57b34f2f29ee23762ec78ccd45ccf5b4c0c26c34
+
+package builtin
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertEventRowToEvent converts EventRow to Event.
+// If src is nil, dst is left unchanged.
+func ConvertEventRowToEvent(src *EventRow, dst *Event) error {
+	if src == nil {
+		return nil
+	}
+
+	runtime.BytesToString(src.ID, &dst.ID)
+	runtime.NullStringToPointer(src.Title, &dst.Title)
+	if err := runtime.RFC3339ToTime(src.StartsAt, &dst.StartsAt); err != nil {
+		return &runtime.FieldError{Path: "StartsAt", Err: err}
+	}
+	runtime.UnixToTime(src.CreatedAt, &dst.CreatedAt)
+	if err := runtime.StringToDuration(src.Timeout, &dst.Timeout); err != nil {
+		return &runtime.FieldError{Path: "Timeout", Err: err}
+	}
+	runtime.Int64ToDuration(src.Retry, &dst.Retry)
+	if err := runtime.StringToURLPointer(src.Link, &dst.Link); err != nil {
+		return &runtime.FieldError{Path: "Link", Err: err}
+	}
+	if err := runtime.StringToAddr(src.Host, &dst.Host); err != nil {
+		return &runtime.FieldError{Path: "Host", Err: err}
+	}
+	runtime.Deref(src.Priority, &dst.Priority)
+	runtime.NullTimeToPointer(src.DeletedAt, &dst.DeletedAt)
+	ConvertVenueRowToVenue(&src.Venue, &dst.Venue)
+
+	return nil
+}
+
+// ConvertEventToEventRow converts Event to EventRow.
+// If src is nil, dst is left unchanged.
+func ConvertEventToEventRow(src *Event, dst *EventRow) {
+	if src == nil {
+		return
+	}
+
+	runtime.StringToBytes(src.ID, &dst.ID)
+	runtime.PointerToNullString(src.Title, &dst.Title)
+	runtime.TimeToRFC3339(src.StartsAt, &dst.StartsAt)
+	runtime.TimeToUnix(src.CreatedAt, &dst.CreatedAt)
+	runtime.DurationToString(src.Timeout, &dst.Timeout)
+	runtime.DurationToInt64(src.Retry, &dst.Retry)
+	runtime.URLPointerToString(src.Link, &dst.Link)
+	runtime.AddrToString(src.Host, &dst.Host)
+	runtime.Ref(src.Priority, &dst.Priority)
+	runtime.PointerToNullTime(src.DeletedAt, &dst.DeletedAt)
+	ConvertVenueToVenueRow(&src.Venue, &dst.Venue)
+}
+
+// ConvertSummaryToSummaryDTO converts Summary to SummaryDTO.
+// If src is nil, dst is left unchanged.
+func ConvertSummaryToSummaryDTO(src *Summary, dst *SummaryDTO) {
+	if src == nil {
+		return
+	}
+
+	runtime.TimeToRFC3339(src.StartsAt, &dst.StartsAt)
+	ConvertSummaryTitleToSummaryDTOTitle(src, dst)
+}
+
+// ConvertScheduleToScheduleDTO converts Schedule to ScheduleDTO.
+// If src is nil, dst is left unchanged.
+func ConvertScheduleToScheduleDTO(src *Schedule, dst *ScheduleDTO) {
+	if src == nil {
+		return
+	}
+
+	runtime.Ref(src.StartsAt, &dst.StartsAt)
+	runtime.Deref(src.EndsAt, &dst.EndsAt)
+}
+
+// ConvertScheduleDTOToSchedule converts ScheduleDTO to Schedule.
+// If src is nil, dst is left unchanged.
+func ConvertScheduleDTOToSchedule(src *ScheduleDTO, dst *Schedule) {
+	if src == nil {
+		return
+	}
+
+	runtime.Deref(src.StartsAt, &dst.StartsAt)
+	runtime.Ref(src.EndsAt, &dst.EndsAt)
+}
+
+// ConvertVenueRowToVenue converts VenueRow to Venue.
+// If src is nil, dst is left unchanged.
+func ConvertVenueRowToVenue(src *VenueRow, dst *Venue) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	runtime.NullInt64ToPointer(src.Capacity, &dst.Capacity)
+}
+
+// ConvertVenueToVenueRow converts Venue to VenueRow.
+// If src is nil, dst is left unchanged.
+func ConvertVenueToVenueRow(src *Venue, dst *VenueRow) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	runtime.PointerToNullInt64(src.Capacity, &dst.Capacity)
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddFallibleConversion(s, ConvertEventRowToEvent)
+	runtime.AddConversion(s, ConvertEventToEventRow)
+	runtime.AddConversion(s, ConvertSummaryToSummaryDTO)
+	runtime.AddConversion(s, ConvertScheduleToScheduleDTO)
+	runtime.AddConversion(s, ConvertScheduleDTOToSchedule)
+	runtime.AddConversion(s, ConvertVenueRowToVenue)
+	runtime.AddConversion(s, ConvertVenueToVenueRow)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
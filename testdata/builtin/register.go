@@ -0,0 +1,11 @@
+//go:build gonverter
+
+package builtin
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterBidirectional[*EventRow, *Event](runtime.WithBuiltins())
+var _ = runtime.Register[*Summary, *SummaryDTO](runtime.WithBuiltins(runtime.BuiltinTime))
+var _ = runtime.RegisterBidirectional[*Schedule, *ScheduleDTO](runtime.WithBuiltins())
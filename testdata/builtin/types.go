@@ -0,0 +1,70 @@
+package builtin
+
+import (
+	"database/sql"
+	"net/netip"
+	"net/url"
+	"time"
+)
+
+// EventRow is an event as stored in the database.
+type EventRow struct {
+	ID        []byte
+	Title     sql.NullString
+	StartsAt  string
+	CreatedAt int64
+	Timeout   string
+	Retry     int64
+	Link      string
+	Host      string
+	Priority  *int
+	DeletedAt sql.NullTime
+	Venue     VenueRow
+}
+
+type VenueRow struct {
+	Name     string
+	Capacity sql.NullInt64
+}
+
+// Event is the domain model of an event.
+type Event struct {
+	ID        string
+	Title     *string
+	StartsAt  time.Time
+	CreatedAt time.Time
+	Timeout   time.Duration
+	Retry     time.Duration
+	Link      *url.URL
+	Host      netip.Addr
+	Priority  int
+	DeletedAt *time.Time
+	Venue     Venue
+}
+
+type Venue struct {
+	Name     string
+	Capacity *int64
+}
+
+// Summary only enables BuiltinTime.
+type Summary struct {
+	StartsAt time.Time
+	Title    *string
+}
+
+type SummaryDTO struct {
+	StartsAt string
+	Title    string
+}
+
+// Schedule keeps its times as values and pointers, which are copied as a whole.
+type Schedule struct {
+	StartsAt time.Time
+	EndsAt   *time.Time
+}
+
+type ScheduleDTO struct {
+	StartsAt *time.Time
+	EndsAt   time.Time
+}
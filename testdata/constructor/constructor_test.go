@@ -0,0 +1,42 @@
+package constructor
+
+import "testing"
+
+func TestPointerConstructor(t *testing.T) {
+	if got := ToUser(nil); got != nil {
+		t.Errorf("ToUser(nil) = %+v, want nil", got)
+	}
+
+	got := ToUser(&UserRequest{Name: "John"})
+	if got == nil || got.Name != "John" {
+		t.Errorf("ToUser() = %+v, want Name %q", got, "John")
+	}
+
+	// Bidirectional registrations get constructors in both directions.
+	if back := ToUserRequest(got); back == nil || back.Name != "John" {
+		t.Errorf("ToUserRequest() = %+v, want Name %q", back, "John")
+	}
+}
+
+func TestSliceHelper(t *testing.T) {
+	if got := ToAddresses(nil); got != nil {
+		t.Errorf("ToAddresses(nil) = %v, want nil", got)
+	}
+
+	got := ToAddresses([]AddressRequest{{City: "Tokyo"}, {City: "Osaka"}})
+	if len(got) != 2 || got[0].City != "Tokyo" || got[1].City != "Osaka" {
+		t.Errorf("ToAddresses() = %+v", got)
+	}
+}
+
+func TestValueConstructor(t *testing.T) {
+	got := ToPrice(Money{Amount: 100, Currency: "JPY"})
+	if got != (Price{Amount: 100, Currency: "JPY"}) {
+		t.Errorf("ToPrice() = %+v", got)
+	}
+
+	prices := ToPrices([]Money{{Amount: 1, Currency: "USD"}})
+	if len(prices) != 1 || prices[0].Amount != 1 {
+		t.Errorf("ToPrices() = %+v", prices)
+	}
+}
@@ -0,0 +1,165 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:8442b29ad34f4f36c4ad87779dfa046adb6a8cdd89eff90d50f443b2b0287729
+// Checksum:      sha256:54bfea6fd7ef08838318504f243cce2c5c72ecbc69c768e0e9b9817d7dc5830d
+
+package constructor
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertUserRequestToUser converts UserRequest to User.
+// If src is nil, dst is left unchanged.
+func ConvertUserRequestToUser(src *UserRequest, dst *User) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+}
+
+// ToUser returns src converted to a new User, or nil if src is nil.
+func ToUser(src *UserRequest) *User {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(User)
+	ConvertUserRequestToUser(src, dst)
+
+	return dst
+}
+
+// ToUsers converts every element of src to User. It returns nil if src is nil.
+func ToUsers(src []UserRequest) []User {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]User, len(src))
+	for i := range src {
+		ConvertUserRequestToUser(&src[i], &dst[i])
+	}
+
+	return dst
+}
+
+// ConvertUserToUserRequest converts User to UserRequest.
+// If src is nil, dst is left unchanged.
+func ConvertUserToUserRequest(src *User, dst *UserRequest) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+}
+
+// ToUserRequest returns src converted to a new UserRequest, or nil if src is nil.
+func ToUserRequest(src *User) *UserRequest {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(UserRequest)
+	ConvertUserToUserRequest(src, dst)
+
+	return dst
+}
+
+// ToUserRequests converts every element of src to UserRequest. It returns nil if src is nil.
+func ToUserRequests(src []User) []UserRequest {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]UserRequest, len(src))
+	for i := range src {
+		ConvertUserToUserRequest(&src[i], &dst[i])
+	}
+
+	return dst
+}
+
+// ConvertAddressRequestToAddress converts AddressRequest to Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressRequestToAddress(src *AddressRequest, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// ToAddress returns src converted to a new Address, or nil if src is nil.
+func ToAddress(src *AddressRequest) *Address {
+	if src == nil {
+		return nil
+	}
+
+	dst := new(Address)
+	ConvertAddressRequestToAddress(src, dst)
+
+	return dst
+}
+
+// ToAddresses converts every element of src to Address. It returns nil if src is nil.
+func ToAddresses(src []AddressRequest) []Address {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]Address, len(src))
+	for i := range src {
+		ConvertAddressRequestToAddress(&src[i], &dst[i])
+	}
+
+	return dst
+}
+
+// ConvertMoneyToPrice converts Money to Price.
+// If src is nil, dst is left unchanged.
+func ConvertMoneyToPrice(src *Money, dst *Price) {
+	if src == nil {
+		return
+	}
+
+	dst.Amount = src.Amount
+	dst.Currency = src.Currency
+}
+
+// ToPrice returns src converted to Price.
+func ToPrice(src Money) Price {
+	var dst Price
+	ConvertMoneyToPrice(&src, &dst)
+
+	return dst
+}
+
+// ToPrices converts every element of src to Price. It returns nil if src is nil.
+func ToPrices(src []Money) []Price {
+	if src == nil {
+		return nil
+	}
+
+	dst := make([]Price, len(src))
+	for i := range src {
+		ConvertMoneyToPrice(&src[i], &dst[i])
+	}
+
+	return dst
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertUserToUserRequest)
+	runtime.AddConversion(s, ConvertAddressRequestToAddress)
+	runtime.AddConversion(s, ConvertMoneyToPrice)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,11 @@
+//go:build gonverter
+
+package constructor
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterBidirectional[*UserRequest, *User](runtime.WithConstructor())
+var _ = runtime.Register[*AddressRequest, *Address](runtime.WithConstructor())
+var _ = runtime.Register[*Money, *Price](runtime.WithValueConstructor())
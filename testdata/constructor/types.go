@@ -0,0 +1,29 @@
+package constructor
+
+// Source types
+type UserRequest struct {
+	Name string
+}
+
+type AddressRequest struct {
+	City string
+}
+
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// Target types
+type User struct {
+	Name string
+}
+
+type Address struct {
+	City string
+}
+
+type Price struct {
+	Amount   int64
+	Currency string
+}
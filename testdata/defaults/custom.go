@@ -0,0 +1,14 @@
+package defaults
+
+// SetDefaults_Config runs after the default tags of Config are applied.
+func SetDefaults_Config(obj *Config) {
+	if obj.Labels == nil {
+		obj.Labels = map[string]string{"app": obj.Name}
+	}
+}
+
+func SetDefaults_Cache(obj *Cache) {
+	if obj.Size == 0 {
+		obj.Size = 64
+	}
+}
@@ -0,0 +1,58 @@
+package defaults
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSetDefaultsConfig(t *testing.T) {
+	obj := Config{
+		Port:     9090,
+		Backends: []Backend{{URL: "a"}, {URL: "b", Weight: 5}},
+		Limits:   map[string]Limit{"cpu": {}},
+		Cache:    &Cache{},
+	}
+
+	SetDefaultsConfig(&obj)
+
+	debug, replicas := true, int32(3)
+	want := Config{
+		Name:     "app",
+		Port:     9090,
+		Ratio:    0.5,
+		Timeout:  30 * time.Second,
+		Debug:    &debug,
+		Replicas: &replicas,
+		Labels:   map[string]string{"app": "app"},
+		Server:   Server{Host: "localhost", Scheme: "https"},
+		Backends: []Backend{{URL: "a", Weight: 1}, {URL: "b", Weight: 5}},
+		Limits:   map[string]Limit{"cpu": {Max: 100}},
+		Cache:    &Cache{Size: 64},
+	}
+
+	if !reflect.DeepEqual(obj, want) {
+		t.Errorf("SetDefaultsConfig() = %+v, want %+v", obj, want)
+	}
+}
+
+func TestSetDefaultsConfigKeepsSetFields(t *testing.T) {
+	debug := false
+	obj := Config{Name: "api", Debug: &debug, Server: Server{Scheme: "http"}}
+
+	SetDefaultsConfig(&obj)
+
+	if obj.Name != "api" || *obj.Debug || obj.Server.Scheme != "http" || obj.Labels["app"] != "api" {
+		t.Errorf("SetDefaultsConfig() = %+v", obj)
+	}
+}
+
+func TestConvertServerRequestToServer(t *testing.T) {
+	var dst Server
+
+	ConvertServerRequestToServer(&ServerRequest{}, &dst)
+
+	if want := (Server{Host: "localhost", Scheme: "https"}); dst != want {
+		t.Errorf("dst = %+v, want %+v", dst, want)
+	}
+}
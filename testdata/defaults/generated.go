@@ -0,0 +1,103 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:fd973e7beb99631c6c5abfbc7b42cfdedb56e35a2f45e341dc0799eb2229920d
+// Checksum:      sha256:dc5b6b087cc7992b2ea709ddb57f149d0a6236d1b9b40d2f2aa1b59d179f1693
+
+package defaults
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertServerRequestToServer converts ServerRequest to Server.
+// If src is nil, dst is left unchanged.
+func ConvertServerRequestToServer(src *ServerRequest, dst *Server) {
+	if src == nil {
+		return
+	}
+
+	dst.Host = src.Host
+
+	SetDefaultsServer(dst)
+}
+
+// SetDefaultsConfig sets the zero-valued fields of obj to their defaults and sets the
+// defaults of the values nested in obj.
+func SetDefaultsConfig(obj *Config) {
+	if obj.Name == "" {
+		obj.Name = "app"
+	}
+	if obj.Port == 0 {
+		obj.Port = 8080
+	}
+	if obj.Ratio == 0 {
+		obj.Ratio = 0.5
+	}
+	if obj.Timeout == 0 {
+		obj.Timeout = 30000000000
+	}
+	if obj.Debug == nil {
+		obj.Debug = new(bool)
+		*obj.Debug = true
+	}
+	if obj.Replicas == nil {
+		obj.Replicas = new(int32)
+		*obj.Replicas = 3
+	}
+	SetDefaults_Config(obj)
+	SetDefaultsServer(&obj.Server)
+	for i := range obj.Backends {
+		SetDefaultsBackend(&obj.Backends[i])
+	}
+	for k, elem := range obj.Limits {
+		SetDefaultsLimit(&elem)
+		obj.Limits[k] = elem
+	}
+	if obj.Cache != nil {
+		SetDefaultsCache(obj.Cache)
+	}
+}
+
+// SetDefaultsServer sets the zero-valued fields of obj to their defaults and sets the
+// defaults of the values nested in obj.
+func SetDefaultsServer(obj *Server) {
+	if obj.Host == "" {
+		obj.Host = "localhost"
+	}
+	if obj.Scheme == "" {
+		obj.Scheme = "https"
+	}
+}
+
+// SetDefaultsBackend sets the zero-valued fields of obj to their defaults and sets the
+// defaults of the values nested in obj.
+func SetDefaultsBackend(obj *Backend) {
+	if obj.Weight == 0 {
+		obj.Weight = 1
+	}
+}
+
+// SetDefaultsLimit sets the zero-valued fields of obj to their defaults and sets the
+// defaults of the values nested in obj.
+func SetDefaultsLimit(obj *Limit) {
+	if obj.Max == 0 {
+		obj.Max = 100
+	}
+}
+
+// SetDefaultsCache sets the zero-valued fields of obj to their defaults and sets the
+// defaults of the values nested in obj.
+func SetDefaultsCache(obj *Cache) {
+	SetDefaults_Cache(obj)
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertServerRequestToServer)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
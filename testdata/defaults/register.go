@@ -0,0 +1,10 @@
+//go:build gonverter
+
+package defaults
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterDefaults[Config]()
+var _ = runtime.Register[*ServerRequest, *Server](runtime.WithDefaults())
@@ -0,0 +1,41 @@
+package defaults
+
+import "time"
+
+type Config struct {
+	Name     string        `default:"app"`
+	Port     int           `default:"8080"`
+	Ratio    float64       `default:"0.5"`
+	Timeout  time.Duration `default:"30s"`
+	Debug    *bool         `default:"true"`
+	Replicas *int32        `default:"3"`
+	Labels   map[string]string
+	Server   Server
+	Backends []Backend
+	Limits   map[string]Limit
+	Cache    *Cache
+}
+
+type Server struct {
+	Host   string `default:"localhost"`
+	Scheme Scheme `default:"https"`
+}
+
+type Scheme string
+
+type Backend struct {
+	URL    string
+	Weight int `default:"1"`
+}
+
+type Limit struct {
+	Max int `default:"100"`
+}
+
+type Cache struct {
+	Size int
+}
+
+type ServerRequest struct {
+	Host string
+}
@@ -0,0 +1,99 @@
+package enum
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/enum/pb"
+)
+
+func TestConvertPbStatusToStatus(t *testing.T) {
+	tests := []struct {
+		src  pb.Status
+		want Status
+	}{
+		{pb.Status_STATUS_ACTIVE, StatusActive},
+		{pb.Status_STATUS_SUSPENDED, StatusSuspended},
+		{pb.Status_STATUS_DELETED, StatusUnknown},
+		{pb.Status(42), StatusUnknown},
+	}
+
+	for _, tt := range tests {
+		var got Status
+
+		ConvertPbStatusToStatus(&tt.src, &got)
+
+		if got != tt.want {
+			t.Errorf("ConvertPbStatusToStatus(%v) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestConvertStatusToPbStatus(t *testing.T) {
+	src, got := StatusSuspended, pb.Status_STATUS_ACTIVE
+
+	ConvertStatusToPbStatus(&src, &got)
+
+	if got != pb.Status_STATUS_SUSPENDED {
+		t.Errorf("ConvertStatusToPbStatus(%q) = %v, want %v", src, got, pb.Status_STATUS_SUSPENDED)
+	}
+
+	src = StatusUnknown
+	ConvertStatusToPbStatus(&src, &got)
+
+	if got != pb.Status_STATUS_UNSPECIFIED {
+		t.Errorf("ConvertStatusToPbStatus(%q) = %v, want %v", src, got, pb.Status_STATUS_UNSPECIFIED)
+	}
+}
+
+func TestConvertPbRoleToRole(t *testing.T) {
+	var got Role
+
+	// Aliases share the case of the constant they alias.
+	src := pb.Role_ROLE_OWNER
+	if err := ConvertPbRoleToRole(&src, &got); err != nil || got != PermAdmin {
+		t.Errorf("ConvertPbRoleToRole(%v) = %v, %v, want %v", src, got, err, PermAdmin)
+	}
+
+	src = pb.Role_ROLE_UNSPECIFIED
+	if err := ConvertPbRoleToRole(&src, &got); !errors.Is(err, runtime.ErrUnknownEnumValue) {
+		t.Errorf("ConvertPbRoleToRole(%v) error = %v, want %v", src, err, runtime.ErrUnknownEnumValue)
+	}
+}
+
+func TestToUser(t *testing.T) {
+	previous := pb.Status_STATUS_SUSPENDED
+
+	got, err := ToUser(&pb.User{
+		Name:     "John",
+		Status:   pb.Status_STATUS_ACTIVE,
+		Previous: &previous,
+		Roles:    []pb.Role{pb.Role_ROLE_ADMIN, pb.Role_ROLE_MEMBER},
+		Labels:   map[string]pb.Status{"billing": pb.Status_STATUS_DELETED},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	suspended := StatusSuspended
+	want := &User{
+		Name:     "John",
+		Status:   StatusActive,
+		Previous: &suspended,
+		Roles:    []Role{PermAdmin, PermMember},
+		Labels:   map[string]Status{"billing": StatusUnknown},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ToUser() = %+v, want %+v", got, want)
+	}
+}
+
+func TestToUserUnknownRole(t *testing.T) {
+	_, err := ToUser(&pb.User{Roles: []pb.Role{pb.Role_ROLE_ADMIN, pb.Role(7)}})
+	if !errors.Is(err, runtime.ErrUnknownEnumValue) {
+		t.Errorf("ToUser() error = %v, want %v", err, runtime.ErrUnknownEnumValue)
+	}
+}
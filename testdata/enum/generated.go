@@ -0,0 +1,145 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:ee675db251f835798179d9d386d01181f664122252465315c1e54123c7faea8a
+// Checksum:      sha256:7bdf7b9b6378e5876f46264c4ba8b406ed6aaa34a6a1a66fae2dc18db2295637
+
+package enum
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/enum/pb"
+)
+
+// ConvertPbStatusToStatus converts pb.Status to Status.
+// If src is nil, dst is left unchanged.
+// Values without an equivalent become StatusUnknown.
+func ConvertPbStatusToStatus(src *pb.Status, dst *Status) {
+	if src == nil {
+		return
+	}
+
+	switch *src {
+	case pb.Status_STATUS_ACTIVE:
+		*dst = StatusActive
+	case pb.Status_STATUS_SUSPENDED:
+		*dst = StatusSuspended
+	default:
+		*dst = StatusUnknown
+	}
+}
+
+// ConvertStatusToPbStatus converts Status to pb.Status.
+// If src is nil, dst is left unchanged.
+// Values without an equivalent become pb.Status_STATUS_UNSPECIFIED.
+func ConvertStatusToPbStatus(src *Status, dst *pb.Status) {
+	if src == nil {
+		return
+	}
+
+	switch *src {
+	case StatusActive:
+		*dst = pb.Status_STATUS_ACTIVE
+	case StatusSuspended:
+		*dst = pb.Status_STATUS_SUSPENDED
+	default:
+		*dst = pb.Status_STATUS_UNSPECIFIED
+	}
+}
+
+// ConvertPbRoleToRole converts pb.Role to Role.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownEnumValue for values without an equivalent.
+func ConvertPbRoleToRole(src *pb.Role, dst *Role) error {
+	if src == nil {
+		return nil
+	}
+
+	switch *src {
+	case pb.Role_ROLE_ADMIN:
+		*dst = PermAdmin
+	case pb.Role_ROLE_MEMBER:
+		*dst = PermMember
+	default:
+		return runtime.UnknownEnumValue(*src, "Role")
+	}
+
+	return nil
+}
+
+// ConvertUserToUser converts pb.User to User.
+// If src is nil, dst is left unchanged.
+func ConvertUserToUser(src *pb.User, dst *User) error {
+	if src == nil {
+		return nil
+	}
+
+	dst.Name = src.Name
+	ConvertPbStatusToStatus(&src.Status, &dst.Status)
+	if src.Previous != nil {
+		dst.Previous = new(Status)
+		ConvertPbStatusToStatus(src.Previous, dst.Previous)
+	}
+	if src.Roles != nil {
+		dst.Roles = make([]Role, len(src.Roles))
+		for i := range src.Roles {
+			if err := ConvertPbRoleToRole(&src.Roles[i], &dst.Roles[i]); err != nil {
+				return err
+			}
+		}
+	}
+	if src.Labels != nil {
+		dst.Labels = make(map[string]Status, len(src.Labels))
+		for k, v := range src.Labels {
+			var converted Status
+			ConvertPbStatusToStatus(&v, &converted)
+			dst.Labels[k] = converted
+		}
+	}
+
+	return nil
+}
+
+// ToUser returns src converted to a new User, or nil if src is nil.
+func ToUser(src *pb.User) (*User, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := new(User)
+	if err := ConvertUserToUser(src, dst); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// ToUsers converts every element of src to User. It returns nil if src is nil,
+// and stops at the first error.
+func ToUsers(src []pb.User) ([]User, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := make([]User, len(src))
+	for i := range src {
+		if err := ConvertUserToUser(&src[i], &dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertPbStatusToStatus)
+	runtime.AddConversion(s, ConvertStatusToPbStatus)
+	runtime.AddFallibleConversion(s, ConvertPbRoleToRole)
+	runtime.AddFallibleConversion(s, ConvertUserToUser)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
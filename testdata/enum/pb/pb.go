@@ -0,0 +1,31 @@
+// Package pb mimics the types protoc-gen-go generates for enums.
+package pb
+
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+	Status_STATUS_SUSPENDED   Status = 2
+	Status_STATUS_DELETED     Status = 3
+	// status_STATUS_PURGED is internal to pb and cannot be converted from other packages.
+	status_STATUS_PURGED Status = 4
+)
+
+type Role int32
+
+const (
+	Role_ROLE_UNSPECIFIED Role = 0
+	Role_ROLE_ADMIN       Role = 1
+	Role_ROLE_MEMBER      Role = 2
+	// Role_ROLE_OWNER is an alias kept for compatibility.
+	Role_ROLE_OWNER Role = 1
+)
+
+type User struct {
+	Name     string
+	Status   Status
+	Previous *Status
+	Roles    []Role
+	Labels   map[string]Status
+}
@@ -0,0 +1,15 @@
+//go:build gonverter
+
+package enum
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/enum/pb"
+)
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterEnum[pb.Status, Status](runtime.WithEnumFallback(StatusUnknown))
+var _ = runtime.RegisterEnum[Status, pb.Status](runtime.WithEnumFallback(pb.Status_STATUS_UNSPECIFIED))
+var _ = runtime.RegisterEnum[pb.Role, Role](runtime.WithEnumPrefix("Role_ROLE_", "Perm"))
+var _ = runtime.Register[*pb.User, *User](runtime.WithConstructor())
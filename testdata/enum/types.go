@@ -0,0 +1,24 @@
+package enum
+
+type Status string
+
+const (
+	StatusUnknown   Status = "unknown"
+	StatusActive    Status = "active"
+	StatusSuspended Status = "suspended"
+)
+
+type Role int
+
+const (
+	PermAdmin Role = iota + 1
+	PermMember
+)
+
+type User struct {
+	Name     string
+	Status   Status
+	Previous *Status
+	Roles    []Role
+	Labels   map[string]Status
+}
@@ -0,0 +1,74 @@
+// Package domain holds value objects that can only be created through their
+// constructors.
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+type UserID string
+
+// Email is a validated email address.
+type Email struct {
+	address string
+}
+
+func NewEmail(address string) (Email, error) {
+	if !strings.Contains(address, "@") {
+		return Email{}, errors.New("invalid email address")
+	}
+
+	return Email{address: address}, nil
+}
+
+func (e Email) String() string {
+	return e.address
+}
+
+type User struct {
+	id    UserID
+	name  string
+	email Email
+	age   int
+}
+
+func NewUser(id UserID, name string, email Email, age int) (*User, error) {
+	if name == "" {
+		return nil, errors.New("name is required")
+	}
+
+	return &User{id: id, name: name, email: email, age: age}, nil
+}
+
+func (u *User) ID() UserID   { return u.id }
+func (u *User) Name() string { return u.name }
+func (u *User) Email() Email { return u.email }
+func (u *User) Age() int     { return u.age }
+
+type Money struct {
+	amount   int64
+	currency string
+}
+
+func NewMoney(amount int64, currency string) Money {
+	return Money{amount: amount, currency: strings.ToUpper(currency)}
+}
+
+func (m Money) Amount() int64    { return m.amount }
+func (m Money) Currency() string { return m.currency }
+
+// Coupon is a discount code. Codes that are not known give no coupon.
+type Coupon struct {
+	code string
+}
+
+func LookupCoupon(code string) *Coupon {
+	if code == "" {
+		return nil
+	}
+
+	return &Coupon{code: strings.ToUpper(code)}
+}
+
+func (c *Coupon) Code() string { return c.code }
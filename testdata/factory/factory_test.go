@@ -0,0 +1,54 @@
+package factory
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/factory/domain"
+)
+
+func TestConvertSignupRequestToUser(t *testing.T) {
+	var dst domain.User
+
+	err := ConvertSignupRequestToUser(&SignupRequest{ID: "u1", Name: "jd", Email: "jd@example.com", Age: 30}, &dst)
+	if err != nil || dst.ID() != "u1" || dst.Name() != "jd" || dst.Email().String() != "jd@example.com" || dst.Age() != 30 {
+		t.Fatalf("ConvertSignupRequestToUser() = %+v, %v", dst, err)
+	}
+
+	// Errors of value factories name the field.
+	err = ConvertSignupRequestToUser(&SignupRequest{Name: "jd", Email: "jd"}, &dst)
+
+	var fieldErr *runtime.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Email" {
+		t.Errorf("error = %v, want a FieldError for Email", err)
+	}
+
+	// dst is left unchanged when the factory fails.
+	if err := ConvertSignupRequestToUser(&SignupRequest{Email: "x@example.com"}, &dst); err == nil || dst.Name() != "jd" {
+		t.Errorf("ConvertSignupRequestToUser(no name) = %+v, %v, want an error", dst, err)
+	}
+}
+
+func TestConvertPriceRequestToMoney(t *testing.T) {
+	var dst domain.Money
+
+	ConvertPriceRequestToMoney(&PriceRequest{Amount: 100, Currency: "jpy"}, &dst)
+
+	if dst.Amount() != 100 || dst.Currency() != "JPY" {
+		t.Errorf("ConvertPriceRequestToMoney() = %+v", dst)
+	}
+}
+
+func TestConvertCouponRequestToCoupon(t *testing.T) {
+	var dst domain.Coupon
+
+	if err := ConvertCouponRequestToCoupon(&CouponRequest{Code: "spring"}, &dst); err != nil || dst.Code() != "SPRING" {
+		t.Fatalf("ConvertCouponRequestToCoupon() = %+v, %v", dst, err)
+	}
+
+	// A nil pointer from the factory is an error and leaves dst unchanged.
+	if err := ConvertCouponRequestToCoupon(&CouponRequest{}, &dst); !errors.Is(err, runtime.ErrNilFactoryResult) || dst.Code() != "SPRING" {
+		t.Errorf("ConvertCouponRequestToCoupon(no code) = %+v, %v, want ErrNilFactoryResult", dst, err)
+	}
+}
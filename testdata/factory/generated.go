@@ -0,0 +1,75 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:e459b4e3d583b8faabd875f93797688f86472583ef3f987168537703549be44d
+// Checksum:      sha256:b03bc641615c58e93be2f2a43a42c7d88b4a3b513ea5f2e70410aaf299158a9b
+
+package factory
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/factory/domain"
+)
+
+// ConvertSignupRequestToUser converts SignupRequest to domain.User.
+// If src is nil, dst is left unchanged.
+func ConvertSignupRequestToUser(src *SignupRequest, dst *domain.User) error {
+	if src == nil {
+		return nil
+	}
+
+	email, err := domain.NewEmail(src.Email)
+	if err != nil {
+		return &runtime.FieldError{Path: "Email", Err: err}
+	}
+	user, err := domain.NewUser(domain.UserID(src.ID), src.Name, email, int(src.Age))
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return runtime.ErrNilFactoryResult
+	}
+
+	*dst = *user
+
+	return nil
+}
+
+// ConvertPriceRequestToMoney converts PriceRequest to domain.Money.
+// If src is nil, dst is left unchanged.
+func ConvertPriceRequestToMoney(src *PriceRequest, dst *domain.Money) {
+	if src == nil {
+		return
+	}
+
+	*dst = domain.NewMoney(src.Amount, src.Currency)
+}
+
+// ConvertCouponRequestToCoupon converts CouponRequest to domain.Coupon.
+// If src is nil, dst is left unchanged.
+func ConvertCouponRequestToCoupon(src *CouponRequest, dst *domain.Coupon) error {
+	if src == nil {
+		return nil
+	}
+
+	coupon := domain.LookupCoupon(src.Code)
+	if coupon == nil {
+		return runtime.ErrNilFactoryResult
+	}
+
+	*dst = *coupon
+
+	return nil
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddFallibleConversion(s, ConvertSignupRequestToUser)
+	runtime.AddConversion(s, ConvertPriceRequestToMoney)
+	runtime.AddFallibleConversion(s, ConvertCouponRequestToCoupon)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,14 @@
+//go:build gonverter
+
+package factory
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/factory/domain"
+)
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register[*SignupRequest, *domain.User](runtime.WithFactory(domain.NewUser, domain.NewEmail))
+var _ = runtime.Register[*PriceRequest, *domain.Money](runtime.WithFactory(domain.NewMoney))
+var _ = runtime.Register[*CouponRequest, *domain.Coupon](runtime.WithFactory(domain.LookupCoupon))
@@ -0,0 +1,17 @@
+package factory
+
+type SignupRequest struct {
+	ID    string
+	Name  string
+	Email string
+	Age   int32
+}
+
+type PriceRequest struct {
+	Amount   int64
+	Currency string
+}
+
+type CouponRequest struct {
+	Code string
+}
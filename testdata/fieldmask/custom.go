@@ -0,0 +1,7 @@
+package fieldmask
+
+import "strconv"
+
+func ConvertUserRequestScoreToUserScore(src *UserRequest, dst *User) {
+	dst.Score, _ = strconv.Atoi(src.Score)
+}
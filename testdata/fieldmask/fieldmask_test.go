@@ -0,0 +1,120 @@
+package fieldmask
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+)
+
+func request() *UserRequest {
+	return &UserRequest{
+		Name:        "john",
+		DisplayName: "John",
+		Address:     AddressRequest{Street: "2nd St", City: "Osaka", Geo: &GeoRequest{Lat: 34.7, Lng: 135.5}},
+		Contacts:    []ContactRequest{{Kind: "mail", Value: "new@example.com"}},
+		Settings:    map[string]SettingRequest{"news": {Value: "weekly", Enabled: true}},
+		Manager:     &Employee{Name: "Jane", Title: "CTO"},
+		Profile:     Profile{Bio: "new bio", Website: "https://new.example.com"},
+		Tags:        []string{"new"},
+		Score:       "42",
+	}
+}
+
+func existing() User {
+	return User{
+		Name:        "old",
+		DisplayName: "Old",
+		Address:     Address{Street: "1st St", City: "Tokyo"},
+		Contacts:    []Contact{{Kind: "phone", Value: "old@example.com"}},
+		Settings:    map[string]Setting{"news": {Value: "daily"}, "ads": {Value: "never"}},
+		Manager:     &Employee{Name: "Bob", Title: "VP"},
+		Profile:     Profile{Bio: "old bio", Website: "https://old.example.com"},
+		Tags:        []string{"old"},
+		Score:       1,
+	}
+}
+
+func TestEmptyMaskConvertsEverything(t *testing.T) {
+	var want, got User
+
+	ConvertUserRequestToUser(request(), &want)
+
+	if err := ConvertUserRequestToUserWithMask(request(), &got, runtime.FieldMask{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMaskSelectsFields(t *testing.T) {
+	dst := existing()
+	mask := runtime.NewFieldMask(
+		"display_name",
+		"address.city",
+		"address.geo.lat",
+		"contacts.value",
+		"settings.enabled",
+		"manager.title",
+		"profile.bio",
+		"score",
+	)
+
+	if err := ConvertUserRequestToUserWithMask(request(), &dst, mask); err != nil {
+		t.Fatal(err)
+	}
+
+	want := existing()
+	want.DisplayName = "John"
+	want.Address = Address{Street: "1st St", City: "Osaka", Geo: &Geo{Lat: 34.7}}
+	want.Contacts = []Contact{{Kind: "phone", Value: "new@example.com"}}
+	want.Settings = map[string]Setting{"news": {Value: "daily", Enabled: true}, "ads": {Value: "never"}}
+	want.Manager = &Employee{Name: "Bob", Title: "CTO"}
+	want.Profile = Profile{Bio: "new bio", Website: "https://old.example.com"}
+	want.Score = 42
+
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("got %+v, want %+v", dst, want)
+	}
+}
+
+func TestMaskSelectsWholeField(t *testing.T) {
+	dst := existing()
+
+	if err := ConvertUserRequestToUserWithMask(request(), &dst, runtime.NewFieldMask("Settings", "Settings.Value")); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]Setting{"news": {Value: "weekly", Enabled: true}}
+	if !reflect.DeepEqual(dst.Settings, want) {
+		t.Errorf("Settings = %v, want %v", dst.Settings, want)
+	}
+}
+
+func TestMaskUnknownPath(t *testing.T) {
+	for _, path := range []string{"age", "name.first", "address.zip", "address.geo.alt"} {
+		dst := existing()
+
+		// Paths are checked against the destination type, whatever the data.
+		src := request()
+		src.Address.Geo = nil
+
+		err := ConvertUserRequestToUserWithMask(src, &dst, runtime.NewFieldMask("name", path))
+		if !errors.Is(err, runtime.ErrUnknownFieldPath) {
+			t.Errorf("%s: error = %v, want ErrUnknownFieldPath", path, err)
+
+			continue
+		}
+
+		if want := "unknown field path: " + path; err.Error() != want {
+			t.Errorf("error = %q, want %q", err, want)
+		}
+
+		if !reflect.DeepEqual(dst, existing()) {
+			t.Errorf("%s: dst changed to %+v", path, dst)
+		}
+	}
+}
@@ -0,0 +1,462 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:b2e088673c270bc831e6c5417391d9622d9231c95b22387bccfb395bf90ce6e7
+// Checksum:      sha256:91de73b0a21126dc7176b804300e056a577dee559f0a17cfe66ba72f66e11207
+
+package fieldmask
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertUserRequestToUser converts UserRequest to User.
+// If src is nil, dst is left unchanged.
+func ConvertUserRequestToUser(src *UserRequest, dst *User) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	dst.DisplayName = src.DisplayName
+	ConvertAddressRequestToAddress(&src.Address, &dst.Address)
+	if src.Contacts != nil {
+		dst.Contacts = make([]Contact, len(src.Contacts))
+		for i := range src.Contacts {
+			ConvertContactRequestToContact(&src.Contacts[i], &dst.Contacts[i])
+		}
+	}
+	if src.Settings != nil {
+		dst.Settings = make(map[string]Setting, len(src.Settings))
+		for k, v := range src.Settings {
+			var converted Setting
+			ConvertSettingRequestToSetting(&v, &converted)
+			dst.Settings[k] = converted
+		}
+	}
+	dst.Manager = src.Manager
+	dst.Profile = src.Profile
+	dst.Tags = src.Tags
+	ConvertUserRequestScoreToUserScore(src, dst)
+}
+
+// ConvertUserRequestToUserWithMask converts the fields selected by mask from UserRequest to User.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertUserRequestToUserWithMask(src *UserRequest, dst *User, mask runtime.FieldMask) error {
+	fields, err := splitUserRequestToUserMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["Name"]; ok {
+		dst.Name = src.Name
+	}
+	if _, ok := fields["DisplayName"]; ok {
+		dst.DisplayName = src.DisplayName
+	}
+	if m, ok := fields["Address"]; ok && m.All() {
+		ConvertAddressRequestToAddress(&src.Address, &dst.Address)
+	} else if ok {
+		if err := ConvertAddressRequestToAddressWithMask(&src.Address, &dst.Address, m); err != nil {
+			return err
+		}
+	}
+	if m, ok := fields["Contacts"]; ok && m.All() {
+		if src.Contacts != nil {
+			dst.Contacts = make([]Contact, len(src.Contacts))
+			for i := range src.Contacts {
+				ConvertContactRequestToContact(&src.Contacts[i], &dst.Contacts[i])
+			}
+		}
+	} else if ok {
+		if src.Contacts != nil {
+			elems := make([]Contact, len(src.Contacts))
+			copy(elems, dst.Contacts)
+			for i := range src.Contacts {
+				if err := ConvertContactRequestToContactWithMask(&src.Contacts[i], &elems[i], m); err != nil {
+					return err
+				}
+			}
+			dst.Contacts = elems
+		}
+	}
+	if m, ok := fields["Settings"]; ok && m.All() {
+		if src.Settings != nil {
+			dst.Settings = make(map[string]Setting, len(src.Settings))
+			for k, v := range src.Settings {
+				var converted Setting
+				ConvertSettingRequestToSetting(&v, &converted)
+				dst.Settings[k] = converted
+			}
+		}
+	} else if ok {
+		if src.Settings != nil {
+			if dst.Settings == nil {
+				dst.Settings = make(map[string]Setting, len(src.Settings))
+			}
+			for k, v := range src.Settings {
+				converted := dst.Settings[k]
+				if err := ConvertSettingRequestToSettingWithMask(&v, &converted, m); err != nil {
+					return err
+				}
+				dst.Settings[k] = converted
+			}
+		}
+	}
+	if m, ok := fields["Manager"]; ok && m.All() {
+		dst.Manager = src.Manager
+	} else if ok {
+		if src.Manager != nil {
+			if dst.Manager == nil {
+				dst.Manager = new(Employee)
+			}
+			if err := ConvertEmployeeToEmployeeWithMask(src.Manager, dst.Manager, m); err != nil {
+				return err
+			}
+		}
+	}
+	if m, ok := fields["Profile"]; ok && m.All() {
+		dst.Profile = src.Profile
+	} else if ok {
+		if err := ConvertProfileToProfileWithMask(&src.Profile, &dst.Profile, m); err != nil {
+			return err
+		}
+	}
+	if _, ok := fields["Tags"]; ok {
+		dst.Tags = src.Tags
+	}
+	if _, ok := fields["Score"]; ok {
+		ConvertUserRequestScoreToUserScore(src, dst)
+	}
+
+	return nil
+}
+
+// splitUserRequestToUserMask splits mask by the fields of User and checks the paths below them.
+func splitUserRequestToUserMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"Name", "DisplayName", "Tags", "Score"}, []string{"Address", "Contacts", "Settings", "Manager", "Profile"})
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := fields["Address"]; ok && !m.All() {
+		if _, err := splitAddressRequestToAddressMask(m); err != nil {
+			return nil, err
+		}
+	}
+	if m, ok := fields["Contacts"]; ok && !m.All() {
+		if _, err := splitContactRequestToContactMask(m); err != nil {
+			return nil, err
+		}
+	}
+	if m, ok := fields["Settings"]; ok && !m.All() {
+		if _, err := splitSettingRequestToSettingMask(m); err != nil {
+			return nil, err
+		}
+	}
+	if m, ok := fields["Manager"]; ok && !m.All() {
+		if _, err := splitEmployeeToEmployeeMask(m); err != nil {
+			return nil, err
+		}
+	}
+	if m, ok := fields["Profile"]; ok && !m.All() {
+		if _, err := splitProfileToProfileMask(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
+// ConvertAddressRequestToAddress converts AddressRequest to Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressRequestToAddress(src *AddressRequest, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.Street = src.Street
+	dst.City = src.City
+	if src.Geo != nil {
+		dst.Geo = new(Geo)
+		ConvertGeoRequestToGeo(src.Geo, dst.Geo)
+	}
+}
+
+// ConvertContactRequestToContact converts ContactRequest to Contact.
+// If src is nil, dst is left unchanged.
+func ConvertContactRequestToContact(src *ContactRequest, dst *Contact) {
+	if src == nil {
+		return
+	}
+
+	dst.Kind = src.Kind
+	dst.Value = src.Value
+}
+
+// ConvertSettingRequestToSetting converts SettingRequest to Setting.
+// If src is nil, dst is left unchanged.
+func ConvertSettingRequestToSetting(src *SettingRequest, dst *Setting) {
+	if src == nil {
+		return
+	}
+
+	dst.Value = src.Value
+	dst.Enabled = src.Enabled
+}
+
+// ConvertAddressRequestToAddressWithMask converts the fields selected by mask from AddressRequest to Address.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertAddressRequestToAddressWithMask(src *AddressRequest, dst *Address, mask runtime.FieldMask) error {
+	fields, err := splitAddressRequestToAddressMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["Street"]; ok {
+		dst.Street = src.Street
+	}
+	if _, ok := fields["City"]; ok {
+		dst.City = src.City
+	}
+	if m, ok := fields["Geo"]; ok && m.All() {
+		if src.Geo != nil {
+			dst.Geo = new(Geo)
+			ConvertGeoRequestToGeo(src.Geo, dst.Geo)
+		}
+	} else if ok {
+		if src.Geo != nil {
+			if dst.Geo == nil {
+				dst.Geo = new(Geo)
+			}
+			if err := ConvertGeoRequestToGeoWithMask(src.Geo, dst.Geo, m); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// splitAddressRequestToAddressMask splits mask by the fields of Address and checks the paths below them.
+func splitAddressRequestToAddressMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"Street", "City"}, []string{"Geo"})
+	if err != nil {
+		return nil, err
+	}
+
+	if m, ok := fields["Geo"]; ok && !m.All() {
+		if _, err := splitGeoRequestToGeoMask(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return fields, nil
+}
+
+// ConvertContactRequestToContactWithMask converts the fields selected by mask from ContactRequest to Contact.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertContactRequestToContactWithMask(src *ContactRequest, dst *Contact, mask runtime.FieldMask) error {
+	fields, err := splitContactRequestToContactMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["Kind"]; ok {
+		dst.Kind = src.Kind
+	}
+	if _, ok := fields["Value"]; ok {
+		dst.Value = src.Value
+	}
+
+	return nil
+}
+
+// splitContactRequestToContactMask splits mask by the fields of Contact and checks the paths below them.
+func splitContactRequestToContactMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"Kind", "Value"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// ConvertSettingRequestToSettingWithMask converts the fields selected by mask from SettingRequest to Setting.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertSettingRequestToSettingWithMask(src *SettingRequest, dst *Setting, mask runtime.FieldMask) error {
+	fields, err := splitSettingRequestToSettingMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["Value"]; ok {
+		dst.Value = src.Value
+	}
+	if _, ok := fields["Enabled"]; ok {
+		dst.Enabled = src.Enabled
+	}
+
+	return nil
+}
+
+// splitSettingRequestToSettingMask splits mask by the fields of Setting and checks the paths below them.
+func splitSettingRequestToSettingMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"Value", "Enabled"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// ConvertEmployeeToEmployeeWithMask converts the fields selected by mask from Employee to Employee.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertEmployeeToEmployeeWithMask(src *Employee, dst *Employee, mask runtime.FieldMask) error {
+	fields, err := splitEmployeeToEmployeeMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["Name"]; ok {
+		dst.Name = src.Name
+	}
+	if _, ok := fields["Title"]; ok {
+		dst.Title = src.Title
+	}
+
+	return nil
+}
+
+// splitEmployeeToEmployeeMask splits mask by the fields of Employee and checks the paths below them.
+func splitEmployeeToEmployeeMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"Name", "Title"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// ConvertProfileToProfileWithMask converts the fields selected by mask from Profile to Profile.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertProfileToProfileWithMask(src *Profile, dst *Profile, mask runtime.FieldMask) error {
+	fields, err := splitProfileToProfileMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["Bio"]; ok {
+		dst.Bio = src.Bio
+	}
+	if _, ok := fields["Website"]; ok {
+		dst.Website = src.Website
+	}
+
+	return nil
+}
+
+// splitProfileToProfileMask splits mask by the fields of Profile and checks the paths below them.
+func splitProfileToProfileMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"Bio", "Website"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// ConvertGeoRequestToGeo converts GeoRequest to Geo.
+// If src is nil, dst is left unchanged.
+func ConvertGeoRequestToGeo(src *GeoRequest, dst *Geo) {
+	if src == nil {
+		return
+	}
+
+	dst.Lat = src.Lat
+	dst.Lng = src.Lng
+}
+
+// ConvertGeoRequestToGeoWithMask converts the fields selected by mask from GeoRequest to Geo.
+// An empty mask selects every field.
+// If src is nil, dst is left unchanged.
+// It returns an error wrapping runtime.ErrUnknownFieldPath if a path in mask names no field.
+func ConvertGeoRequestToGeoWithMask(src *GeoRequest, dst *Geo, mask runtime.FieldMask) error {
+	fields, err := splitGeoRequestToGeoMask(mask)
+	if err != nil {
+		return err
+	}
+
+	if src == nil {
+		return nil
+	}
+
+	if _, ok := fields["Lat"]; ok {
+		dst.Lat = src.Lat
+	}
+	if _, ok := fields["Lng"]; ok {
+		dst.Lng = src.Lng
+	}
+
+	return nil
+}
+
+// splitGeoRequestToGeoMask splits mask by the fields of Geo and checks the paths below them.
+func splitGeoRequestToGeoMask(mask runtime.FieldMask) (map[string]runtime.FieldMask, error) {
+	fields, err := mask.Split([]string{"Lat", "Lng"}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertAddressRequestToAddress)
+	runtime.AddConversion(s, ConvertContactRequestToContact)
+	runtime.AddConversion(s, ConvertSettingRequestToSetting)
+	runtime.AddConversion(s, ConvertGeoRequestToGeo)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,80 @@
+package fieldmask
+
+// Source types
+type UserRequest struct {
+	Name        string
+	DisplayName string
+	Address     AddressRequest
+	Contacts    []ContactRequest
+	Settings    map[string]SettingRequest
+	Manager     *Employee
+	Profile     Profile
+	Tags        []string
+	Score       string
+}
+
+type AddressRequest struct {
+	Street string
+	City   string
+	Geo    *GeoRequest
+}
+
+type GeoRequest struct {
+	Lat float64
+	Lng float64
+}
+
+type ContactRequest struct {
+	Kind  string
+	Value string
+}
+
+type SettingRequest struct {
+	Value   string
+	Enabled bool
+}
+
+// Target types
+type User struct {
+	Name        string
+	DisplayName string
+	Address     Address
+	Contacts    []Contact
+	Settings    map[string]Setting
+	Manager     *Employee
+	Profile     Profile
+	Tags        []string
+	Score       int
+}
+
+type Address struct {
+	Street string
+	City   string
+	Geo    *Geo
+}
+
+type Geo struct {
+	Lat float64
+	Lng float64
+}
+
+type Contact struct {
+	Kind  string
+	Value string
+}
+
+type Setting struct {
+	Value   string
+	Enabled bool
+}
+
+// Types shared by both sides
+type Employee struct {
+	Name  string
+	Title string
+}
+
+type Profile struct {
+	Bio     string
+	Website string
+}
@@ -0,0 +1,46 @@
+package hooks
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMissingEmail = errors.New("missing email")
+	ErrInvalidZip   = errors.New("invalid zip code")
+)
+
+// BeforeConvertUserRequestToUser rejects requests before any field is converted.
+func BeforeConvertUserRequestToUser(src *UserRequest, _ *User) error {
+	if src.Email == "" {
+		return ErrMissingEmail
+	}
+
+	return nil
+}
+
+// AfterConvertUserRequestToUser fills the fields that have no source field.
+func AfterConvertUserRequestToUser(_ *UserRequest, dst *User) {
+	dst.DisplayName = strings.TrimSpace(dst.FirstName + " " + dst.LastName)
+}
+
+// AfterConvertAddressRequestToAddress makes the conversion of users fallible too.
+func AfterConvertAddressRequestToAddress(_ *AddressRequest, dst *Address) error {
+	if len(dst.Zip) != 5 {
+		return ErrInvalidZip
+	}
+
+	return nil
+}
+
+// AfterConvertOrderToOrderDTO does not return an error, so neither does the conversion.
+func AfterConvertOrderToOrderDTO(src *Order, dst *OrderDTO) {
+	dst.ItemCount = len(src.Items)
+}
+
+// BeforeMidnight returns the time left before midnight. It is named like a
+// hook, but not after any generated function.
+func BeforeMidnight(t time.Time) time.Duration {
+	return t.Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(t)
+}
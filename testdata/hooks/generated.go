@@ -0,0 +1,107 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:c8c1cd2033ca4061eac87bedd342518f17739d22672e4b8169ee52351ea3802c
+// Checksum:      sha256:773c71e7dc9675960246e00b69d079a5b41194ee7892bedbec3569c0999a7726
+
+package hooks
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertUserRequestToUser converts UserRequest to User.
+// If src is nil, dst is left unchanged.
+func ConvertUserRequestToUser(src *UserRequest, dst *User) error {
+	if src == nil {
+		return nil
+	}
+
+	if err := BeforeConvertUserRequestToUser(src, dst); err != nil {
+		return err
+	}
+
+	dst.FirstName = src.FirstName
+	dst.LastName = src.LastName
+	dst.Email = src.Email
+	if err := ConvertAddressRequestToAddress(&src.Address, &dst.Address); err != nil {
+		return err
+	}
+
+	AfterConvertUserRequestToUser(src, dst)
+
+	return nil
+}
+
+// ToUser returns src converted to a new User, or nil if src is nil.
+func ToUser(src *UserRequest) (*User, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := new(User)
+	if err := ConvertUserRequestToUser(src, dst); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// ToUsers converts every element of src to User. It returns nil if src is nil,
+// and stops at the first error.
+func ToUsers(src []UserRequest) ([]User, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := make([]User, len(src))
+	for i := range src {
+		if err := ConvertUserRequestToUser(&src[i], &dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// ConvertOrderToOrderDTO converts Order to OrderDTO.
+// If src is nil, dst is left unchanged.
+func ConvertOrderToOrderDTO(src *Order, dst *OrderDTO) {
+	if src == nil {
+		return
+	}
+
+	dst.ID = src.ID
+	dst.Items = src.Items
+
+	AfterConvertOrderToOrderDTO(src, dst)
+}
+
+// ConvertAddressRequestToAddress converts AddressRequest to Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressRequestToAddress(src *AddressRequest, dst *Address) error {
+	if src == nil {
+		return nil
+	}
+
+	dst.City = src.City
+	dst.Zip = src.Zip
+
+	if err := AfterConvertAddressRequestToAddress(src, dst); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddFallibleConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertOrderToOrderDTO)
+	runtime.AddFallibleConversion(s, ConvertAddressRequestToAddress)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
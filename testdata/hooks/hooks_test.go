@@ -0,0 +1,82 @@
+package hooks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+)
+
+func TestConvertUserRequestToUser(t *testing.T) {
+	got, err := ToUser(&UserRequest{
+		FirstName: "John",
+		LastName:  "Doe",
+		Email:     "john@example.com",
+		Address:   AddressRequest{City: "Tokyo", Zip: "10001"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := User{
+		FirstName:   "John",
+		LastName:    "Doe",
+		Email:       "john@example.com",
+		DisplayName: "John Doe",
+		Address:     Address{City: "Tokyo", Zip: "10001"},
+	}
+	if *got != want {
+		t.Errorf("ToUser() = %+v, want %+v", *got, want)
+	}
+}
+
+func TestConvertUserRequestToUserBeforeError(t *testing.T) {
+	var dst User
+
+	err := ConvertUserRequestToUser(&UserRequest{FirstName: "John"}, &dst)
+	if !errors.Is(err, ErrMissingEmail) {
+		t.Fatalf("error = %v, want ErrMissingEmail", err)
+	}
+
+	// The Before hook aborts the conversion before any field is set.
+	if dst != (User{}) {
+		t.Errorf("dst = %+v, want zero value", dst)
+	}
+}
+
+func TestConvertUserRequestToUserNestedAfterError(t *testing.T) {
+	req := UserRequest{FirstName: "John", Email: "john@example.com", Address: AddressRequest{Zip: "1"}}
+
+	var dst User
+	if err := ConvertUserRequestToUser(&req, &dst); !errors.Is(err, ErrInvalidZip) {
+		t.Fatalf("error = %v, want ErrInvalidZip", err)
+	}
+
+	// The error of the nested conversion skips the After hook of the parent.
+	if dst.DisplayName != "" {
+		t.Errorf("DisplayName = %q, want empty", dst.DisplayName)
+	}
+
+	if _, err := ToUsers([]UserRequest{{Email: "a@example.com", Address: AddressRequest{Zip: "12345"}}, req}); !errors.Is(err, ErrInvalidZip) {
+		t.Errorf("ToUsers() error = %v, want ErrInvalidZip", err)
+	}
+}
+
+func TestConvertOrderToOrderDTO(t *testing.T) {
+	var dst OrderDTO
+
+	ConvertOrderToOrderDTO(&Order{ID: "1", Items: []string{"a", "b"}}, &dst)
+
+	if dst.ID != "1" || dst.ItemCount != 2 {
+		t.Errorf("dst = %+v, want ID 1 and ItemCount 2", dst)
+	}
+}
+
+func TestSchemeConvertReturnsHookError(t *testing.T) {
+	var dst User
+
+	err := runtime.DefaultScheme.Convert(&UserRequest{}, &dst)
+	if !errors.Is(err, ErrMissingEmail) {
+		t.Errorf("Convert() error = %v, want ErrMissingEmail", err)
+	}
+}
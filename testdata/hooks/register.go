@@ -0,0 +1,10 @@
+//go:build gonverter
+
+package hooks
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register[*UserRequest, *User](runtime.WithConstructor())
+var _ = runtime.Register[*Order, *OrderDTO]()
@@ -0,0 +1,37 @@
+package hooks
+
+type UserRequest struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Address   AddressRequest
+}
+
+type AddressRequest struct {
+	City string
+	Zip  string
+}
+
+type User struct {
+	FirstName   string
+	LastName    string
+	Email       string
+	DisplayName string
+	Address     Address
+}
+
+type Address struct {
+	City string
+	Zip  string
+}
+
+type Order struct {
+	ID    string
+	Items []string
+}
+
+type OrderDTO struct {
+	ID        string
+	Items     []string
+	ItemCount int
+}
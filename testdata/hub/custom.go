@@ -0,0 +1,32 @@
+package hub
+
+import (
+	"strings"
+
+	v1 "github.com/sivchari/gonverter/testdata/hub/v1"
+	v2 "github.com/sivchari/gonverter/testdata/hub/v2"
+)
+
+// v1 has a single Name field, split into FirstName and LastName since v2.
+
+func ConvertV1UserFirstNameToUserFirstName(src *v1.User, dst *User) {
+	dst.FirstName, _, _ = strings.Cut(src.Name, " ")
+}
+
+func ConvertV1UserLastNameToUserLastName(src *v1.User, dst *User) {
+	_, dst.LastName, _ = strings.Cut(src.Name, " ")
+}
+
+func ConvertUserNameToV1UserName(src *User, dst *v1.User) {
+	dst.Name = strings.TrimSpace(src.FirstName + " " + src.LastName)
+}
+
+// Email was added in v3; older versions leave it empty.
+
+func ConvertV1UserEmailToUserEmail(_ *v1.User, dst *User) {
+	dst.Email = ""
+}
+
+func ConvertV2UserEmailToUserEmail(_ *v2.User, dst *User) {
+	dst.Email = ""
+}
@@ -0,0 +1,251 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:6b09b39ef68228ed78c693f5c9c979dd5b1135006c3c5f98ff3626b20d7a3a32
+// Checksum:      sha256:39e683400a7e3fdfdea65e251ce447ddcf697fd0acc656367849bbd11cd1fc3d
+
+package hub
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/hub/v1"
+	"github.com/sivchari/gonverter/testdata/hub/v2"
+	"github.com/sivchari/gonverter/testdata/hub/v3"
+)
+
+// ConvertV1UserToUser converts v1.User to User.
+// If src is nil, dst is left unchanged.
+func ConvertV1UserToUser(src *v1.User, dst *User) {
+	if src == nil {
+		return
+	}
+
+	ConvertV1UserFirstNameToUserFirstName(src, dst)
+	ConvertV1UserLastNameToUserLastName(src, dst)
+	ConvertV1UserEmailToUserEmail(src, dst)
+	ConvertV1AddressToAddress(&src.Address, &dst.Address)
+}
+
+// ConvertUserToV1User converts User to v1.User.
+// If src is nil, dst is left unchanged.
+func ConvertUserToV1User(src *User, dst *v1.User) {
+	if src == nil {
+		return
+	}
+
+	ConvertUserNameToV1UserName(src, dst)
+	ConvertAddressToV1Address(&src.Address, &dst.Address)
+}
+
+// ConvertV2UserToUser converts v2.User to User.
+// If src is nil, dst is left unchanged.
+func ConvertV2UserToUser(src *v2.User, dst *User) {
+	if src == nil {
+		return
+	}
+
+	dst.FirstName = src.FirstName
+	dst.LastName = src.LastName
+	ConvertV2UserEmailToUserEmail(src, dst)
+	ConvertV2AddressToAddress(&src.Address, &dst.Address)
+}
+
+// ConvertUserToV2User converts User to v2.User.
+// If src is nil, dst is left unchanged.
+func ConvertUserToV2User(src *User, dst *v2.User) {
+	if src == nil {
+		return
+	}
+
+	dst.FirstName = src.FirstName
+	dst.LastName = src.LastName
+	ConvertAddressToV2Address(&src.Address, &dst.Address)
+}
+
+// ConvertV3UserToUser converts v3.User to User.
+// If src is nil, dst is left unchanged.
+func ConvertV3UserToUser(src *v3.User, dst *User) {
+	if src == nil {
+		return
+	}
+
+	dst.FirstName = src.FirstName
+	dst.LastName = src.LastName
+	dst.Email = src.Email
+	if src.Address != nil {
+		ConvertV3AddressToAddress(src.Address, &dst.Address)
+	}
+}
+
+// ConvertUserToV3User converts User to v3.User.
+// If src is nil, dst is left unchanged.
+func ConvertUserToV3User(src *User, dst *v3.User) {
+	if src == nil {
+		return
+	}
+
+	dst.FirstName = src.FirstName
+	dst.LastName = src.LastName
+	dst.Email = src.Email
+	dst.Address = new(v3.Address)
+	ConvertAddressToV3Address(&src.Address, dst.Address)
+}
+
+// ConvertV1UserToV2User converts v1.User to v2.User.
+// If src is nil, dst is left unchanged.
+func ConvertV1UserToV2User(src *v1.User, dst *v2.User) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 User
+	ConvertV1UserToUser(src, &tmp0)
+	ConvertUserToV2User(&tmp0, dst)
+}
+
+// ConvertV1UserToV3User converts v1.User to v3.User.
+// If src is nil, dst is left unchanged.
+func ConvertV1UserToV3User(src *v1.User, dst *v3.User) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 User
+	ConvertV1UserToUser(src, &tmp0)
+	ConvertUserToV3User(&tmp0, dst)
+}
+
+// ConvertV2UserToV1User converts v2.User to v1.User.
+// If src is nil, dst is left unchanged.
+func ConvertV2UserToV1User(src *v2.User, dst *v1.User) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 User
+	ConvertV2UserToUser(src, &tmp0)
+	ConvertUserToV1User(&tmp0, dst)
+}
+
+// ConvertV2UserToV3User converts v2.User to v3.User.
+// If src is nil, dst is left unchanged.
+func ConvertV2UserToV3User(src *v2.User, dst *v3.User) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 User
+	ConvertV2UserToUser(src, &tmp0)
+	ConvertUserToV3User(&tmp0, dst)
+}
+
+// ConvertV3UserToV1User converts v3.User to v1.User.
+// If src is nil, dst is left unchanged.
+func ConvertV3UserToV1User(src *v3.User, dst *v1.User) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 User
+	ConvertV3UserToUser(src, &tmp0)
+	ConvertUserToV1User(&tmp0, dst)
+}
+
+// ConvertV3UserToV2User converts v3.User to v2.User.
+// If src is nil, dst is left unchanged.
+func ConvertV3UserToV2User(src *v3.User, dst *v2.User) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 User
+	ConvertV3UserToUser(src, &tmp0)
+	ConvertUserToV2User(&tmp0, dst)
+}
+
+// ConvertV1AddressToAddress converts v1.Address to Address.
+// If src is nil, dst is left unchanged.
+func ConvertV1AddressToAddress(src *v1.Address, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// ConvertAddressToV1Address converts Address to v1.Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressToV1Address(src *Address, dst *v1.Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// ConvertV2AddressToAddress converts v2.Address to Address.
+// If src is nil, dst is left unchanged.
+func ConvertV2AddressToAddress(src *v2.Address, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// ConvertAddressToV2Address converts Address to v2.Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressToV2Address(src *Address, dst *v2.Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// ConvertV3AddressToAddress converts v3.Address to Address.
+// If src is nil, dst is left unchanged.
+func ConvertV3AddressToAddress(src *v3.Address, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// ConvertAddressToV3Address converts Address to v3.Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressToV3Address(src *Address, dst *v3.Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertV1UserToUser)
+	runtime.AddConversion(s, ConvertUserToV1User)
+	runtime.AddConversion(s, ConvertV2UserToUser)
+	runtime.AddConversion(s, ConvertUserToV2User)
+	runtime.AddConversion(s, ConvertV3UserToUser)
+	runtime.AddConversion(s, ConvertUserToV3User)
+	runtime.AddConversion(s, ConvertV1UserToV2User)
+	runtime.AddConversion(s, ConvertV1UserToV3User)
+	runtime.AddConversion(s, ConvertV2UserToV1User)
+	runtime.AddConversion(s, ConvertV2UserToV3User)
+	runtime.AddConversion(s, ConvertV3UserToV1User)
+	runtime.AddConversion(s, ConvertV3UserToV2User)
+	runtime.AddConversion(s, ConvertV1AddressToAddress)
+	runtime.AddConversion(s, ConvertAddressToV1Address)
+	runtime.AddConversion(s, ConvertV2AddressToAddress)
+	runtime.AddConversion(s, ConvertAddressToV2Address)
+	runtime.AddConversion(s, ConvertV3AddressToAddress)
+	runtime.AddConversion(s, ConvertAddressToV3Address)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
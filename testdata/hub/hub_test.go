@@ -0,0 +1,77 @@
+package hub
+
+import (
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+	v1 "github.com/sivchari/gonverter/testdata/hub/v1"
+	v2 "github.com/sivchari/gonverter/testdata/hub/v2"
+	v3 "github.com/sivchari/gonverter/testdata/hub/v3"
+)
+
+func TestSpokeToHub(t *testing.T) {
+	src := &v1.User{Name: "John Doe", Address: v1.Address{City: "Tokyo"}}
+
+	var dst User
+	ConvertV1UserToUser(src, &dst)
+
+	if dst.FirstName != "John" || dst.LastName != "Doe" {
+		t.Errorf("name = %q %q, want %q %q", dst.FirstName, dst.LastName, "John", "Doe")
+	}
+
+	if dst.Address.City != "Tokyo" {
+		t.Errorf("Address.City = %q, want %q", dst.Address.City, "Tokyo")
+	}
+}
+
+func TestComposedThroughHub(t *testing.T) {
+	t.Run("v1 to v3", func(t *testing.T) {
+		src := &v1.User{Name: "John Doe", Address: v1.Address{City: "Tokyo"}}
+
+		var dst v3.User
+		ConvertV1UserToV3User(src, &dst)
+
+		if dst.FirstName != "John" || dst.LastName != "Doe" {
+			t.Errorf("name = %q %q, want %q %q", dst.FirstName, dst.LastName, "John", "Doe")
+		}
+
+		if dst.Address == nil || dst.Address.City != "Tokyo" {
+			t.Errorf("Address = %+v, want City %q", dst.Address, "Tokyo")
+		}
+	})
+
+	t.Run("v3 to v1", func(t *testing.T) {
+		src := &v3.User{FirstName: "Jane", LastName: "Smith", Email: "jane@example.com", Address: &v3.Address{City: "Osaka"}}
+
+		var dst v1.User
+		ConvertV3UserToV1User(src, &dst)
+
+		if dst.Name != "Jane Smith" {
+			t.Errorf("Name = %q, want %q", dst.Name, "Jane Smith")
+		}
+
+		if dst.Address.City != "Osaka" {
+			t.Errorf("Address.City = %q, want %q", dst.Address.City, "Osaka")
+		}
+	})
+
+	t.Run("nil source", func(t *testing.T) {
+		dst := v2.User{FirstName: "keep"}
+		ConvertV1UserToV2User(nil, &dst)
+
+		if dst.FirstName != "keep" {
+			t.Errorf("FirstName = %q, want %q", dst.FirstName, "keep")
+		}
+	})
+}
+
+func TestSchemeConvertsBetweenVersions(t *testing.T) {
+	got, err := runtime.Convert[*v2.User, *v1.User](&v2.User{FirstName: "John", LastName: "Doe"})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if got.Name != "John Doe" {
+		t.Errorf("Name = %q, want %q", got.Name, "John Doe")
+	}
+}
@@ -0,0 +1,14 @@
+//go:build gonverter
+
+package hub
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	v1 "github.com/sivchari/gonverter/testdata/hub/v1"
+	v2 "github.com/sivchari/gonverter/testdata/hub/v2"
+	v3 "github.com/sivchari/gonverter/testdata/hub/v3"
+)
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterHub[User](v1.User{}, v2.User{}, v3.User{})
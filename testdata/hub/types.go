@@ -0,0 +1,13 @@
+package hub
+
+// User is the internal hub type all API versions convert through.
+type User struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Address   Address
+}
+
+type Address struct {
+	City string
+}
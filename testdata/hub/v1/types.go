@@ -0,0 +1,10 @@
+package v1
+
+type User struct {
+	Name    string
+	Address Address
+}
+
+type Address struct {
+	City string
+}
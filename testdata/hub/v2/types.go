@@ -0,0 +1,11 @@
+package v2
+
+type User struct {
+	FirstName string
+	LastName  string
+	Address   Address
+}
+
+type Address struct {
+	City string
+}
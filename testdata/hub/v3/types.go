@@ -0,0 +1,12 @@
+package v3
+
+type User struct {
+	FirstName string
+	LastName  string
+	Email     string
+	Address   *Address
+}
+
+type Address struct {
+	City string
+}
@@ -0,0 +1,9 @@
+package invalid
+
+// AfterConvertSourceToTarget is named after a generated function but does not
+// take its arguments.
+func AfterConvertSourceToTarget(_ *Target) {}
+
+// BeforeConvertSourceToTarget is named after a generated function but takes
+// arguments of other types.
+func BeforeConvertSourceToTarget(_ int, _ string) {}
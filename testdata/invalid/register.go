@@ -0,0 +1,14 @@
+//go:build gonverter
+
+package invalid
+
+import "github.com/sivchari/gonverter/runtime"
+
+var _ = runtime.Register[*Source, *Target]()
+var _ = runtime.Register[*Source, *Scalar]()
+var _ = runtime.RegisterHub[Target](Target{})
+var _ = runtime.RegisterPath[*Target, *Source]()
+var _ = runtime.Register[*Source, *Target](runtime.Option{})
+var _ = runtime.RegisterPatch[Source, Scalar](runtime.WithResetDst())
+var _ = runtime.Register[*AccountRequest, *Account](runtime.WithAccessors(), runtime.WithFieldMask())
+var _ = runtime.Register[*Window, *WindowDTO]()
@@ -0,0 +1,51 @@
+package invalid
+
+import "time"
+
+// Source is the source type for conversion
+type Source struct {
+	Name  string
+	Count string
+}
+
+// Target has a field with an incompatible type and a field missing from Source
+type Target struct {
+	Name  string
+	Count int
+	Extra string
+}
+
+// Scalar is not a struct and cannot be registered
+type Scalar int
+
+// AccountRequest is converted to Account with a field mask
+type AccountRequest struct {
+	Profile Profile
+}
+
+// Account keeps its profile behind a setter, which cannot keep the fields a
+// mask leaves out
+type Account struct {
+	profile Profile
+}
+
+// SetProfile sets the profile of the account
+func (a *Account) SetProfile(profile Profile) {
+	a.profile = profile
+}
+
+// Profile is selected in part by field masks
+type Profile struct {
+	Name string
+	Bio  string
+}
+
+// Window opens at a time, which WindowDTO keeps behind a pointer
+type Window struct {
+	Opens time.Time
+}
+
+// WindowDTO needs BuiltinPointers to be converted from Window
+type WindowDTO struct {
+	Opens *time.Time
+}
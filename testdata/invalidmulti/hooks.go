@@ -0,0 +1,5 @@
+package invalidmulti
+
+// AfterConvertBodyAndPathToMember is named after a generated function but
+// does not take a src per source.
+func AfterConvertBodyAndPathToMember(_ *Body, _ *Member) {}
@@ -0,0 +1,8 @@
+//go:build gonverter
+
+package invalidmulti
+
+import "github.com/sivchari/gonverter/runtime"
+
+var _ = runtime.Register2[*Body, *Path, *Member](runtime.WithPrecedence(Query{}))
+var _ = runtime.Register[*Scalar, *Member]()
@@ -0,0 +1,23 @@
+package invalidmulti
+
+// Body is a source of the many-to-one conversion
+type Body struct {
+	Name string
+}
+
+// Path is a source of the many-to-one conversion
+type Path struct {
+	ID string
+}
+
+// Member is the destination of the many-to-one conversion
+type Member struct {
+	ID   string
+	Name string
+}
+
+// Query is not a source of the many-to-one conversion
+type Query struct{}
+
+// Scalar is not a struct and cannot be converted from
+type Scalar int
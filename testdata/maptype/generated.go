@@ -1,8 +1,18 @@
 // Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:bd2ea5770e6f77c82511d2b74ce6b719a4182af98b0c423067c7bee3853289e9
+// Checksum:      sha256:46cd92074d6b1f970ea40d137ca3596269371404068e3f1f30cc9d4f611dda4b
 
 package maptype
 
-// ConvertConfigRequestToConfig converts ConfigRequest to Config
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertConfigRequestToConfig converts ConfigRequest to Config.
+// If src is nil, dst is left unchanged.
 func ConvertConfigRequestToConfig(src *ConfigRequest, dst *Config) {
 	if src == nil {
 		return
@@ -19,7 +29,8 @@ func ConvertConfigRequestToConfig(src *ConfigRequest, dst *Config) {
 	}
 }
 
-// ConvertSettingRequestToSetting converts SettingRequest to Setting
+// ConvertSettingRequestToSetting converts SettingRequest to Setting.
+// If src is nil, dst is left unchanged.
 func ConvertSettingRequestToSetting(src *SettingRequest, dst *Setting) {
 	if src == nil {
 		return
@@ -28,3 +39,13 @@ func ConvertSettingRequestToSetting(src *SettingRequest, dst *Setting) {
 	dst.Value = src.Value
 	dst.Enabled = src.Enabled
 }
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertConfigRequestToConfig)
+	runtime.AddConversion(s, ConvertSettingRequestToSetting)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
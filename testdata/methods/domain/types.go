@@ -0,0 +1,10 @@
+package domain
+
+type User struct {
+	Name  string
+	Email string
+}
+
+type Profile struct {
+	Bio string
+}
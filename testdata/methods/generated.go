@@ -0,0 +1,85 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:e8ac2704e83c3b3cfb6ba16e4fbcdf7a1b97311a228ac3e67fe6c418aed8564a
+// Checksum:      sha256:32abf82f2b584b046a959ee5dba7289c2f5849377ade20ef3cf3e4e447c5a121
+
+package methods
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/methods/domain"
+)
+
+// ConvertUserRequestToUser converts UserRequest to domain.User.
+// If src is nil, dst is left unchanged.
+func ConvertUserRequestToUser(src *UserRequest, dst *domain.User) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	dst.Email = src.Email
+}
+
+// ToDomain returns r converted to a new domain.User, or nil if r is nil.
+func (r *UserRequest) ToDomain() *domain.User {
+	if r == nil {
+		return nil
+	}
+
+	dst := new(domain.User)
+	ConvertUserRequestToUser(r, dst)
+
+	return dst
+}
+
+// FromDomain sets r from src.
+func (r *UserRequest) FromDomain(src *domain.User) {
+	ConvertUserToUserRequest(src, r)
+}
+
+// ConvertUserToUserRequest converts domain.User to UserRequest.
+// If src is nil, dst is left unchanged.
+func ConvertUserToUserRequest(src *domain.User, dst *UserRequest) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	dst.Email = src.Email
+}
+
+// ConvertProfileRequestToProfile converts ProfileRequest to domain.Profile.
+// If src is nil, dst is left unchanged.
+func ConvertProfileRequestToProfile(src *ProfileRequest, dst *domain.Profile) {
+	if src == nil {
+		return
+	}
+
+	dst.Bio = src.Bio
+}
+
+// AsProfile returns r converted to a new domain.Profile, or nil if r is nil.
+func (r *ProfileRequest) AsProfile() *domain.Profile {
+	if r == nil {
+		return nil
+	}
+
+	dst := new(domain.Profile)
+	ConvertProfileRequestToProfile(r, dst)
+
+	return dst
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertUserToUserRequest)
+	runtime.AddConversion(s, ConvertProfileRequestToProfile)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,37 @@
+package methods
+
+import (
+	"testing"
+
+	"github.com/sivchari/gonverter/testdata/methods/domain"
+)
+
+func TestToMethod(t *testing.T) {
+	req := &UserRequest{Name: "John", Email: "john@example.com"}
+
+	user := req.ToDomain()
+	if user == nil || user.Name != "John" || user.Email != "john@example.com" {
+		t.Errorf("ToDomain() = %+v", user)
+	}
+
+	var nilReq *UserRequest
+	if got := nilReq.ToDomain(); got != nil {
+		t.Errorf("ToDomain() on nil = %+v, want nil", got)
+	}
+}
+
+func TestFromMethod(t *testing.T) {
+	var req UserRequest
+	req.FromDomain(&domain.User{Name: "Jane", Email: "jane@example.com"})
+
+	if req.Name != "Jane" || req.Email != "jane@example.com" {
+		t.Errorf("FromDomain() = %+v", req)
+	}
+}
+
+func TestMethodNamePattern(t *testing.T) {
+	profile := (&ProfileRequest{Bio: "hello"}).AsProfile()
+	if profile == nil || profile.Bio != "hello" {
+		t.Errorf("AsProfile() = %+v", profile)
+	}
+}
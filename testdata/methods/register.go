@@ -0,0 +1,13 @@
+//go:build gonverter
+
+package methods
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+	"github.com/sivchari/gonverter/testdata/methods/domain"
+)
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register[*UserRequest, *domain.User](runtime.WithMethods())
+var _ = runtime.Register[*ProfileRequest, *domain.Profile](runtime.WithMethodNames("As{Type}", ""))
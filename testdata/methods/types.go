@@ -0,0 +1,12 @@
+package methods
+
+// UserRequest gets ToDomain and FromDomain methods.
+type UserRequest struct {
+	Name  string
+	Email string
+}
+
+// ProfileRequest gets an AsProfile method only.
+type ProfileRequest struct {
+	Bio string
+}
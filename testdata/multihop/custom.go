@@ -0,0 +1,12 @@
+package multihop
+
+// ConvertDomainUserNicknameToDBUserNickname leaves Nickname empty; the domain does not track it.
+func ConvertDomainUserNicknameToDBUserNickname(_ *DomainUser, dst *DBUser) {
+	dst.Nickname = ""
+}
+
+// ConvertDBUserToRow is hand-written and used as a step of routes ending in Row.
+func ConvertDBUserToRow(src *DBUser, dst *Row) {
+	dst.Key = "user:" + src.ID
+	dst.Name = src.Name
+}
@@ -0,0 +1,73 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:51bc67e18c70cc8c8d361597f250d8ad69d71c78bf6ecf0a82381f0ac2a1bb02
+// Checksum:      sha256:e4c783738dc0e1f8d4b72e110eb6556922ad095e1d733fe243f3f433dbaeffad
+
+package multihop
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertAPIUserToDomainUser converts APIUser to DomainUser.
+// If src is nil, dst is left unchanged.
+func ConvertAPIUserToDomainUser(src *APIUser, dst *DomainUser) {
+	if src == nil {
+		return
+	}
+
+	dst.ID = src.ID
+	dst.Name = src.Name
+}
+
+// ConvertDomainUserToDBUser converts DomainUser to DBUser.
+// If src is nil, dst is left unchanged.
+func ConvertDomainUserToDBUser(src *DomainUser, dst *DBUser) {
+	if src == nil {
+		return
+	}
+
+	dst.ID = src.ID
+	dst.Name = src.Name
+	ConvertDomainUserNicknameToDBUserNickname(src, dst)
+}
+
+// ConvertAPIUserToDBUser converts APIUser to DBUser.
+// If src is nil, dst is left unchanged.
+func ConvertAPIUserToDBUser(src *APIUser, dst *DBUser) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 DomainUser
+	ConvertAPIUserToDomainUser(src, &tmp0)
+	ConvertDomainUserToDBUser(&tmp0, dst)
+}
+
+// ConvertAPIUserToRow converts APIUser to Row.
+// If src is nil, dst is left unchanged.
+func ConvertAPIUserToRow(src *APIUser, dst *Row) {
+	if src == nil {
+		return
+	}
+
+	var tmp0 DomainUser
+	ConvertAPIUserToDomainUser(src, &tmp0)
+	var tmp1 DBUser
+	ConvertDomainUserToDBUser(&tmp0, &tmp1)
+	ConvertDBUserToRow(&tmp1, dst)
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertAPIUserToDomainUser)
+	runtime.AddConversion(s, ConvertDomainUserToDBUser)
+	runtime.AddConversion(s, ConvertAPIUserToDBUser)
+	runtime.AddConversion(s, ConvertAPIUserToRow)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
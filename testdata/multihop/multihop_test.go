@@ -0,0 +1,28 @@
+package multihop
+
+import "testing"
+
+func TestRegisterPath(t *testing.T) {
+	src := &APIUser{ID: "1", Name: "John", Nickname: "johnny"}
+
+	var dst DBUser
+	ConvertAPIUserToDBUser(src, &dst)
+
+	if dst.ID != "1" || dst.Name != "John" {
+		t.Errorf("got %+v, want ID %q and Name %q", dst, "1", "John")
+	}
+
+	// Nickname is dropped by DomainUser on the way.
+	if dst.Nickname != "" {
+		t.Errorf("Nickname = %q, want empty", dst.Nickname)
+	}
+}
+
+func TestRegisterVia(t *testing.T) {
+	var dst Row
+	ConvertAPIUserToRow(&APIUser{ID: "2", Name: "Jane"}, &dst)
+
+	if dst.Key != "user:2" || dst.Name != "Jane" {
+		t.Errorf("got %+v, want Key %q and Name %q", dst, "user:2", "Jane")
+	}
+}
@@ -0,0 +1,12 @@
+//go:build gonverter
+
+package multihop
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register[*APIUser, *DomainUser]()
+var _ = runtime.Register[*DomainUser, *DBUser]()
+var _ = runtime.RegisterPath[*APIUser, *DBUser]()
+var _ = runtime.RegisterVia[*APIUser, *Row, *DBUser]()
@@ -0,0 +1,27 @@
+package multihop
+
+// APIUser is received from clients.
+type APIUser struct {
+	ID       string
+	Name     string
+	Nickname string
+}
+
+// DomainUser has no Nickname, so routes through it lose that field.
+type DomainUser struct {
+	ID   string
+	Name string
+}
+
+// DBUser is stored in the database.
+type DBUser struct {
+	ID       string
+	Name     string
+	Nickname string
+}
+
+// Row is a generic key-value record.
+type Row struct {
+	Key  string
+	Name string
+}
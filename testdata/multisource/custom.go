@@ -0,0 +1,23 @@
+package multisource
+
+import "errors"
+
+// ErrUnauthenticated is returned when a member is added without a caller.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// ConvertBodyAndPathAndPrincipalCreatedByToMemberCreatedBy fills the field no
+// source has from the caller.
+func ConvertBodyAndPathAndPrincipalCreatedByToMemberCreatedBy(_ *Body, _ *Path, principal *Principal, dst *Member) {
+	if principal != nil {
+		dst.CreatedBy = principal.UserID
+	}
+}
+
+// AfterConvertBodyAndPathAndPrincipalToMember rejects members added without a caller.
+func AfterConvertBodyAndPathAndPrincipalToMember(_ *Body, _ *Path, principal *Principal, _ *Member) error {
+	if principal == nil {
+		return ErrUnauthenticated
+	}
+
+	return nil
+}
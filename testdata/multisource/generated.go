@@ -0,0 +1,70 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:8bea86a03d9388f679a293dab983ae4dfbcdfdc7ccb6d9888903111517e402f8
+// Checksum:      sha256:d11a1c5b088584e95dccc18188d00d0eb76dc620de27e761d45118845e5d96c8
+
+package multisource
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertBodyAndPathAndPrincipalToMember converts Body, Path and Principal to Member.
+// Fields found in several sources are taken from body, then path, then principal.
+// A nil source leaves the fields taken from it unchanged.
+func ConvertBodyAndPathAndPrincipalToMember(body *Body, path *Path, principal *Principal, dst *Member) error {
+	if body != nil {
+		dst.Name = body.Name
+		dst.Email = body.Email
+		dst.Role = body.Role
+		ConvertAddressToMemberAddress(&body.Address, &dst.Address)
+	}
+
+	if path != nil {
+		dst.TenantID = path.TenantID
+	}
+
+	ConvertBodyAndPathAndPrincipalCreatedByToMemberCreatedBy(body, path, principal, dst)
+
+	if err := AfterConvertBodyAndPathAndPrincipalToMember(body, path, principal, dst); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConvertBodyAndPathToInvite converts Body and Path to Invite.
+// Fields found in several sources are taken from path, then body.
+// A nil source leaves the fields taken from it unchanged.
+func ConvertBodyAndPathToInvite(body *Body, path *Path, dst *Invite) {
+	if body != nil {
+		dst.Email = body.Email
+	}
+
+	if path != nil {
+		dst.TenantID = path.TenantID
+		dst.Role = path.Role
+	}
+}
+
+// ConvertAddressToMemberAddress converts Address to MemberAddress.
+// If src is nil, dst is left unchanged.
+func ConvertAddressToMemberAddress(src *Address, dst *MemberAddress) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+	dst.Zip = src.Zip
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertAddressToMemberAddress)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
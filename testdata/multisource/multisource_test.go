@@ -0,0 +1,43 @@
+package multisource
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConvertBodyAndPathAndPrincipalToMember(t *testing.T) {
+	body := &Body{Name: "jd", Email: "jd@example.com", Role: "admin", Address: Address{City: "Tokyo", Zip: "100"}}
+	path := &Path{TenantID: "t1", Role: "viewer"}
+
+	var dst Member
+	if err := ConvertBodyAndPathAndPrincipalToMember(body, path, &Principal{UserID: "u1", TenantID: "t2"}, &dst); err != nil {
+		t.Fatalf("ConvertBodyAndPathAndPrincipalToMember() error = %v", err)
+	}
+
+	want := Member{TenantID: "t1", Name: "jd", Email: "jd@example.com", Role: "admin", Address: MemberAddress{City: "Tokyo", Zip: "100"}, CreatedBy: "u1"}
+	if dst != want {
+		t.Errorf("ConvertBodyAndPathAndPrincipalToMember() = %+v, want %+v", dst, want)
+	}
+
+	// A nil source leaves the fields taken from it unchanged, even if another
+	// source has them.
+	dst = Member{Role: "owner"}
+	if err := ConvertBodyAndPathAndPrincipalToMember(nil, path, &Principal{UserID: "u1"}, &dst); err != nil || dst.Role != "owner" || dst.TenantID != "t1" {
+		t.Errorf("ConvertBodyAndPathAndPrincipalToMember(nil body) = %+v, %v", dst, err)
+	}
+
+	// Hooks receive every source.
+	if err := ConvertBodyAndPathAndPrincipalToMember(body, path, nil, &dst); !errors.Is(err, ErrUnauthenticated) {
+		t.Errorf("ConvertBodyAndPathAndPrincipalToMember(nil principal) error = %v, want %v", err, ErrUnauthenticated)
+	}
+}
+
+func TestConvertBodyAndPathToInvite(t *testing.T) {
+	var dst Invite
+
+	ConvertBodyAndPathToInvite(&Body{Email: "jd@example.com", Role: "admin"}, &Path{TenantID: "t1", Role: "viewer"}, &dst)
+
+	if want := (Invite{TenantID: "t1", Email: "jd@example.com", Role: "viewer"}); dst != want {
+		t.Errorf("ConvertBodyAndPathToInvite() = %+v, want %+v", dst, want)
+	}
+}
@@ -0,0 +1,10 @@
+//go:build gonverter
+
+package multisource
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register3[*Body, *Path, *Principal, *Member]()
+var _ = runtime.Register2[*Body, *Path, *Invite](runtime.WithPrecedence(Path{}))
@@ -0,0 +1,51 @@
+package multisource
+
+// Body is the JSON body of a request adding a member to a team.
+type Body struct {
+	Name    string
+	Email   string
+	Role    string
+	Address Address
+}
+
+// Address is the address of a Body.
+type Address struct {
+	City string
+	Zip  string
+}
+
+// Path holds the path parameters of the request, as in
+// /tenants/{TenantID}/roles/{Role}/members.
+type Path struct {
+	TenantID string
+	Role     string
+}
+
+// Principal is the authenticated caller.
+type Principal struct {
+	UserID   string
+	TenantID string
+}
+
+// Member is built from the body, the path and the caller of the request.
+type Member struct {
+	TenantID  string
+	Name      string
+	Email     string
+	Role      string
+	Address   MemberAddress
+	CreatedBy string
+}
+
+// MemberAddress is the address of a Member.
+type MemberAddress struct {
+	City string
+	Zip  string
+}
+
+// Invite takes its role from the path rather than the body.
+type Invite struct {
+	TenantID string
+	Email    string
+	Role     string
+}
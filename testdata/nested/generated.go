@@ -1,8 +1,18 @@
 // Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:387f8be14691d24c33d3135b47847f2f8e6d656f4058501ae6f951b0d4df5118
+// Checksum:      sha256:18a26c5532864699826ede05b4d02e2d4f4807c71ce93e97796effea7d74e041
 
 package nested
 
-// ConvertUserRequestToUser converts UserRequest to User
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertUserRequestToUser converts UserRequest to User.
+// If src is nil, dst is left unchanged.
 func ConvertUserRequestToUser(src *UserRequest, dst *User) {
 	if src == nil {
 		return
@@ -12,7 +22,8 @@ func ConvertUserRequestToUser(src *UserRequest, dst *User) {
 	ConvertAddressRequestToAddress(&src.Address, &dst.Address)
 }
 
-// ConvertAddressRequestToAddress converts AddressRequest to Address
+// ConvertAddressRequestToAddress converts AddressRequest to Address.
+// If src is nil, dst is left unchanged.
 func ConvertAddressRequestToAddress(src *AddressRequest, dst *Address) {
 	if src == nil {
 		return
@@ -21,3 +32,13 @@ func ConvertAddressRequestToAddress(src *AddressRequest, dst *Address) {
 	dst.City = src.City
 	dst.ZipCode = src.ZipCode
 }
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertAddressRequestToAddress)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
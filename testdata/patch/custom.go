@@ -0,0 +1,10 @@
+package patch
+
+import "strings"
+
+// Field hooks are shared with conversions and called by patches alike.
+func ConvertUserPatchRoleToUserRole(src *UserPatch, dst *User) {
+	if src.Role != nil {
+		dst.Role = Role(strings.ToLower(*src.Role))
+	}
+}
@@ -0,0 +1,152 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:b1f5fe91520afbbe8a6de1beefa53d860ac8254789f93a6fa453b930e915c33b
+// Checksum:      sha256:3d383ca22a2c4bbc84d73e551ef86c707a0247ea634a9ae29526bde00ddcc238
+
+package patch
+
+import (
+	"time"
+)
+
+// ApplyUserPatchToUser applies the fields set in UserPatch to User.
+// If src is nil, dst is left unchanged.
+// Nil pointers, slices and maps in src leave dst unchanged.
+// Nested structs are merged field by field and maps key by key.
+func ApplyUserPatchToUser(src *UserPatch, dst *User) {
+	if src == nil {
+		return
+	}
+
+	if src.Name != nil {
+		dst.Name = *src.Name
+	}
+	if src.Age != nil {
+		dst.Age = *src.Age
+	}
+	dst.Email = src.Email
+	if src.Nickname != nil {
+		dst.Nickname = src.Nickname
+	}
+	if src.Tags != nil {
+		dst.Tags = src.Tags
+	}
+	if src.Labels != nil {
+		if dst.Labels == nil {
+			dst.Labels = make(map[string]string, len(src.Labels))
+		}
+		for k, v := range src.Labels {
+			dst.Labels[k] = v
+		}
+	}
+	if src.Address != nil {
+		if dst.Address == nil {
+			dst.Address = new(Address)
+		}
+		ApplyAddressPatchToAddress(src.Address, dst.Address)
+	}
+	if src.Settings != nil {
+		if dst.Settings == nil {
+			dst.Settings = make(map[string]Setting, len(src.Settings))
+		}
+		for k, v := range src.Settings {
+			merged := dst.Settings[k]
+			ApplySettingPatchToSetting(&v, &merged)
+			dst.Settings[k] = merged
+		}
+	}
+	ApplyProfileToProfile(&src.Profile, &dst.Profile)
+	ConvertUserPatchRoleToUserRole(src, dst)
+}
+
+// ApplyPreferencesFormToPreferences applies the fields set in PreferencesForm to Preferences.
+// If src is nil, dst is left unchanged.
+// Nil and zero-valued fields of src leave dst unchanged.
+// Nested structs are merged field by field and maps key by key.
+func ApplyPreferencesFormToPreferences(src *PreferencesForm, dst *Preferences) {
+	if src == nil {
+		return
+	}
+
+	if src.Theme != "" {
+		dst.Theme = src.Theme
+	}
+	if src.PageSize != 0 {
+		dst.PageSize = src.PageSize
+	}
+	if src.Beta {
+		dst.Beta = src.Beta
+	}
+	if src.UpdatedAt != (time.Time{}) {
+		dst.UpdatedAt = src.UpdatedAt
+	}
+	ApplyLimitsToLimits(&src.Limits, &dst.Limits)
+}
+
+// ApplyAddressPatchToAddress applies the fields set in AddressPatch to Address.
+// If src is nil, dst is left unchanged.
+// Nil pointers, slices and maps in src leave dst unchanged.
+// Nested structs are merged field by field and maps key by key.
+func ApplyAddressPatchToAddress(src *AddressPatch, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	if src.Street != nil {
+		dst.Street = *src.Street
+	}
+	if src.City != nil {
+		dst.City = *src.City
+	}
+}
+
+// ApplySettingPatchToSetting applies the fields set in SettingPatch to Setting.
+// If src is nil, dst is left unchanged.
+// Nil pointers, slices and maps in src leave dst unchanged.
+// Nested structs are merged field by field and maps key by key.
+func ApplySettingPatchToSetting(src *SettingPatch, dst *Setting) {
+	if src == nil {
+		return
+	}
+
+	if src.Value != nil {
+		dst.Value = *src.Value
+	}
+	if src.Enabled != nil {
+		dst.Enabled = *src.Enabled
+	}
+}
+
+// ApplyProfileToProfile applies the fields set in Profile to Profile.
+// If src is nil, dst is left unchanged.
+// Nil pointers, slices and maps in src leave dst unchanged.
+// Nested structs are merged field by field and maps key by key.
+func ApplyProfileToProfile(src *Profile, dst *Profile) {
+	if src == nil {
+		return
+	}
+
+	dst.Bio = src.Bio
+	if src.Website != nil {
+		dst.Website = src.Website
+	}
+}
+
+// ApplyLimitsToLimits applies the fields set in Limits to Limits.
+// If src is nil, dst is left unchanged.
+// Nil and zero-valued fields of src leave dst unchanged.
+// Nested structs are merged field by field and maps key by key.
+func ApplyLimitsToLimits(src *Limits, dst *Limits) {
+	if src == nil {
+		return
+	}
+
+	if src.MaxItems != 0 {
+		dst.MaxItems = src.MaxItems
+	}
+	if src.MaxSize != 0 {
+		dst.MaxSize = src.MaxSize
+	}
+}
@@ -0,0 +1,88 @@
+package patch
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestApplyUserPatch(t *testing.T) {
+	website := ptr("https://example.com")
+	dst := User{
+		Name:     "John",
+		Age:      30,
+		Email:    "john@example.com",
+		Tags:     []string{"admin"},
+		Labels:   map[string]string{"team": "core", "tier": "gold"},
+		Address:  &Address{Street: "1st Ave", City: "Tokyo"},
+		Settings: map[string]Setting{"mail": {Value: "daily", Enabled: true}},
+		Profile:  Profile{Bio: "old", Website: website},
+		Role:     "admin",
+	}
+
+	ApplyUserPatchToUser(&UserPatch{
+		Age:      ptr(31),
+		Email:    "john@example.org",
+		Labels:   map[string]string{"tier": "silver"},
+		Address:  &AddressPatch{City: ptr("Osaka")},
+		Settings: map[string]SettingPatch{"mail": {Enabled: ptr(false)}, "sms": {Value: ptr("never")}},
+		Profile:  Profile{Bio: "new"},
+		Role:     ptr("Owner"),
+	}, &dst)
+
+	want := User{
+		Name:     "John",
+		Age:      31,
+		Email:    "john@example.org",
+		Tags:     []string{"admin"},
+		Labels:   map[string]string{"team": "core", "tier": "silver"},
+		Address:  &Address{Street: "1st Ave", City: "Osaka"},
+		Settings: map[string]Setting{"mail": {Value: "daily"}, "sms": {Value: "never"}},
+		Profile:  Profile{Bio: "new", Website: website},
+		Role:     "owner",
+	}
+
+	if !reflect.DeepEqual(dst, want) {
+		t.Errorf("dst = %+v, want %+v", dst, want)
+	}
+}
+
+func TestApplyUserPatchAllocates(t *testing.T) {
+	var dst User
+
+	ApplyUserPatchToUser(&UserPatch{
+		Labels:  map[string]string{"team": "core"},
+		Address: &AddressPatch{City: ptr("Osaka")},
+	}, &dst)
+
+	if dst.Labels["team"] != "core" {
+		t.Errorf("Labels = %v, want team=core", dst.Labels)
+	}
+
+	if dst.Address == nil || dst.Address.City != "Osaka" {
+		t.Errorf("Address = %+v, want City %q", dst.Address, "Osaka")
+	}
+
+	// A nil patch changes nothing.
+	ApplyUserPatchToUser(nil, &dst)
+
+	if dst.Address.City != "Osaka" {
+		t.Errorf("Address = %+v after nil patch", dst.Address)
+	}
+}
+
+func TestApplyZeroAsUnset(t *testing.T) {
+	updated := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	dst := Preferences{Theme: "dark", PageSize: 20, Beta: true, UpdatedAt: updated, Limits: Limits{MaxItems: 10, MaxSize: 1 << 20}}
+
+	ApplyPreferencesFormToPreferences(&PreferencesForm{PageSize: 50, Limits: Limits{MaxItems: 100}}, &dst)
+
+	want := Preferences{Theme: "dark", PageSize: 50, Beta: true, UpdatedAt: updated, Limits: Limits{MaxItems: 100, MaxSize: 1 << 20}}
+	if dst != want {
+		t.Errorf("dst = %+v, want %+v", dst, want)
+	}
+}
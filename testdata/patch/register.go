@@ -0,0 +1,10 @@
+//go:build gonverter
+
+package patch
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterPatch[UserPatch, User]()
+var _ = runtime.RegisterPatch[PreferencesForm, Preferences](runtime.WithZeroAsUnset())
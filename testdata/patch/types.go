@@ -0,0 +1,79 @@
+package patch
+
+import "time"
+
+// Patch types
+type UserPatch struct {
+	Name     *string
+	Age      *int
+	Email    string
+	Nickname *string
+	Tags     []string
+	Labels   map[string]string
+	Address  *AddressPatch
+	Settings map[string]SettingPatch
+	Profile  Profile
+	Role     *string
+}
+
+type AddressPatch struct {
+	Street *string
+	City   *string
+}
+
+type SettingPatch struct {
+	Value   *string
+	Enabled *bool
+}
+
+type PreferencesForm struct {
+	Theme     string
+	PageSize  int
+	Beta      bool
+	UpdatedAt time.Time
+	Limits    Limits
+}
+
+// Target types
+type User struct {
+	Name     string
+	Age      int
+	Email    string
+	Nickname *string
+	Tags     []string
+	Labels   map[string]string
+	Address  *Address
+	Settings map[string]Setting
+	Profile  Profile
+	Role     Role
+}
+
+type Address struct {
+	Street string
+	City   string
+}
+
+type Setting struct {
+	Value   string
+	Enabled bool
+}
+
+type Profile struct {
+	Bio     string
+	Website *string
+}
+
+type Role string
+
+type Preferences struct {
+	Theme     string
+	PageSize  int
+	Beta      bool
+	UpdatedAt time.Time
+	Limits    Limits
+}
+
+type Limits struct {
+	MaxItems int
+	MaxSize  int64
+}
@@ -1,8 +1,18 @@
 // Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:6454b9e38ca2f76140a179a566d52bd570fdd5706091b880fde766838da823d3
+// Checksum:      sha256:31e5c49ba7d8c8bf18cd174e3d8cae42b42a06e36af7dbeb9b17c44cfc08149d
 
 package pointer
 
-// ConvertUserRequestToUser converts UserRequest to User
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertUserRequestToUser converts UserRequest to User.
+// If src is nil, dst is left unchanged.
 func ConvertUserRequestToUser(src *UserRequest, dst *User) {
 	if src == nil {
 		return
@@ -15,7 +25,8 @@ func ConvertUserRequestToUser(src *UserRequest, dst *User) {
 	}
 }
 
-// ConvertProfileRequestToProfile converts ProfileRequest to Profile
+// ConvertProfileRequestToProfile converts ProfileRequest to Profile.
+// If src is nil, dst is left unchanged.
 func ConvertProfileRequestToProfile(src *ProfileRequest, dst *Profile) {
 	if src == nil {
 		return
@@ -24,3 +35,13 @@ func ConvertProfileRequestToProfile(src *ProfileRequest, dst *Profile) {
 	dst.Bio = src.Bio
 	dst.Age = src.Age
 }
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertProfileRequestToProfile)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,43 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:bb62a4682904d3b63acd90c1b890fc22e7effdb4bd1594af072a7de817d32780
+// Checksum:      sha256:9599f5b79e3a49da1ae7868bc456d45440112881ac3671df7b09b112bcc88e70
+
+package scheme
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertUserRequestToUser converts UserRequest to User.
+// If src is nil, dst is left unchanged.
+func ConvertUserRequestToUser(src *UserRequest, dst *User) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	ConvertAddressRequestToAddress(&src.Address, &dst.Address)
+}
+
+// ConvertAddressRequestToAddress converts AddressRequest to Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressRequestToAddress(src *AddressRequest, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertUserRequestToUser)
+	runtime.AddConversion(s, ConvertAddressRequestToAddress)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
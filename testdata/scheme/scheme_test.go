@@ -0,0 +1,60 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+)
+
+func TestDefaultSchemeConvert(t *testing.T) {
+	src := &UserRequest{Name: "John Doe", Address: AddressRequest{City: "Tokyo"}}
+
+	var dst User
+	if err := runtime.DefaultScheme.Convert(src, &dst); err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if dst.Name != "John Doe" {
+		t.Errorf("Name = %q, want %q", dst.Name, "John Doe")
+	}
+
+	if dst.Address.City != "Tokyo" {
+		t.Errorf("Address.City = %q, want %q", dst.Address.City, "Tokyo")
+	}
+}
+
+func TestGenericConvert(t *testing.T) {
+	user, err := runtime.Convert[*UserRequest, *User](&UserRequest{Name: "Jane"})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if user.Name != "Jane" {
+		t.Errorf("Name = %q, want %q", user.Name, "Jane")
+	}
+}
+
+func TestNestedPairIsRegistered(t *testing.T) {
+	addr, err := runtime.Convert[AddressRequest, Address](AddressRequest{City: "Osaka"})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	if addr.City != "Osaka" {
+		t.Errorf("City = %q, want %q", addr.City, "Osaka")
+	}
+}
+
+func TestRegisterConversionsIntoCustomScheme(t *testing.T) {
+	s := runtime.NewScheme()
+	RegisterConversions(s)
+
+	user, err := runtime.ConvertWith[*UserRequest, *User](s, &UserRequest{Name: "Custom"})
+	if err != nil {
+		t.Fatalf("ConvertWith() error = %v", err)
+	}
+
+	if user.Name != "Custom" {
+		t.Errorf("Name = %q, want %q", user.Name, "Custom")
+	}
+}
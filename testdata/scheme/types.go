@@ -0,0 +1,21 @@
+package scheme
+
+// Source types
+type UserRequest struct {
+	Name    string
+	Address AddressRequest
+}
+
+type AddressRequest struct {
+	City string
+}
+
+// Target types
+type User struct {
+	Name    string
+	Address Address
+}
+
+type Address struct {
+	City string
+}
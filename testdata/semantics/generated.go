@@ -0,0 +1,94 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:6168c20b8e4b8fe54c16573376f54c43799455fef38f066d9bd78145a677f518
+// Checksum:      sha256:cc13f118563c8db2d9f637c9e7db30d9de98412b931470a54fdd30f1206db0ba
+
+package semantics
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertOrderRequestToOrder converts OrderRequest to Order.
+// If src is nil, dst is reset to its zero value.
+// Otherwise dst is reset to its zero value before fields are mapped.
+// Nil slices and maps in src become empty ones in dst.
+func ConvertOrderRequestToOrder(src *OrderRequest, dst *Order) {
+	if src == nil {
+		*dst = Order{}
+		return
+	}
+
+	*dst = Order{}
+
+	dst.ID = src.ID
+	dst.Tags = src.Tags
+	if dst.Tags == nil {
+		dst.Tags = []string{}
+	}
+	dst.Items = make([]Item, len(src.Items))
+	for i := range src.Items {
+		ConvertItemRequestToItem(&src.Items[i], &dst.Items[i])
+	}
+	dst.Labels = src.Labels
+	if dst.Labels == nil {
+		dst.Labels = map[string]string{}
+	}
+	dst.Extras = make(map[string]Item, len(src.Extras))
+	for k, v := range src.Extras {
+		var converted Item
+		ConvertItemRequestToItem(&v, &converted)
+		dst.Extras[k] = converted
+	}
+}
+
+// ConvertCartRequestToCart converts CartRequest to Cart.
+// If src is nil, dst is left unchanged.
+// Otherwise dst is reset to its zero value before fields are mapped.
+func ConvertCartRequestToCart(src *CartRequest, dst *Cart) {
+	if src == nil {
+		return
+	}
+
+	*dst = Cart{}
+
+	dst.Owner = src.Owner
+	if src.Items != nil {
+		dst.Items = make([]Item, len(src.Items))
+		for i := range src.Items {
+			ConvertItemRequestToItem(&src.Items[i], &dst.Items[i])
+		}
+	}
+}
+
+// ConvertItemRequestToItem converts ItemRequest to Item.
+// If src is nil, dst is reset to its zero value.
+// Otherwise dst is reset to its zero value before fields are mapped.
+// Nil slices and maps in src become empty ones in dst.
+func ConvertItemRequestToItem(src *ItemRequest, dst *Item) {
+	if src == nil {
+		*dst = Item{}
+		return
+	}
+
+	*dst = Item{}
+
+	dst.SKU = src.SKU
+	dst.Options = src.Options
+	if dst.Options == nil {
+		dst.Options = []string{}
+	}
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertOrderRequestToOrder)
+	runtime.AddConversion(s, ConvertCartRequestToCart)
+	runtime.AddConversion(s, ConvertItemRequestToItem)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
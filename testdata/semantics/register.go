@@ -0,0 +1,10 @@
+//go:build gonverter
+
+package semantics
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register[*OrderRequest, *Order](runtime.WithResetDst(), runtime.WithEmptyCollections())
+var _ = runtime.Register[*CartRequest, *Cart](runtime.WithResetDst(), runtime.WithPreserveOnNil())
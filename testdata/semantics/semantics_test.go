@@ -0,0 +1,53 @@
+package semantics
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEmptyCollections(t *testing.T) {
+	var dst Order
+	ConvertOrderRequestToOrder(&OrderRequest{ID: "1", Items: []ItemRequest{{SKU: "a"}}}, &dst)
+
+	got, err := json.Marshal(dst)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"id":"1","tags":[],"items":[{"sku":"a","options":[]}],"labels":{},"extras":{}}`
+	if string(got) != want {
+		t.Errorf("json = %s, want %s", got, want)
+	}
+}
+
+func TestResetDst(t *testing.T) {
+	dst := Order{ID: "old", Tags: []string{"stale"}}
+	ConvertOrderRequestToOrder(&OrderRequest{ID: "new"}, &dst)
+
+	if dst.ID != "new" || len(dst.Tags) != 0 {
+		t.Errorf("dst = %+v, want ID %q and no tags", dst, "new")
+	}
+
+	// Without WithPreserveOnNil a nil source resets dst as well.
+	ConvertOrderRequestToOrder(nil, &dst)
+
+	if dst.ID != "" || dst.Tags != nil {
+		t.Errorf("dst = %+v, want zero value", dst)
+	}
+}
+
+func TestPreserveOnNil(t *testing.T) {
+	dst := Cart{Owner: "old", Items: []Item{{SKU: "stale"}}}
+	ConvertCartRequestToCart(nil, &dst)
+
+	if dst.Owner != "old" || len(dst.Items) != 1 {
+		t.Errorf("dst = %+v, want it unchanged", dst)
+	}
+
+	// A non-nil source still resets dst, so no stale items remain.
+	ConvertCartRequestToCart(&CartRequest{Owner: "new"}, &dst)
+
+	if dst.Owner != "new" || dst.Items != nil {
+		t.Errorf("dst = %+v, want Owner %q and nil items", dst, "new")
+	}
+}
@@ -0,0 +1,39 @@
+package semantics
+
+// Source types
+type OrderRequest struct {
+	ID     string
+	Tags   []string
+	Items  []ItemRequest
+	Labels map[string]string
+	Extras map[string]ItemRequest
+}
+
+type ItemRequest struct {
+	SKU     string
+	Options []string
+}
+
+type CartRequest struct {
+	Owner string
+	Items []ItemRequest
+}
+
+// Target types
+type Order struct {
+	ID     string            `json:"id"`
+	Tags   []string          `json:"tags"`
+	Items  []Item            `json:"items"`
+	Labels map[string]string `json:"labels"`
+	Extras map[string]Item   `json:"extras"`
+}
+
+type Item struct {
+	SKU     string   `json:"sku"`
+	Options []string `json:"options"`
+}
+
+type Cart struct {
+	Owner string
+	Items []Item
+}
@@ -1,8 +1,18 @@
 // Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:d68bbaed84f9dedb31b473617783ff6caea96609a13dcde1d85a8d7165a8022a
+// Checksum:      sha256:fc10b5d592f1edf73c34d1868f9409a47bfc869ac1de5388b14bc2394ae57b7e
 
 package slice
 
-// ConvertTeamRequestToTeam converts TeamRequest to Team
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertTeamRequestToTeam converts TeamRequest to Team.
+// If src is nil, dst is left unchanged.
 func ConvertTeamRequestToTeam(src *TeamRequest, dst *Team) {
 	if src == nil {
 		return
@@ -17,7 +27,8 @@ func ConvertTeamRequestToTeam(src *TeamRequest, dst *Team) {
 	}
 }
 
-// ConvertMemberRequestToMember converts MemberRequest to Member
+// ConvertMemberRequestToMember converts MemberRequest to Member.
+// If src is nil, dst is left unchanged.
 func ConvertMemberRequestToMember(src *MemberRequest, dst *Member) {
 	if src == nil {
 		return
@@ -26,3 +37,13 @@ func ConvertMemberRequestToMember(src *MemberRequest, dst *Member) {
 	dst.Name = src.Name
 	dst.Role = src.Role
 }
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertTeamRequestToTeam)
+	runtime.AddConversion(s, ConvertMemberRequestToMember)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,20 @@
+package split
+
+import "errors"
+
+// ErrNoItems is returned when an order without items is stored.
+var ErrNoItems = errors.New("order has no items")
+
+// ConvertOrderItemCountToOrderRowItemCount fills the field the order does not have.
+func ConvertOrderItemCountToOrderRowItemCount(src *Order, dst *OrderRow) {
+	dst.ItemCount = len(src.Items)
+}
+
+// AfterConvertOrderToOrderRowAndOrderItems rejects orders without items.
+func AfterConvertOrderToOrderRowAndOrderItems(_ *Order, _ *OrderRow, items *OrderItems) error {
+	if len(items.Items) == 0 {
+		return ErrNoItems
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:f94b694f318d9d1615fb613d354dd6790169365547404ecb0468c523ab51520a
+// Checksum:      sha256:e68233866551c7e09322585d873105d075fe372c81db16d6e070177bf1daa763
+
+package split
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertOrderToOrderRowAndOrderItems converts Order to OrderRow and OrderItems.
+// If src is nil, the destinations are left unchanged.
+func ConvertOrderToOrderRowAndOrderItems(src *Order, orderRow *OrderRow, orderItems *OrderItems) error {
+	if src == nil {
+		return nil
+	}
+
+	id := src.ID
+
+	orderRow.ID = id
+	orderRow.CustomerID = src.CustomerID
+	orderRow.Total = src.Total
+	ConvertOrderItemCountToOrderRowItemCount(src, orderRow)
+
+	orderItems.ID = id
+	if src.Items != nil {
+		orderItems.Items = make([]ItemRow, len(src.Items))
+		for i := range src.Items {
+			ConvertItemToItemRow(&src.Items[i], &orderItems.Items[i])
+		}
+	}
+
+	if err := AfterConvertOrderToOrderRowAndOrderItems(src, orderRow, orderItems); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ConvertItemToItemRow converts Item to ItemRow.
+// If src is nil, dst is left unchanged.
+func ConvertItemToItemRow(src *Item, dst *ItemRow) {
+	if src == nil {
+		return
+	}
+
+	dst.SKU = src.SKU
+	dst.Quantity = int64(src.Quantity)
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertItemToItemRow)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,9 @@
+//go:build gonverter
+
+package split
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.RegisterSplit2[*Order, *OrderRow, *OrderItems]()
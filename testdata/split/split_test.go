@@ -0,0 +1,41 @@
+package split
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConvertOrderToOrderRowAndOrderItems(t *testing.T) {
+	order := &Order{
+		ID:         "o1",
+		CustomerID: "c1",
+		Items:      []Item{{SKU: "a", Quantity: 2}, {SKU: "b", Quantity: 1}},
+		Total:      300,
+	}
+
+	var (
+		row   OrderRow
+		items OrderItems
+	)
+
+	if err := ConvertOrderToOrderRowAndOrderItems(order, &row, &items); err != nil {
+		t.Fatalf("ConvertOrderToOrderRowAndOrderItems() error = %v", err)
+	}
+
+	if want := (OrderRow{ID: "o1", CustomerID: "c1", Total: 300, ItemCount: 2}); row != want {
+		t.Errorf("row = %+v, want %+v", row, want)
+	}
+
+	if items.ID != "o1" || len(items.Items) != 2 || items.Items[1] != (ItemRow{SKU: "b", Quantity: 1}) {
+		t.Errorf("items = %+v", items)
+	}
+
+	// After hooks receive every destination.
+	if err := ConvertOrderToOrderRowAndOrderItems(&Order{ID: "o2"}, &OrderRow{}, &OrderItems{}); !errors.Is(err, ErrNoItems) {
+		t.Errorf("ConvertOrderToOrderRowAndOrderItems(no items) error = %v, want %v", err, ErrNoItems)
+	}
+
+	if err := ConvertOrderToOrderRowAndOrderItems(nil, &row, &items); err != nil || row.ID != "o1" {
+		t.Errorf("ConvertOrderToOrderRowAndOrderItems(nil) = %+v, %v, want row unchanged", row, err)
+	}
+}
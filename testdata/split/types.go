@@ -0,0 +1,37 @@
+package split
+
+// Order is an aggregate stored as a row of the orders table and rows of the
+// order_items table.
+type Order struct {
+	ID         string
+	CustomerID string
+	Items      []Item
+	Total      int64
+	Note       string
+}
+
+// Item is a line item of an Order.
+type Item struct {
+	SKU      string
+	Quantity int32
+}
+
+// OrderRow is the row of an Order in the orders table.
+type OrderRow struct {
+	ID         string
+	CustomerID string
+	Total      int64
+	ItemCount  int
+}
+
+// OrderItems are the rows of an Order in the order_items table.
+type OrderItems struct {
+	ID    string
+	Items []ItemRow
+}
+
+// ItemRow is a row of the order_items table.
+type ItemRow struct {
+	SKU      string
+	Quantity int64
+}
@@ -0,0 +1,5 @@
+package validation
+
+func ConvertCustomerRequestEmailToCustomerEmail(src *CustomerRequest, dst *Customer) {
+	dst.Email = Email(src.Email)
+}
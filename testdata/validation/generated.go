@@ -0,0 +1,166 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:015f476d4e358a32fcdf29b06541bd99faaab1bb8ebef6528b50790be388e059
+// Checksum:      sha256:28655003e381ed5afe7010ad6bc08f2bfda5114306a895d80f62522b0233f779
+
+package validation
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertOrderRequestToOrder converts OrderRequest to Order.
+// If src is nil, dst is left unchanged.
+func ConvertOrderRequestToOrder(src *OrderRequest, dst *Order) error {
+	if src == nil {
+		return nil
+	}
+
+	dst.ID = src.ID
+	ConvertCustomerRequestToCustomer(&src.Customer, &dst.Customer)
+	if src.Items != nil {
+		dst.Items = make([]Item, len(src.Items))
+		for i := range src.Items {
+			ConvertItemRequestToItem(&src.Items[i], &dst.Items[i])
+		}
+	}
+	if src.Shipping != nil {
+		dst.Shipping = new(Address)
+		ConvertAddressRequestToAddress(src.Shipping, dst.Shipping)
+	}
+	if src.Tags != nil {
+		dst.Tags = make(map[string]Tag, len(src.Tags))
+		for k, v := range src.Tags {
+			var converted Tag
+			ConvertTagRequestToTag(&v, &converted)
+			dst.Tags[k] = converted
+		}
+	}
+	dst.Returns = src.Returns
+
+	var v runtime.Validation
+	validateOrder(&v, "", dst)
+
+	return v.Err()
+}
+
+// ToOrder returns src converted to a new Order, or nil if src is nil.
+func ToOrder(src *OrderRequest) (*Order, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := new(Order)
+	if err := ConvertOrderRequestToOrder(src, dst); err != nil {
+		return nil, err
+	}
+
+	return dst, nil
+}
+
+// ToOrders converts every element of src to Order. It returns nil if src is nil,
+// and stops at the first error.
+func ToOrders(src []OrderRequest) ([]Order, error) {
+	if src == nil {
+		return nil, nil
+	}
+
+	dst := make([]Order, len(src))
+	for i := range src {
+		if err := ConvertOrderRequestToOrder(&src[i], &dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return dst, nil
+}
+
+// ConvertItemRequestToItem converts ItemRequest to Item.
+// If src is nil, dst is left unchanged.
+func ConvertItemRequestToItem(src *ItemRequest, dst *Item) {
+	if src == nil {
+		return
+	}
+
+	dst.SKU = src.SKU
+	dst.Quantity = src.Quantity
+}
+
+// ConvertCustomerRequestToCustomer converts CustomerRequest to Customer.
+// If src is nil, dst is left unchanged.
+func ConvertCustomerRequestToCustomer(src *CustomerRequest, dst *Customer) {
+	if src == nil {
+		return
+	}
+
+	dst.Name = src.Name
+	ConvertCustomerRequestEmailToCustomerEmail(src, dst)
+}
+
+// ConvertAddressRequestToAddress converts AddressRequest to Address.
+// If src is nil, dst is left unchanged.
+func ConvertAddressRequestToAddress(src *AddressRequest, dst *Address) {
+	if src == nil {
+		return
+	}
+
+	dst.City = src.City
+	dst.Zip = src.Zip
+}
+
+// ConvertTagRequestToTag converts TagRequest to Tag.
+// If src is nil, dst is left unchanged.
+func ConvertTagRequestToTag(src *TagRequest, dst *Tag) {
+	if src == nil {
+		return
+	}
+
+	dst.Label = src.Label
+}
+
+// validateOrder calls Validate on dst and on the values nested in it that
+// implement runtime.Validator, and records their errors in v under path.
+func validateOrder(v *runtime.Validation, path string, dst *Order) {
+	v.Check(path, dst)
+	validateCustomer(v, runtime.FieldPath(path, "Customer"), &dst.Customer)
+	for i := range dst.Items {
+		validateItem(v, runtime.IndexPath(runtime.FieldPath(path, "Items"), i), &dst.Items[i])
+	}
+	if dst.Shipping != nil {
+		validateAddress(v, runtime.FieldPath(path, "Shipping"), dst.Shipping)
+	}
+	for i := range dst.Returns {
+		validateItem(v, runtime.IndexPath(runtime.FieldPath(path, "Returns"), i), &dst.Returns[i])
+	}
+}
+
+// validateCustomer calls Validate on the values nested in dst that
+// implement runtime.Validator, and records their errors in v under path.
+func validateCustomer(v *runtime.Validation, path string, dst *Customer) {
+	v.Check(runtime.FieldPath(path, "Email"), &dst.Email)
+}
+
+// validateItem calls Validate on dst and records its error in v under path.
+func validateItem(v *runtime.Validation, path string, dst *Item) {
+	v.Check(path, dst)
+}
+
+// validateAddress calls Validate on dst and records its error in v under path.
+func validateAddress(v *runtime.Validation, path string, dst *Address) {
+	v.Check(path, dst)
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddFallibleConversion(s, ConvertOrderRequestToOrder)
+	runtime.AddConversion(s, ConvertItemRequestToItem)
+	runtime.AddConversion(s, ConvertCustomerRequestToCustomer)
+	runtime.AddConversion(s, ConvertAddressRequestToAddress)
+	runtime.AddConversion(s, ConvertTagRequestToTag)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
@@ -0,0 +1,10 @@
+//go:build gonverter
+
+package validation
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register[*OrderRequest, *Order](runtime.WithValidation(), runtime.WithConstructor())
+var _ = runtime.Register[*ItemRequest, *Item]()
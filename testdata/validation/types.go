@@ -0,0 +1,60 @@
+package validation
+
+type OrderRequest struct {
+	ID       string
+	Customer CustomerRequest
+	Items    []ItemRequest
+	Shipping *AddressRequest
+	Tags     map[string]TagRequest
+	Returns  Lines
+}
+
+type CustomerRequest struct {
+	Name  string
+	Email string
+}
+
+type ItemRequest struct {
+	SKU      string
+	Quantity int
+}
+
+type AddressRequest struct {
+	City string
+	Zip  string
+}
+
+type TagRequest struct {
+	Label string
+}
+
+type Order struct {
+	ID       string
+	Customer Customer
+	Items    []Item
+	Shipping *Address
+	Tags     map[string]Tag
+	Returns  Lines
+}
+
+type Customer struct {
+	Name  string
+	Email Email
+}
+
+type Item struct {
+	SKU      string
+	Quantity int
+}
+
+// Lines is a named slice, whose items are validated like those of []Item.
+type Lines []Item
+
+type Address struct {
+	City string
+	Zip  string
+}
+
+type Tag struct {
+	Label string
+}
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrRequired        = errors.New("required")
+	ErrInvalidEmail    = errors.New("invalid email")
+	ErrInvalidQuantity = errors.New("quantity must be positive")
+)
+
+// Email is validated on its own, wherever it is nested.
+type Email string
+
+func (e Email) Validate() error {
+	if !strings.Contains(string(e), "@") {
+		return ErrInvalidEmail
+	}
+
+	return nil
+}
+
+func (o *Order) Validate() error {
+	if o.ID == "" {
+		return ErrRequired
+	}
+
+	return nil
+}
+
+func (i Item) Validate() error {
+	if i.Quantity <= 0 {
+		return ErrInvalidQuantity
+	}
+
+	return nil
+}
+
+func (a *Address) Validate() error {
+	if a.Zip == "" {
+		return ErrRequired
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package validation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+)
+
+func TestConvertOrderRequestToOrder(t *testing.T) {
+	got, err := ToOrder(&OrderRequest{
+		ID:       "1",
+		Customer: CustomerRequest{Name: "John", Email: "john@example.com"},
+		Items:    []ItemRequest{{SKU: "a", Quantity: 1}},
+		Shipping: &AddressRequest{City: "Tokyo", Zip: "100-0001"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.ID != "1" || got.Customer.Email != "john@example.com" || got.Shipping.Zip != "100-0001" {
+		t.Errorf("ToOrder() = %+v", got)
+	}
+}
+
+func TestConvertOrderRequestToOrderAggregatesErrors(t *testing.T) {
+	var dst Order
+
+	err := ConvertOrderRequestToOrder(&OrderRequest{
+		Customer: CustomerRequest{Email: "john"},
+		Items:    []ItemRequest{{SKU: "a", Quantity: 1}, {SKU: "b"}},
+		Shipping: &AddressRequest{City: "Tokyo"},
+		Returns:  Lines{{SKU: "c"}},
+	}, &dst)
+
+	var errs runtime.ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error = %v, want runtime.ValidationErrors", err)
+	}
+
+	want := []struct {
+		path string
+		err  error
+	}{
+		{"", ErrRequired},
+		{"Customer.Email", ErrInvalidEmail},
+		{"Items[1]", ErrInvalidQuantity},
+		{"Shipping", ErrRequired},
+		{"Returns[0]", ErrInvalidQuantity},
+	}
+
+	if len(errs) != len(want) {
+		t.Fatalf("errors = %v, want %d errors", errs, len(want))
+	}
+
+	for i, w := range want {
+		if errs[i].Path != w.path || !errors.Is(errs[i], w.err) {
+			t.Errorf("errors[%d] = %v, want %s: %v", i, errs[i], w.path, w.err)
+		}
+	}
+
+	// dst is converted even if it is invalid.
+	if len(dst.Items) != 2 {
+		t.Errorf("Items = %v, want 2 items", dst.Items)
+	}
+}
+
+func TestConvertItemRequestToItemDoesNotValidate(t *testing.T) {
+	var dst Item
+
+	// Only the registration with WithValidation validates.
+	ConvertItemRequestToItem(&ItemRequest{SKU: "a"}, &dst)
+
+	if dst.SKU != "a" {
+		t.Errorf("SKU = %q, want a", dst.SKU)
+	}
+}
+
+func TestSchemeConvertValidates(t *testing.T) {
+	var dst Order
+
+	err := runtime.DefaultScheme.Convert(&OrderRequest{ID: "1", Customer: CustomerRequest{Email: "john"}}, &dst)
+	if !errors.Is(err, ErrInvalidEmail) {
+		t.Errorf("Convert() error = %v, want ErrInvalidEmail", err)
+	}
+}
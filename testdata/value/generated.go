@@ -0,0 +1,66 @@
+// Code generated by gonverter. DO NOT EDIT.
+//
+// Version:       0.1.0
+// Registrations: register.go
+// Inputs:        sha256:6ebcfa1399038818ab44138439b0b31a33f688560b98634d3b56f273c4a49a58
+// Checksum:      sha256:6af60212548e40ee22a7a5ff5e4d03a38f608c0c262d253f77600171299034dc
+
+package value
+
+import (
+	"github.com/sivchari/gonverter/runtime"
+)
+
+// ConvertAccountRequestToAccount converts AccountRequest to Account.
+// If src is nil, dst is left unchanged.
+func ConvertAccountRequestToAccount(src *AccountRequest, dst *Account) {
+	if src == nil {
+		return
+	}
+
+	dst.ID = nil
+	if src.ID != nil {
+		dst.ID = new(string)
+		*dst.ID = string(*src.ID)
+	}
+	runtime.Deref(src.Nickname, &dst.Nickname)
+	dst.Age = 0
+	if src.Age != nil {
+		dst.Age = int64(*src.Age)
+	}
+	runtime.Ref(src.Score, &dst.Score)
+	dst.Level = new(int64)
+	*dst.Level = int64(src.Level)
+	dst.Owner = UserID(src.Owner)
+	dst.Tags = Tags(src.Tags)
+}
+
+// ConvertAccountRequestToStrictAccount converts AccountRequest to StrictAccount.
+// If src is nil, dst is left unchanged.
+// Nil pointers in src are an error for fields of dst that are not pointers.
+func ConvertAccountRequestToStrictAccount(src *AccountRequest, dst *StrictAccount) error {
+	if src == nil {
+		return nil
+	}
+
+	if src.Nickname == nil {
+		return &runtime.FieldError{Path: "Nickname", Err: runtime.ErrNilPointer}
+	}
+	dst.Nickname = *src.Nickname
+	if src.Age == nil {
+		return &runtime.FieldError{Path: "Age", Err: runtime.ErrNilPointer}
+	}
+	dst.Age = int64(*src.Age)
+
+	return nil
+}
+
+// RegisterConversions adds the generated conversion functions to s.
+func RegisterConversions(s *runtime.Scheme) {
+	runtime.AddConversion(s, ConvertAccountRequestToAccount)
+	runtime.AddFallibleConversion(s, ConvertAccountRequestToStrictAccount)
+}
+
+func init() {
+	RegisterConversions(runtime.DefaultScheme)
+}
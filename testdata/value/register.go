@@ -0,0 +1,10 @@
+//go:build gonverter
+
+package value
+
+import "github.com/sivchari/gonverter/runtime"
+
+//go:generate go run ../../cmd/gonverter/main.go .
+
+var _ = runtime.Register[*AccountRequest, *Account](runtime.WithBuiltins(runtime.BuiltinPointers))
+var _ = runtime.Register[*AccountRequest, *StrictAccount](runtime.WithBuiltins(runtime.BuiltinPointers), runtime.WithNilAsError())
@@ -0,0 +1,33 @@
+package value
+
+type UserID string
+
+type Tags []string
+
+// AccountRequest has fields of other types than those of Account, which are
+// dereferenced, allocated and converted.
+type AccountRequest struct {
+	ID       *UserID
+	Nickname *string
+	Age      *int32
+	Score    int
+	Level    int16
+	Owner    string
+	Tags     []string
+}
+
+type Account struct {
+	ID       *string
+	Nickname string
+	Age      int64
+	Score    *int
+	Level    *int64
+	Owner    UserID
+	Tags     Tags
+}
+
+// StrictAccount requires the pointer fields of AccountRequest to be set.
+type StrictAccount struct {
+	Nickname string
+	Age      int64
+}
@@ -0,0 +1,50 @@
+package value
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sivchari/gonverter/runtime"
+)
+
+func TestConvertAccountRequestToAccount(t *testing.T) {
+	id, nickname, age := UserID("u1"), "jd", int32(30)
+	src := &AccountRequest{ID: &id, Nickname: &nickname, Age: &age, Score: 7, Level: 3, Owner: "admin", Tags: []string{"a"}}
+
+	var dst Account
+
+	ConvertAccountRequestToAccount(src, &dst)
+
+	if dst.ID == nil || *dst.ID != "u1" || dst.Nickname != "jd" || dst.Age != 30 || *dst.Score != 7 || *dst.Level != 3 ||
+		dst.Owner != "admin" || len(dst.Tags) != 1 {
+		t.Fatalf("ConvertAccountRequestToAccount() = %+v", dst)
+	}
+
+	// The destination does not share memory with the source.
+	*src.ID = "u2"
+	src.Score = 8
+
+	if *dst.ID != "u1" || *dst.Score != 7 {
+		t.Errorf("dst changed with src: %+v", dst)
+	}
+
+	// Nil pointers become nil and zero values.
+	ConvertAccountRequestToAccount(&AccountRequest{}, &dst)
+
+	if dst.ID != nil || dst.Nickname != "" || dst.Age != 0 {
+		t.Errorf("ConvertAccountRequestToAccount(nil fields) = %+v", dst)
+	}
+}
+
+func TestConvertAccountRequestToStrictAccount(t *testing.T) {
+	nickname := "jd"
+
+	var dst StrictAccount
+
+	err := ConvertAccountRequestToStrictAccount(&AccountRequest{Nickname: &nickname}, &dst)
+
+	var fieldErr *runtime.FieldError
+	if !errors.As(err, &fieldErr) || fieldErr.Path != "Age" || !errors.Is(err, runtime.ErrNilPointer) {
+		t.Errorf("error = %v, want ErrNilPointer for Age", err)
+	}
+}
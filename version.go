@@ -1,5 +1,7 @@
 // Package gonverter provides type-safe code generation for Go struct conversions.
 package gonverter
 
-// Version is the current version of govalid.
-const Version = "0.1.0"
+import "github.com/sivchari/gonverter/internal/gonverter"
+
+// Version is the current version of gonverter.
+const Version = gonverter.Version